@@ -20,12 +20,17 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"strings"
+	"time"
 
 	pb "github.com/datacommonsorg/mixer/internal/proto"
 	"github.com/datacommonsorg/mixer/internal/server"
 	"github.com/datacommonsorg/mixer/internal/server/healthcheck"
 	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/store/geo"
 	"github.com/datacommonsorg/mixer/internal/store/memdb"
+	"github.com/datacommonsorg/mixer/internal/store/sqldb"
 	"golang.org/x/oauth2/google"
 
 	"cloud.google.com/go/bigquery"
@@ -47,7 +52,16 @@ var (
 	// BigQuery (Sparql)
 	useBigquery = flag.Bool("use_bigquery", true, "Use Bigquery to serve Sparql Query")
 	bqDataset   = flag.String("bq_dataset", "", "DataCommons BigQuery dataset.")
-	schemaPath  = flag.String("schema_path", "", "The directory that contains the schema mapping files")
+	schemaPath  = flag.String("schema_path", "", "The local directory or GCS folder (gs://bucket/folder) that contains the schema mapping files")
+	bqMaxBytes  = flag.Int64("bq_max_bytes", 0, "Maximum number of bytes a Sparql query can process. No limit if 0.")
+	// Local SQLite database (Sparql), used in place of BigQuery.
+	sqliteDataDir = flag.String("sqlite_data_dir", "", "The directory that contains the CSV files of the tables to serve Sparql Query from a local SQLite database.")
+	// Reload of the schema mapping files when they change.
+	watchSchema        = flag.Bool("watch_schema", false, "Reload the schema mapping files when they change")
+	schemaPollInterval = flag.Duration("schema_poll_interval", 30*time.Second, "How often to check local schema mapping files for change.")
+	// Cache of Sparql query results.
+	queryCacheSize = flag.Int("query_cache_size", 0, "Maximum number of Sparql query results to cache. No caching if 0.")
+	queryCacheTTL  = flag.Duration("query_cache_ttl", 10*time.Minute, "How long a cached Sparql query result is served.")
 	// Base Bigtable Cache
 	useBaseBt     = flag.Bool("use_base_bt", true, "Use base bigtable cache")
 	baseTableName = flag.String("base_table", "", "Base cache Bigtable table.")
@@ -61,6 +75,16 @@ var (
 	// Specify what services to serve
 	serveMixerService = flag.Bool("serve_mixer_service", true, "Serve Mixer service")
 	serveReconService = flag.Bool("serve_recon_service", false, "Serve Recon service")
+	reconBoundaryDir  = flag.String("recon_boundary_dir", "", "The local directory of place boundaries, as GeoJSON files or shapefiles, to resolve coordinates without Bigtable.")
+	useCentroidIndex  = flag.Bool("use_centroid_index", false, "Find nearby places with an index of place centroids built at startup")
+	centroidCsv       = flag.String("centroid_csv", "", "The local CSV file of place centroids to build the centroid index from, instead of Bigtable.")
+	useReconNameIndex = flag.Bool("use_recon_name_index", false, "Resolve entities by name with an index of place names built at startup")
+	searchIndexCsv    = flag.String("search_index_csv", "", "The local CSV file of entity names to serve Search from an index built at startup, instead of BigQuery.")
+	// GraphQL endpoint, served over HTTP along with the Mixer service.
+	graphqlPort = flag.Int("graphql_port", 0, "Port on which to serve GraphQL. Disabled if 0.")
+	// Admin API, served over HTTP along with the Mixer service.
+	adminPort = flag.Int("admin_port", 0, "Port on which to serve the admin API. Disabled if 0.")
+	adminHost = flag.String("admin_host", "localhost", "Host on which to serve the admin API, which has no authentication. Only local clients can reach it by default.")
 )
 
 const (
@@ -75,8 +99,29 @@ const (
 	// GCS Pubsub
 	tmcfCsvPubsubTopic      = "tmcf-csv-reload"
 	tmcfCsvSubscriberPrefix = "tmcf-csv-subscriber-"
+	// Schema mapping Pubsub, for schema mapping files in GCS.
+	schemaMappingPubsubTopic      = "schema-mapping-reload"
+	schemaMappingSubscriberPrefix = "schema-mapping-subscriber-"
+	// Timeouts of the HTTP endpoints, so that slow clients do not hold
+	// connections forever. Writes allow for slow queries.
+	httpReadHeaderTimeout = 10 * time.Second
+	httpReadTimeout       = 30 * time.Second
+	httpWriteTimeout      = 5 * time.Minute
+	httpIdleTimeout       = 2 * time.Minute
 )
 
+// newHTTPServer creates an HTTP server with the timeouts of the HTTP endpoints.
+func newHTTPServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+		ReadTimeout:       httpReadTimeout,
+		WriteTimeout:      httpWriteTimeout,
+		IdleTimeout:       httpIdleTimeout,
+	}
+}
+
 func main() {
 	log.Println("Enter mixer main() function")
 	// Parse flag
@@ -126,6 +171,21 @@ func main() {
 		}
 	}
 
+	// Place centroid index
+	var centroids *geo.CentroidIndex
+	if *useCentroidIndex {
+		if *centroidCsv != "" {
+			centroids, err = geo.LoadCentroidCSV(*centroidCsv)
+		} else if baseTable != nil {
+			centroids, err = geo.LoadCentroidIndex(ctx, baseTable)
+		} else {
+			err = fmt.Errorf("no Bigtable or CSV file to load place centroids from")
+		}
+		if err != nil {
+			log.Fatalf("Failed to build centroid index: %v", err)
+		}
+	}
+
 	if *serveMixerService {
 		// TMCF + CSV from GCS
 		memDb := memdb.NewMemDb()
@@ -155,6 +215,21 @@ func main() {
 			if err != nil {
 				log.Fatalf("Failed to create metadata: %v", err)
 			}
+			metadata.BqMaxBytes = *bqMaxBytes
+		}
+		// Local SQLite database.
+		var sqliteDb *sqldb.SQLite
+		if *sqliteDataDir != "" {
+			if metadata == nil {
+				metadata, err = server.NewMetadata(*bqDataset, *storeProject, branchBtInstance, *schemaPath)
+				if err != nil {
+					log.Fatalf("Failed to create metadata: %v", err)
+				}
+			}
+			sqliteDb, err = sqldb.NewSQLite(metadata.Mappings, *sqliteDataDir)
+			if err != nil {
+				log.Fatalf("Failed to create SQLite database: %v", err)
+			}
 		}
 
 		// Branch Bigtable cache
@@ -173,6 +248,20 @@ func main() {
 
 		// Create server object
 		mixerServer := server.NewMixerServer(bqClient, baseTable, branchTable, metadata, cache, memDb)
+		if sqliteDb != nil {
+			mixerServer.SetSQLDb(sqliteDb)
+		}
+		if *queryCacheSize > 0 {
+			mixerServer.SetQueryCache(sqldb.NewCache(*queryCacheSize, *queryCacheTTL))
+		}
+		if centroids != nil {
+			mixerServer.SetCentroidIndex(centroids)
+		}
+		if *searchIndexCsv != "" {
+			if err := mixerServer.LoadSearchIndex(*searchIndexCsv); err != nil {
+				log.Fatalf("Failed to build search index: %v", err)
+			}
+		}
 		pb.RegisterMixerServer(srv, mixerServer)
 
 		// Subscribe to branch cache update
@@ -183,11 +272,56 @@ func main() {
 				log.Fatalf("Failed to subscribe to branch cache update: %v", err)
 			}
 		}
+
+		// Reload schema mapping on change
+		if *watchSchema && metadata != nil {
+			if strings.HasPrefix(*schemaPath, "gs://") {
+				err = mixerServer.SubscribeMappingUpdate(
+					ctx, *mixerProject, schemaMappingSubscriberPrefix, schemaMappingPubsubTopic)
+			} else {
+				err = mixerServer.WatchMappings(ctx, *schemaPollInterval)
+			}
+			if err != nil {
+				log.Fatalf("Failed to watch schema mapping: %v", err)
+			}
+		}
+
+		// GraphQL endpoint
+		if *graphqlPort > 0 {
+			mux := http.NewServeMux()
+			mux.Handle("/graphql", mixerServer.GraphQLHandler())
+			go func() {
+				err := newHTTPServer(fmt.Sprintf(":%d", *graphqlPort), mux).ListenAndServe()
+				log.Fatalf("Failed to serve GraphQL: %v", err)
+			}()
+		}
+
+		// Admin endpoint
+		if *adminPort > 0 {
+			go func() {
+				err := newHTTPServer(
+					fmt.Sprintf("%s:%d", *adminHost, *adminPort), mixerServer.AdminHandler()).ListenAndServe()
+				log.Fatalf("Failed to serve admin API: %v", err)
+			}()
+		}
 	}
 
 	// Register for Recon Service.
 	if *serveReconService {
 		reconServer := server.NewReconServer(baseTable)
+		if *useReconNameIndex {
+			if err := reconServer.LoadNameIndex(ctx); err != nil {
+				log.Fatalf("Failed to build name index: %v", err)
+			}
+		}
+		if centroids != nil {
+			reconServer.SetCentroidIndex(centroids)
+		}
+		if *reconBoundaryDir != "" {
+			if err := reconServer.LoadBoundaryIndex(*reconBoundaryDir); err != nil {
+				log.Fatalf("Failed to build boundary index: %v", err)
+			}
+		}
 		pb.RegisterReconServer(srv, reconServer)
 	}
 
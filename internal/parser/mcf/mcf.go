@@ -28,7 +28,7 @@ func ParseMapping(mcf, database string) ([]*types.Mapping, error) {
 	lines := strings.Split(mcf, "\n")
 	mappings := []*types.Mapping{}
 	var sub string
-	for _, line := range lines {
+	for i, line := range lines {
 		line = strings.TrimSpace(line)
 		if strings.HasPrefix(line, "#") || line == "" {
 			continue
@@ -36,7 +36,7 @@ func ParseMapping(mcf, database string) ([]*types.Mapping, error) {
 		parts := strings.SplitN(line, ": ", 2)
 		if len(parts) < 2 {
 			return nil, status.Errorf(
-				codes.InvalidArgument, "invalid schema mapping mcf:\n%s", mcf)
+				codes.InvalidArgument, "invalid schema mapping mcf at line %d: %s", i+1, line)
 		}
 		head := strings.TrimSpace(parts[0])
 		body := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(parts[1]), `"`), `"`)
@@ -119,6 +119,62 @@ func (x *EntityInfo) GetValue() string {
 	return ""
 }
 
+// Basic info for a collection of entities.
+type EntityInfoCollection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entities   []*EntityInfo `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	TotalCount int64         `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Before potential truncation.
+}
+
+func (x *EntityInfoCollection) Reset() {
+	*x = EntityInfoCollection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_entity_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntityInfoCollection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityInfoCollection) ProtoMessage() {}
+
+func (x *EntityInfoCollection) ProtoReflect() protoreflect.Message {
+	mi := &file_entity_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityInfoCollection.ProtoReflect.Descriptor instead.
+func (*EntityInfoCollection) Descriptor() ([]byte, []int) {
+	return file_entity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EntityInfoCollection) GetEntities() []*EntityInfo {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *EntityInfoCollection) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
 type IdWithProperty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -131,7 +187,7 @@ type IdWithProperty struct {
 func (x *IdWithProperty) Reset() {
 	*x = IdWithProperty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_entity_proto_msgTypes[1]
+		mi := &file_entity_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -144,7 +200,7 @@ func (x *IdWithProperty) String() string {
 func (*IdWithProperty) ProtoMessage() {}
 
 func (x *IdWithProperty) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_proto_msgTypes[1]
+	mi := &file_entity_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -157,7 +213,7 @@ func (x *IdWithProperty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IdWithProperty.ProtoReflect.Descriptor instead.
 func (*IdWithProperty) Descriptor() ([]byte, []int) {
-	return file_entity_proto_rawDescGZIP(), []int{1}
+	return file_entity_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *IdWithProperty) GetProp() string {
@@ -185,7 +241,7 @@ type EntityIds struct {
 func (x *EntityIds) Reset() {
 	*x = EntityIds{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_entity_proto_msgTypes[2]
+		mi := &file_entity_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -198,7 +254,7 @@ func (x *EntityIds) String() string {
 func (*EntityIds) ProtoMessage() {}
 
 func (x *EntityIds) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_proto_msgTypes[2]
+	mi := &file_entity_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,7 +267,7 @@ func (x *EntityIds) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EntityIds.ProtoReflect.Descriptor instead.
 func (*EntityIds) Descriptor() ([]byte, []int) {
-	return file_entity_proto_rawDescGZIP(), []int{2}
+	return file_entity_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *EntityIds) GetIds() []*IdWithProperty {
@@ -238,7 +294,7 @@ type EntitySubGraph struct {
 func (x *EntitySubGraph) Reset() {
 	*x = EntitySubGraph{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_entity_proto_msgTypes[3]
+		mi := &file_entity_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -251,7 +307,7 @@ func (x *EntitySubGraph) String() string {
 func (*EntitySubGraph) ProtoMessage() {}
 
 func (x *EntitySubGraph) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_proto_msgTypes[3]
+	mi := &file_entity_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +320,7 @@ func (x *EntitySubGraph) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EntitySubGraph.ProtoReflect.Descriptor instead.
 func (*EntitySubGraph) Descriptor() ([]byte, []int) {
-	return file_entity_proto_rawDescGZIP(), []int{3}
+	return file_entity_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *EntitySubGraph) GetSourceId() string {
@@ -323,7 +379,7 @@ type EntityPair struct {
 func (x *EntityPair) Reset() {
 	*x = EntityPair{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_entity_proto_msgTypes[4]
+		mi := &file_entity_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -336,7 +392,7 @@ func (x *EntityPair) String() string {
 func (*EntityPair) ProtoMessage() {}
 
 func (x *EntityPair) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_proto_msgTypes[4]
+	mi := &file_entity_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,7 +405,7 @@ func (x *EntityPair) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EntityPair.ProtoReflect.Descriptor instead.
 func (*EntityPair) Descriptor() ([]byte, []int) {
-	return file_entity_proto_rawDescGZIP(), []int{4}
+	return file_entity_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *EntityPair) GetEntityOne() *EntitySubGraph {
@@ -379,36 +435,43 @@ var file_entity_proto_rawDesc = []byte{
 	0x63, 0x69, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63,
 	0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76,
 	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x36,
-	0x0a, 0x0e, 0x49, 0x64, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79,
-	0x12, 0x12, 0x0a, 0x04, 0x70, 0x72, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x70, 0x72, 0x6f, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x76, 0x61, 0x6c, 0x22, 0x3a, 0x0a, 0x09, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x49, 0x64, 0x73, 0x12, 0x2d, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x49,
-	0x64, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x03, 0x69,
-	0x64, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x0e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x75, 0x62,
-	0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x49, 0x64, 0x12, 0x34, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x5f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x73, 0x2e, 0x4d, 0x63, 0x66, 0x47, 0x72, 0x61, 0x70, 0x68, 0x48, 0x00, 0x52, 0x08,
-	0x73, 0x75, 0x62, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x37, 0x0a, 0x0a, 0x65, 0x6e, 0x74, 0x69,
-	0x74, 0x79, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x49, 0x64, 0x73, 0x48, 0x00, 0x52, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64,
-	0x73, 0x42, 0x16, 0x0a, 0x14, 0x67, 0x72, 0x61, 0x70, 0x68, 0x5f, 0x72, 0x65, 0x70, 0x72, 0x65,
-	0x73, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x0a, 0x45, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x50, 0x61, 0x69, 0x72, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x6e, 0x74, 0x69,
-	0x74, 0x79, 0x5f, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x53, 0x75, 0x62, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x4f, 0x6e, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x74,
-	0x77, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x75, 0x62,
-	0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x77, 0x6f,
-	0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6c,
+	0x0a, 0x14, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x36, 0x0a, 0x0e,
+	0x49, 0x64, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x72, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x72,
+	0x6f, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x76, 0x61, 0x6c, 0x22, 0x3a, 0x0a, 0x09, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64,
+	0x73, 0x12, 0x2d, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x49, 0x64, 0x57,
+	0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x03, 0x69, 0x64, 0x73,
+	0x22, 0xb4, 0x01, 0x0a, 0x0e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x75, 0x62, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x34, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x5f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x73, 0x2e, 0x4d, 0x63, 0x66, 0x47, 0x72, 0x61, 0x70, 0x68, 0x48, 0x00, 0x52, 0x08, 0x73, 0x75,
+	0x62, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x37, 0x0a, 0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49,
+	0x64, 0x73, 0x48, 0x00, 0x52, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x73, 0x42,
+	0x16, 0x0a, 0x14, 0x67, 0x72, 0x61, 0x70, 0x68, 0x5f, 0x72, 0x65, 0x70, 0x72, 0x65, 0x73, 0x65,
+	0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x0a, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x50, 0x61, 0x69, 0x72, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x5f, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53,
+	0x75, 0x62, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4f,
+	0x6e, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x77, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x75, 0x62, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x52, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x77, 0x6f, 0x42, 0x09,
+	0x5a, 0x07, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -423,26 +486,28 @@ func file_entity_proto_rawDescGZIP() []byte {
 	return file_entity_proto_rawDescData
 }
 
-var file_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_entity_proto_goTypes = []interface{}{
-	(*EntityInfo)(nil),     // 0: datacommons.EntityInfo
-	(*IdWithProperty)(nil), // 1: datacommons.IdWithProperty
-	(*EntityIds)(nil),      // 2: datacommons.EntityIds
-	(*EntitySubGraph)(nil), // 3: datacommons.EntitySubGraph
-	(*EntityPair)(nil),     // 4: datacommons.EntityPair
-	(*McfGraph)(nil),       // 5: datacommons.McfGraph
+	(*EntityInfo)(nil),           // 0: datacommons.EntityInfo
+	(*EntityInfoCollection)(nil), // 1: datacommons.EntityInfoCollection
+	(*IdWithProperty)(nil),       // 2: datacommons.IdWithProperty
+	(*EntityIds)(nil),            // 3: datacommons.EntityIds
+	(*EntitySubGraph)(nil),       // 4: datacommons.EntitySubGraph
+	(*EntityPair)(nil),           // 5: datacommons.EntityPair
+	(*McfGraph)(nil),             // 6: datacommons.McfGraph
 }
 var file_entity_proto_depIdxs = []int32{
-	1, // 0: datacommons.EntityIds.ids:type_name -> datacommons.IdWithProperty
-	5, // 1: datacommons.EntitySubGraph.sub_graph:type_name -> datacommons.McfGraph
-	2, // 2: datacommons.EntitySubGraph.entity_ids:type_name -> datacommons.EntityIds
-	3, // 3: datacommons.EntityPair.entity_one:type_name -> datacommons.EntitySubGraph
-	3, // 4: datacommons.EntityPair.entity_two:type_name -> datacommons.EntitySubGraph
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	0, // 0: datacommons.EntityInfoCollection.entities:type_name -> datacommons.EntityInfo
+	2, // 1: datacommons.EntityIds.ids:type_name -> datacommons.IdWithProperty
+	6, // 2: datacommons.EntitySubGraph.sub_graph:type_name -> datacommons.McfGraph
+	3, // 3: datacommons.EntitySubGraph.entity_ids:type_name -> datacommons.EntityIds
+	4, // 4: datacommons.EntityPair.entity_one:type_name -> datacommons.EntitySubGraph
+	4, // 5: datacommons.EntityPair.entity_two:type_name -> datacommons.EntitySubGraph
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_entity_proto_init() }
@@ -465,7 +530,7 @@ func file_entity_proto_init() {
 			}
 		}
 		file_entity_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IdWithProperty); i {
+			switch v := v.(*EntityInfoCollection); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -477,7 +542,7 @@ func file_entity_proto_init() {
 			}
 		}
 		file_entity_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EntityIds); i {
+			switch v := v.(*IdWithProperty); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -489,7 +554,7 @@ func file_entity_proto_init() {
 			}
 		}
 		file_entity_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EntitySubGraph); i {
+			switch v := v.(*EntityIds); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -501,6 +566,18 @@ func file_entity_proto_init() {
 			}
 		}
 		file_entity_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntitySubGraph); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_entity_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EntityPair); i {
 			case 0:
 				return &v.state
@@ -513,7 +590,7 @@ func file_entity_proto_init() {
 			}
 		}
 	}
-	file_entity_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_entity_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*EntitySubGraph_SubGraph)(nil),
 		(*EntitySubGraph_EntityIds)(nil),
 	}
@@ -523,7 +600,7 @@ func file_entity_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_entity_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
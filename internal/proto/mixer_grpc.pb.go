@@ -17,7 +17,7 @@ const _ = grpc.SupportPackageIsVersion6
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MixerClient interface {
-	// Query DataCommons Graph with Sparql.
+	// Query DataCommons Graph with Sparql or datalog.
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	// Fetch property labels adjacent of nodes
 	GetPropertyLabels(ctx context.Context, in *GetPropertyLabelsRequest, opts ...grpc.CallOption) (*GetPropertyLabelsResponse, error)
@@ -25,6 +25,14 @@ type MixerClient interface {
 	GetPropertyValues(ctx context.Context, in *GetPropertyValuesRequest, opts ...grpc.CallOption) (*GetPropertyValuesResponse, error)
 	// Fetch triples that have the given nodes as subject or object.
 	GetTriples(ctx context.Context, in *GetTriplesRequest, opts ...grpc.CallOption) (*GetTriplesResponse, error)
+	// Traverse the graph from the given nodes along a path of properties.
+	Traverse(ctx context.Context, in *TraverseRequest, opts ...grpc.CallOption) (*TraverseResponse, error)
+	// Find the shortest paths that connect two nodes.
+	FindPaths(ctx context.Context, in *FindPathsRequest, opts ...grpc.CallOption) (*FindPathsResponse, error)
+	// Get the hierarchy and properties of types.
+	GetTypeSchema(ctx context.Context, in *GetTypeSchemaRequest, opts ...grpc.CallOption) (*GetTypeSchemaResponse, error)
+	// Get the domain, range and other metadata of properties.
+	GetPropertySchema(ctx context.Context, in *GetPropertySchemaRequest, opts ...grpc.CallOption) (*GetPropertySchemaResponse, error)
 	// Get places contained in parent places.
 	GetPlacesIn(ctx context.Context, in *GetPlacesInRequest, opts ...grpc.CallOption) (*GetPlacesInResponse, error)
 	// Get stats of places by StatisticalVariable. If multiple time series data
@@ -98,6 +106,9 @@ type MixerClient interface {
 	GetStatVarPath(ctx context.Context, in *GetStatVarPathRequest, opts ...grpc.CallOption) (*GetStatVarPathResponse, error)
 	// Search stat var and stat var groups.
 	SearchStatVar(ctx context.Context, in *SearchStatVarRequest, opts ...grpc.CallOption) (*SearchStatVarResponse, error)
+	// Find stat vars by their population type, measured property, stat type and
+	// constraint property values.
+	LookupStatVar(ctx context.Context, in *LookupStatVarRequest, opts ...grpc.CallOption) (*LookupStatVarResponse, error)
 	// Given a list of stat vars, get their summaries.
 	GetStatVarSummary(ctx context.Context, in *GetStatVarSummaryRequest, opts ...grpc.CallOption) (*GetStatVarSummaryResponse, error)
 }
@@ -146,6 +157,42 @@ func (c *mixerClient) GetTriples(ctx context.Context, in *GetTriplesRequest, opt
 	return out, nil
 }
 
+func (c *mixerClient) Traverse(ctx context.Context, in *TraverseRequest, opts ...grpc.CallOption) (*TraverseResponse, error) {
+	out := new(TraverseResponse)
+	err := c.cc.Invoke(ctx, "/datacommons.Mixer/Traverse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixerClient) FindPaths(ctx context.Context, in *FindPathsRequest, opts ...grpc.CallOption) (*FindPathsResponse, error) {
+	out := new(FindPathsResponse)
+	err := c.cc.Invoke(ctx, "/datacommons.Mixer/FindPaths", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixerClient) GetTypeSchema(ctx context.Context, in *GetTypeSchemaRequest, opts ...grpc.CallOption) (*GetTypeSchemaResponse, error) {
+	out := new(GetTypeSchemaResponse)
+	err := c.cc.Invoke(ctx, "/datacommons.Mixer/GetTypeSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mixerClient) GetPropertySchema(ctx context.Context, in *GetPropertySchemaRequest, opts ...grpc.CallOption) (*GetPropertySchemaResponse, error) {
+	out := new(GetPropertySchemaResponse)
+	err := c.cc.Invoke(ctx, "/datacommons.Mixer/GetPropertySchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mixerClient) GetPlacesIn(ctx context.Context, in *GetPlacesInRequest, opts ...grpc.CallOption) (*GetPlacesInResponse, error) {
 	out := new(GetPlacesInResponse)
 	err := c.cc.Invoke(ctx, "/datacommons.Mixer/GetPlacesIn", in, out, opts...)
@@ -380,6 +427,15 @@ func (c *mixerClient) SearchStatVar(ctx context.Context, in *SearchStatVarReques
 	return out, nil
 }
 
+func (c *mixerClient) LookupStatVar(ctx context.Context, in *LookupStatVarRequest, opts ...grpc.CallOption) (*LookupStatVarResponse, error) {
+	out := new(LookupStatVarResponse)
+	err := c.cc.Invoke(ctx, "/datacommons.Mixer/LookupStatVar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mixerClient) GetStatVarSummary(ctx context.Context, in *GetStatVarSummaryRequest, opts ...grpc.CallOption) (*GetStatVarSummaryResponse, error) {
 	out := new(GetStatVarSummaryResponse)
 	err := c.cc.Invoke(ctx, "/datacommons.Mixer/GetStatVarSummary", in, out, opts...)
@@ -393,7 +449,7 @@ func (c *mixerClient) GetStatVarSummary(ctx context.Context, in *GetStatVarSumma
 // All implementations should embed UnimplementedMixerServer
 // for forward compatibility
 type MixerServer interface {
-	// Query DataCommons Graph with Sparql.
+	// Query DataCommons Graph with Sparql or datalog.
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	// Fetch property labels adjacent of nodes
 	GetPropertyLabels(context.Context, *GetPropertyLabelsRequest) (*GetPropertyLabelsResponse, error)
@@ -401,6 +457,14 @@ type MixerServer interface {
 	GetPropertyValues(context.Context, *GetPropertyValuesRequest) (*GetPropertyValuesResponse, error)
 	// Fetch triples that have the given nodes as subject or object.
 	GetTriples(context.Context, *GetTriplesRequest) (*GetTriplesResponse, error)
+	// Traverse the graph from the given nodes along a path of properties.
+	Traverse(context.Context, *TraverseRequest) (*TraverseResponse, error)
+	// Find the shortest paths that connect two nodes.
+	FindPaths(context.Context, *FindPathsRequest) (*FindPathsResponse, error)
+	// Get the hierarchy and properties of types.
+	GetTypeSchema(context.Context, *GetTypeSchemaRequest) (*GetTypeSchemaResponse, error)
+	// Get the domain, range and other metadata of properties.
+	GetPropertySchema(context.Context, *GetPropertySchemaRequest) (*GetPropertySchemaResponse, error)
 	// Get places contained in parent places.
 	GetPlacesIn(context.Context, *GetPlacesInRequest) (*GetPlacesInResponse, error)
 	// Get stats of places by StatisticalVariable. If multiple time series data
@@ -474,6 +538,9 @@ type MixerServer interface {
 	GetStatVarPath(context.Context, *GetStatVarPathRequest) (*GetStatVarPathResponse, error)
 	// Search stat var and stat var groups.
 	SearchStatVar(context.Context, *SearchStatVarRequest) (*SearchStatVarResponse, error)
+	// Find stat vars by their population type, measured property, stat type and
+	// constraint property values.
+	LookupStatVar(context.Context, *LookupStatVarRequest) (*LookupStatVarResponse, error)
 	// Given a list of stat vars, get their summaries.
 	GetStatVarSummary(context.Context, *GetStatVarSummaryRequest) (*GetStatVarSummaryResponse, error)
 }
@@ -494,6 +561,18 @@ func (*UnimplementedMixerServer) GetPropertyValues(context.Context, *GetProperty
 func (*UnimplementedMixerServer) GetTriples(context.Context, *GetTriplesRequest) (*GetTriplesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTriples not implemented")
 }
+func (*UnimplementedMixerServer) Traverse(context.Context, *TraverseRequest) (*TraverseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Traverse not implemented")
+}
+func (*UnimplementedMixerServer) FindPaths(context.Context, *FindPathsRequest) (*FindPathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindPaths not implemented")
+}
+func (*UnimplementedMixerServer) GetTypeSchema(context.Context, *GetTypeSchemaRequest) (*GetTypeSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTypeSchema not implemented")
+}
+func (*UnimplementedMixerServer) GetPropertySchema(context.Context, *GetPropertySchemaRequest) (*GetPropertySchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPropertySchema not implemented")
+}
 func (*UnimplementedMixerServer) GetPlacesIn(context.Context, *GetPlacesInRequest) (*GetPlacesInResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPlacesIn not implemented")
 }
@@ -572,6 +651,9 @@ func (*UnimplementedMixerServer) GetStatVarPath(context.Context, *GetStatVarPath
 func (*UnimplementedMixerServer) SearchStatVar(context.Context, *SearchStatVarRequest) (*SearchStatVarResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchStatVar not implemented")
 }
+func (*UnimplementedMixerServer) LookupStatVar(context.Context, *LookupStatVarRequest) (*LookupStatVarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupStatVar not implemented")
+}
 func (*UnimplementedMixerServer) GetStatVarSummary(context.Context, *GetStatVarSummaryRequest) (*GetStatVarSummaryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatVarSummary not implemented")
 }
@@ -652,6 +734,78 @@ func _Mixer_GetTriples_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mixer_Traverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraverseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixerServer).Traverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacommons.Mixer/Traverse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixerServer).Traverse(ctx, req.(*TraverseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixer_FindPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindPathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixerServer).FindPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacommons.Mixer/FindPaths",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixerServer).FindPaths(ctx, req.(*FindPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixer_GetTypeSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTypeSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixerServer).GetTypeSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacommons.Mixer/GetTypeSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixerServer).GetTypeSchema(ctx, req.(*GetTypeSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mixer_GetPropertySchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPropertySchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixerServer).GetPropertySchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacommons.Mixer/GetPropertySchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixerServer).GetPropertySchema(ctx, req.(*GetPropertySchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Mixer_GetPlacesIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPlacesInRequest)
 	if err := dec(in); err != nil {
@@ -1120,6 +1274,24 @@ func _Mixer_SearchStatVar_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mixer_LookupStatVar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupStatVarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixerServer).LookupStatVar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacommons.Mixer/LookupStatVar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixerServer).LookupStatVar(ctx, req.(*LookupStatVarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Mixer_GetStatVarSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetStatVarSummaryRequest)
 	if err := dec(in); err != nil {
@@ -1158,6 +1330,22 @@ var _Mixer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTriples",
 			Handler:    _Mixer_GetTriples_Handler,
 		},
+		{
+			MethodName: "Traverse",
+			Handler:    _Mixer_Traverse_Handler,
+		},
+		{
+			MethodName: "FindPaths",
+			Handler:    _Mixer_FindPaths_Handler,
+		},
+		{
+			MethodName: "GetTypeSchema",
+			Handler:    _Mixer_GetTypeSchema_Handler,
+		},
+		{
+			MethodName: "GetPropertySchema",
+			Handler:    _Mixer_GetPropertySchema_Handler,
+		},
 		{
 			MethodName: "GetPlacesIn",
 			Handler:    _Mixer_GetPlacesIn_Handler,
@@ -1262,6 +1450,10 @@ var _Mixer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SearchStatVar",
 			Handler:    _Mixer_SearchStatVar_Handler,
 		},
+		{
+			MethodName: "LookupStatVar",
+			Handler:    _Mixer_LookupStatVar_Handler,
+		},
 		{
 			MethodName: "GetStatVarSummary",
 			Handler:    _Mixer_GetStatVarSummary_Handler,
@@ -136,62 +136,6 @@ func (x *CoordinateRecon) GetPlaces() []*CoordinateRecon_Place {
 	return nil
 }
 
-// Basic info for a collection of entities.
-type EntityInfoCollection struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Entities   []*EntityInfo `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
-	TotalCount int64         `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Before potential truncation.
-}
-
-func (x *EntityInfoCollection) Reset() {
-	*x = EntityInfoCollection{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *EntityInfoCollection) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*EntityInfoCollection) ProtoMessage() {}
-
-func (x *EntityInfoCollection) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use EntityInfoCollection.ProtoReflect.Descriptor instead.
-func (*EntityInfoCollection) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *EntityInfoCollection) GetEntities() []*EntityInfo {
-	if x != nil {
-		return x.Entities
-	}
-	return nil
-}
-
-func (x *EntityInfoCollection) GetTotalCount() int64 {
-	if x != nil {
-		return x.TotalCount
-	}
-	return 0
-}
-
 type CompareEntitiesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -203,7 +147,7 @@ type CompareEntitiesRequest struct {
 func (x *CompareEntitiesRequest) Reset() {
 	*x = CompareEntitiesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[3]
+		mi := &file_recon_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -216,7 +160,7 @@ func (x *CompareEntitiesRequest) String() string {
 func (*CompareEntitiesRequest) ProtoMessage() {}
 
 func (x *CompareEntitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[3]
+	mi := &file_recon_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +173,7 @@ func (x *CompareEntitiesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompareEntitiesRequest.ProtoReflect.Descriptor instead.
 func (*CompareEntitiesRequest) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{3}
+	return file_recon_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CompareEntitiesRequest) GetEntityPairs() []*EntityPair {
@@ -250,7 +194,7 @@ type CompareEntitiesResponse struct {
 func (x *CompareEntitiesResponse) Reset() {
 	*x = CompareEntitiesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[4]
+		mi := &file_recon_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -263,7 +207,7 @@ func (x *CompareEntitiesResponse) String() string {
 func (*CompareEntitiesResponse) ProtoMessage() {}
 
 func (x *CompareEntitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[4]
+	mi := &file_recon_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,7 +220,7 @@ func (x *CompareEntitiesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompareEntitiesResponse.ProtoReflect.Descriptor instead.
 func (*CompareEntitiesResponse) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{4}
+	return file_recon_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CompareEntitiesResponse) GetComparisons() []*CompareEntitiesResponse_Comparison {
@@ -299,7 +243,7 @@ type ResolveEntitiesRequest struct {
 func (x *ResolveEntitiesRequest) Reset() {
 	*x = ResolveEntitiesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[5]
+		mi := &file_recon_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -312,7 +256,7 @@ func (x *ResolveEntitiesRequest) String() string {
 func (*ResolveEntitiesRequest) ProtoMessage() {}
 
 func (x *ResolveEntitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[5]
+	mi := &file_recon_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,7 +269,7 @@ func (x *ResolveEntitiesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveEntitiesRequest.ProtoReflect.Descriptor instead.
 func (*ResolveEntitiesRequest) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{5}
+	return file_recon_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ResolveEntitiesRequest) GetEntities() []*EntitySubGraph {
@@ -353,7 +297,7 @@ type ResolveEntitiesResponse struct {
 func (x *ResolveEntitiesResponse) Reset() {
 	*x = ResolveEntitiesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[6]
+		mi := &file_recon_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -366,7 +310,7 @@ func (x *ResolveEntitiesResponse) String() string {
 func (*ResolveEntitiesResponse) ProtoMessage() {}
 
 func (x *ResolveEntitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[6]
+	mi := &file_recon_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,7 +323,7 @@ func (x *ResolveEntitiesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveEntitiesResponse.ProtoReflect.Descriptor instead.
 func (*ResolveEntitiesResponse) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{6}
+	return file_recon_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ResolveEntitiesResponse) GetResolvedEntities() []*ResolveEntitiesResponse_ResolvedEntity {
@@ -395,12 +339,14 @@ type ResolveCoordinatesRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Coordinates []*ResolveCoordinatesRequest_Coordinate `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
+	// If set, only places of these types are returned.
+	PlaceTypes []string `protobuf:"bytes,2,rep,name=place_types,json=placeTypes,proto3" json:"place_types,omitempty"`
 }
 
 func (x *ResolveCoordinatesRequest) Reset() {
 	*x = ResolveCoordinatesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[7]
+		mi := &file_recon_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -413,7 +359,7 @@ func (x *ResolveCoordinatesRequest) String() string {
 func (*ResolveCoordinatesRequest) ProtoMessage() {}
 
 func (x *ResolveCoordinatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[7]
+	mi := &file_recon_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +372,7 @@ func (x *ResolveCoordinatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveCoordinatesRequest.ProtoReflect.Descriptor instead.
 func (*ResolveCoordinatesRequest) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{7}
+	return file_recon_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ResolveCoordinatesRequest) GetCoordinates() []*ResolveCoordinatesRequest_Coordinate {
@@ -436,6 +382,13 @@ func (x *ResolveCoordinatesRequest) GetCoordinates() []*ResolveCoordinatesReques
 	return nil
 }
 
+func (x *ResolveCoordinatesRequest) GetPlaceTypes() []string {
+	if x != nil {
+		return x.PlaceTypes
+	}
+	return nil
+}
+
 type ResolveCoordinatesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -447,7 +400,7 @@ type ResolveCoordinatesResponse struct {
 func (x *ResolveCoordinatesResponse) Reset() {
 	*x = ResolveCoordinatesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[8]
+		mi := &file_recon_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -460,7 +413,7 @@ func (x *ResolveCoordinatesResponse) String() string {
 func (*ResolveCoordinatesResponse) ProtoMessage() {}
 
 func (x *ResolveCoordinatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[8]
+	mi := &file_recon_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,7 +426,7 @@ func (x *ResolveCoordinatesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveCoordinatesResponse.ProtoReflect.Descriptor instead.
 func (*ResolveCoordinatesResponse) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{8}
+	return file_recon_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ResolveCoordinatesResponse) GetPlaceCoordinates() []*ResolveCoordinatesResponse_PlaceCoordinate {
@@ -483,33 +436,36 @@ func (x *ResolveCoordinatesResponse) GetPlaceCoordinates() []*ResolveCoordinates
 	return nil
 }
 
-type ResolveIdsRequest struct {
+type ResolveGeometryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	InProp  string   `protobuf:"bytes,1,opt,name=in_prop,json=inProp,proto3" json:"in_prop,omitempty"`
-	OutProp string   `protobuf:"bytes,2,opt,name=out_prop,json=outProp,proto3" json:"out_prop,omitempty"`
-	Ids     []string `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	// Types that are assignable to Shape:
+	//	*ResolveGeometryRequest_GeoJson
+	//	*ResolveGeometryRequest_BoundingBox
+	Shape isResolveGeometryRequest_Shape `protobuf_oneof:"shape"`
+	// The type of the places to return, like "County".
+	PlaceType string `protobuf:"bytes,3,opt,name=place_type,json=placeType,proto3" json:"place_type,omitempty"`
 }
 
-func (x *ResolveIdsRequest) Reset() {
-	*x = ResolveIdsRequest{}
+func (x *ResolveGeometryRequest) Reset() {
+	*x = ResolveGeometryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[9]
+		mi := &file_recon_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResolveIdsRequest) String() string {
+func (x *ResolveGeometryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolveIdsRequest) ProtoMessage() {}
+func (*ResolveGeometryRequest) ProtoMessage() {}
 
-func (x *ResolveIdsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[9]
+func (x *ResolveGeometryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,57 +476,82 @@ func (x *ResolveIdsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolveIdsRequest.ProtoReflect.Descriptor instead.
-func (*ResolveIdsRequest) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ResolveGeometryRequest.ProtoReflect.Descriptor instead.
+func (*ResolveGeometryRequest) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ResolveIdsRequest) GetInProp() string {
-	if x != nil {
-		return x.InProp
+func (m *ResolveGeometryRequest) GetShape() isResolveGeometryRequest_Shape {
+	if m != nil {
+		return m.Shape
 	}
-	return ""
+	return nil
 }
 
-func (x *ResolveIdsRequest) GetOutProp() string {
-	if x != nil {
-		return x.OutProp
+func (x *ResolveGeometryRequest) GetGeoJson() string {
+	if x, ok := x.GetShape().(*ResolveGeometryRequest_GeoJson); ok {
+		return x.GeoJson
 	}
 	return ""
 }
 
-func (x *ResolveIdsRequest) GetIds() []string {
-	if x != nil {
-		return x.Ids
+func (x *ResolveGeometryRequest) GetBoundingBox() *ResolveGeometryRequest_LatLngRect {
+	if x, ok := x.GetShape().(*ResolveGeometryRequest_BoundingBox); ok {
+		return x.BoundingBox
 	}
 	return nil
 }
 
-type ResolveIdsResponse struct {
+func (x *ResolveGeometryRequest) GetPlaceType() string {
+	if x != nil {
+		return x.PlaceType
+	}
+	return ""
+}
+
+type isResolveGeometryRequest_Shape interface {
+	isResolveGeometryRequest_Shape()
+}
+
+type ResolveGeometryRequest_GeoJson struct {
+	// A GeoJSON Polygon or MultiPolygon geometry.
+	GeoJson string `protobuf:"bytes,1,opt,name=geo_json,json=geoJson,proto3,oneof"`
+}
+
+type ResolveGeometryRequest_BoundingBox struct {
+	BoundingBox *ResolveGeometryRequest_LatLngRect `protobuf:"bytes,2,opt,name=bounding_box,json=boundingBox,proto3,oneof"`
+}
+
+func (*ResolveGeometryRequest_GeoJson) isResolveGeometryRequest_Shape() {}
+
+func (*ResolveGeometryRequest_BoundingBox) isResolveGeometryRequest_Shape() {}
+
+type ResolveGeometryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Entities []*ResolveIdsResponse_Entity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	// Ordered by overlap fraction, from the largest.
+	Places []*ResolveGeometryResponse_Place `protobuf:"bytes,1,rep,name=places,proto3" json:"places,omitempty"`
 }
 
-func (x *ResolveIdsResponse) Reset() {
-	*x = ResolveIdsResponse{}
+func (x *ResolveGeometryResponse) Reset() {
+	*x = ResolveGeometryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[10]
+		mi := &file_recon_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResolveIdsResponse) String() string {
+func (x *ResolveGeometryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolveIdsResponse) ProtoMessage() {}
+func (*ResolveGeometryResponse) ProtoMessage() {}
 
-func (x *ResolveIdsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[10]
+func (x *ResolveGeometryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,46 +562,51 @@ func (x *ResolveIdsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolveIdsResponse.ProtoReflect.Descriptor instead.
-func (*ResolveIdsResponse) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ResolveGeometryResponse.ProtoReflect.Descriptor instead.
+func (*ResolveGeometryResponse) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ResolveIdsResponse) GetEntities() []*ResolveIdsResponse_Entity {
+func (x *ResolveGeometryResponse) GetPlaces() []*ResolveGeometryResponse_Place {
 	if x != nil {
-		return x.Entities
+		return x.Places
 	}
 	return nil
 }
 
-type ReconEntities_Entity struct {
+type FindNearbyPlacesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// All known IDs of the entity, including the DCID, excluding the ID in the
-	// recon request.
-	// NOTE: A single ID prop might be repeated.
-	Ids []*ReconEntities_Entity_ID `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// If set, only places within this distance are returned, in kilometers. It
+	// is at most 1000.
+	RadiusKm float64 `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	// If set, at most this number of the nearest places are returned.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// If set, only places of this type are returned.
+	PlaceType string `protobuf:"bytes,5,opt,name=place_type,json=placeType,proto3" json:"place_type,omitempty"`
 }
 
-func (x *ReconEntities_Entity) Reset() {
-	*x = ReconEntities_Entity{}
+func (x *FindNearbyPlacesRequest) Reset() {
+	*x = FindNearbyPlacesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[11]
+		mi := &file_recon_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReconEntities_Entity) String() string {
+func (x *FindNearbyPlacesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReconEntities_Entity) ProtoMessage() {}
+func (*FindNearbyPlacesRequest) ProtoMessage() {}
 
-func (x *ReconEntities_Entity) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[11]
+func (x *FindNearbyPlacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -631,44 +617,74 @@ func (x *ReconEntities_Entity) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReconEntities_Entity.ProtoReflect.Descriptor instead.
-func (*ReconEntities_Entity) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{0, 0}
+// Deprecated: Use FindNearbyPlacesRequest.ProtoReflect.Descriptor instead.
+func (*FindNearbyPlacesRequest) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ReconEntities_Entity) GetIds() []*ReconEntities_Entity_ID {
+func (x *FindNearbyPlacesRequest) GetLatitude() float64 {
 	if x != nil {
-		return x.Ids
+		return x.Latitude
 	}
-	return nil
+	return 0
 }
 
-type ReconEntities_Entity_ID struct {
+func (x *FindNearbyPlacesRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *FindNearbyPlacesRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *FindNearbyPlacesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FindNearbyPlacesRequest) GetPlaceType() string {
+	if x != nil {
+		return x.PlaceType
+	}
+	return ""
+}
+
+type FindNearbyPlacesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Prop string `protobuf:"bytes,1,opt,name=prop,proto3" json:"prop,omitempty"`
-	Val  string `protobuf:"bytes,2,opt,name=val,proto3" json:"val,omitempty"`
+	// Ordered from the nearest.
+	Places []*FindNearbyPlacesResponse_Place `protobuf:"bytes,1,rep,name=places,proto3" json:"places,omitempty"`
+	// Whether there are more places within radius_km than returned.
+	Truncated bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
 }
 
-func (x *ReconEntities_Entity_ID) Reset() {
-	*x = ReconEntities_Entity_ID{}
+func (x *FindNearbyPlacesResponse) Reset() {
+	*x = FindNearbyPlacesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[12]
+		mi := &file_recon_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReconEntities_Entity_ID) String() string {
+func (x *FindNearbyPlacesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReconEntities_Entity_ID) ProtoMessage() {}
+func (*FindNearbyPlacesResponse) ProtoMessage() {}
 
-func (x *ReconEntities_Entity_ID) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[12]
+func (x *FindNearbyPlacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -679,53 +695,52 @@ func (x *ReconEntities_Entity_ID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReconEntities_Entity_ID.ProtoReflect.Descriptor instead.
-func (*ReconEntities_Entity_ID) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{0, 0, 0}
+// Deprecated: Use FindNearbyPlacesResponse.ProtoReflect.Descriptor instead.
+func (*FindNearbyPlacesResponse) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ReconEntities_Entity_ID) GetProp() string {
+func (x *FindNearbyPlacesResponse) GetPlaces() []*FindNearbyPlacesResponse_Place {
 	if x != nil {
-		return x.Prop
+		return x.Places
 	}
-	return ""
+	return nil
 }
 
-func (x *ReconEntities_Entity_ID) GetVal() string {
+func (x *FindNearbyPlacesResponse) GetTruncated() bool {
 	if x != nil {
-		return x.Val
+		return x.Truncated
 	}
-	return ""
+	return false
 }
 
-type CoordinateRecon_Place struct {
+type ResolveIdsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The DCIDs of the places.
-	Dcid string `protobuf:"bytes,1,opt,name=dcid,proto3" json:"dcid,omitempty"`
-	// If the rectangle is fully contained in the place.
-	Full bool `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	InProp  string   `protobuf:"bytes,1,opt,name=in_prop,json=inProp,proto3" json:"in_prop,omitempty"`
+	OutProp string   `protobuf:"bytes,2,opt,name=out_prop,json=outProp,proto3" json:"out_prop,omitempty"`
+	Ids     []string `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
 }
 
-func (x *CoordinateRecon_Place) Reset() {
-	*x = CoordinateRecon_Place{}
+func (x *ResolveIdsRequest) Reset() {
+	*x = ResolveIdsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[13]
+		mi := &file_recon_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CoordinateRecon_Place) String() string {
+func (x *ResolveIdsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoordinateRecon_Place) ProtoMessage() {}
+func (*ResolveIdsRequest) ProtoMessage() {}
 
-func (x *CoordinateRecon_Place) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[13]
+func (x *ResolveIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -736,37 +751,92 @@ func (x *CoordinateRecon_Place) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoordinateRecon_Place.ProtoReflect.Descriptor instead.
-func (*CoordinateRecon_Place) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use ResolveIdsRequest.ProtoReflect.Descriptor instead.
+func (*ResolveIdsRequest) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *CoordinateRecon_Place) GetDcid() string {
+func (x *ResolveIdsRequest) GetInProp() string {
 	if x != nil {
-		return x.Dcid
+		return x.InProp
 	}
 	return ""
 }
 
-func (x *CoordinateRecon_Place) GetFull() bool {
+func (x *ResolveIdsRequest) GetOutProp() string {
 	if x != nil {
-		return x.Full
+		return x.OutProp
 	}
-	return false
+	return ""
 }
 
-type CompareEntitiesResponse_Comparison struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// Must have two source_ids, one for each entity.
-	SourceIds   []string `protobuf:"bytes,1,rep,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
-	Probability float64  `protobuf:"fixed64,2,opt,name=probability,proto3" json:"probability,omitempty"`
+func (x *ResolveIdsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
 }
 
-func (x *CompareEntitiesResponse_Comparison) Reset() {
-	*x = CompareEntitiesResponse_Comparison{}
+type ResolveIdsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entities []*ResolveIdsResponse_Entity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+}
+
+func (x *ResolveIdsResponse) Reset() {
+	*x = ResolveIdsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveIdsResponse) ProtoMessage() {}
+
+func (x *ResolveIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveIdsResponse.ProtoReflect.Descriptor instead.
+func (*ResolveIdsResponse) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ResolveIdsResponse) GetEntities() []*ResolveIdsResponse_Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+type CrosswalkIdsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// IDs of any ID properties, like geoId, isoCode or wikidataId.
+	Ids []*IdWithProperty `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// The ID properties to translate the IDs to.
+	OutProps []string `protobuf:"bytes,2,rep,name=out_props,json=outProps,proto3" json:"out_props,omitempty"`
+}
+
+func (x *CrosswalkIdsRequest) Reset() {
+	*x = CrosswalkIdsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_recon_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -774,13 +844,13 @@ func (x *CompareEntitiesResponse_Comparison) Reset() {
 	}
 }
 
-func (x *CompareEntitiesResponse_Comparison) String() string {
+func (x *CrosswalkIdsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompareEntitiesResponse_Comparison) ProtoMessage() {}
+func (*CrosswalkIdsRequest) ProtoMessage() {}
 
-func (x *CompareEntitiesResponse_Comparison) ProtoReflect() protoreflect.Message {
+func (x *CrosswalkIdsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_recon_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -792,36 +862,36 @@ func (x *CompareEntitiesResponse_Comparison) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompareEntitiesResponse_Comparison.ProtoReflect.Descriptor instead.
-func (*CompareEntitiesResponse_Comparison) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{4, 0}
+// Deprecated: Use CrosswalkIdsRequest.ProtoReflect.Descriptor instead.
+func (*CrosswalkIdsRequest) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *CompareEntitiesResponse_Comparison) GetSourceIds() []string {
+func (x *CrosswalkIdsRequest) GetIds() []*IdWithProperty {
 	if x != nil {
-		return x.SourceIds
+		return x.Ids
 	}
 	return nil
 }
 
-func (x *CompareEntitiesResponse_Comparison) GetProbability() float64 {
+func (x *CrosswalkIdsRequest) GetOutProps() []string {
 	if x != nil {
-		return x.Probability
+		return x.OutProps
 	}
-	return 0
+	return nil
 }
 
-type ResolveEntitiesResponse_ResolvedId struct {
+type CrosswalkIdsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ids         []*IdWithProperty `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
-	Probability float64           `protobuf:"fixed64,2,opt,name=probability,proto3" json:"probability,omitempty"`
+	// In the order of the requested IDs.
+	Entities []*CrosswalkIdsResponse_Entity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
 }
 
-func (x *ResolveEntitiesResponse_ResolvedId) Reset() {
-	*x = ResolveEntitiesResponse_ResolvedId{}
+func (x *CrosswalkIdsResponse) Reset() {
+	*x = CrosswalkIdsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_recon_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -829,13 +899,13 @@ func (x *ResolveEntitiesResponse_ResolvedId) Reset() {
 	}
 }
 
-func (x *ResolveEntitiesResponse_ResolvedId) String() string {
+func (x *CrosswalkIdsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolveEntitiesResponse_ResolvedId) ProtoMessage() {}
+func (*CrosswalkIdsResponse) ProtoMessage() {}
 
-func (x *ResolveEntitiesResponse_ResolvedId) ProtoReflect() protoreflect.Message {
+func (x *CrosswalkIdsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_recon_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -847,51 +917,151 @@ func (x *ResolveEntitiesResponse_ResolvedId) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolveEntitiesResponse_ResolvedId.ProtoReflect.Descriptor instead.
-func (*ResolveEntitiesResponse_ResolvedId) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{6, 0}
+// Deprecated: Use CrosswalkIdsResponse.ProtoReflect.Descriptor instead.
+func (*CrosswalkIdsResponse) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ResolveEntitiesResponse_ResolvedId) GetIds() []*IdWithProperty {
+func (x *CrosswalkIdsResponse) GetEntities() []*CrosswalkIdsResponse_Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+type ReconEntities_Entity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// All known IDs of the entity, including the DCID, excluding the ID in the
+	// recon request.
+	// NOTE: A single ID prop might be repeated.
+	Ids []*ReconEntities_Entity_ID `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *ReconEntities_Entity) Reset() {
+	*x = ReconEntities_Entity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconEntities_Entity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconEntities_Entity) ProtoMessage() {}
+
+func (x *ReconEntities_Entity) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconEntities_Entity.ProtoReflect.Descriptor instead.
+func (*ReconEntities_Entity) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ReconEntities_Entity) GetIds() []*ReconEntities_Entity_ID {
 	if x != nil {
 		return x.Ids
 	}
 	return nil
 }
 
-func (x *ResolveEntitiesResponse_ResolvedId) GetProbability() float64 {
+type ReconEntities_Entity_ID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prop string `protobuf:"bytes,1,opt,name=prop,proto3" json:"prop,omitempty"`
+	Val  string `protobuf:"bytes,2,opt,name=val,proto3" json:"val,omitempty"`
+}
+
+func (x *ReconEntities_Entity_ID) Reset() {
+	*x = ReconEntities_Entity_ID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconEntities_Entity_ID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconEntities_Entity_ID) ProtoMessage() {}
+
+func (x *ReconEntities_Entity_ID) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconEntities_Entity_ID.ProtoReflect.Descriptor instead.
+func (*ReconEntities_Entity_ID) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+func (x *ReconEntities_Entity_ID) GetProp() string {
 	if x != nil {
-		return x.Probability
+		return x.Prop
 	}
-	return 0
+	return ""
 }
 
-type ResolveEntitiesResponse_ResolvedEntity struct {
+func (x *ReconEntities_Entity_ID) GetVal() string {
+	if x != nil {
+		return x.Val
+	}
+	return ""
+}
+
+type CoordinateRecon_Place struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SourceId    string                                `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
-	ResolvedIds []*ResolveEntitiesResponse_ResolvedId `protobuf:"bytes,2,rep,name=resolved_ids,json=resolvedIds,proto3" json:"resolved_ids,omitempty"`
+	// The DCIDs of the places.
+	Dcid string `protobuf:"bytes,1,opt,name=dcid,proto3" json:"dcid,omitempty"`
+	// If the rectangle is fully contained in the place.
+	Full bool `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
 }
 
-func (x *ResolveEntitiesResponse_ResolvedEntity) Reset() {
-	*x = ResolveEntitiesResponse_ResolvedEntity{}
+func (x *CoordinateRecon_Place) Reset() {
+	*x = CoordinateRecon_Place{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[16]
+		mi := &file_recon_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResolveEntitiesResponse_ResolvedEntity) String() string {
+func (x *CoordinateRecon_Place) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolveEntitiesResponse_ResolvedEntity) ProtoMessage() {}
+func (*CoordinateRecon_Place) ProtoMessage() {}
 
-func (x *ResolveEntitiesResponse_ResolvedEntity) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[16]
+func (x *CoordinateRecon_Place) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,51 +1072,641 @@ func (x *ResolveEntitiesResponse_ResolvedEntity) ProtoReflect() protoreflect.Mes
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolveEntitiesResponse_ResolvedEntity.ProtoReflect.Descriptor instead.
-func (*ResolveEntitiesResponse_ResolvedEntity) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{6, 1}
+// Deprecated: Use CoordinateRecon_Place.ProtoReflect.Descriptor instead.
+func (*CoordinateRecon_Place) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *CoordinateRecon_Place) GetDcid() string {
+	if x != nil {
+		return x.Dcid
+	}
+	return ""
+}
+
+func (x *CoordinateRecon_Place) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+type CompareEntitiesResponse_Comparison struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Must have two source_ids, one for each entity.
+	SourceIds   []string `protobuf:"bytes,1,rep,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
+	Probability float64  `protobuf:"fixed64,2,opt,name=probability,proto3" json:"probability,omitempty"`
+}
+
+func (x *CompareEntitiesResponse_Comparison) Reset() {
+	*x = CompareEntitiesResponse_Comparison{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareEntitiesResponse_Comparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareEntitiesResponse_Comparison) ProtoMessage() {}
+
+func (x *CompareEntitiesResponse_Comparison) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareEntitiesResponse_Comparison.ProtoReflect.Descriptor instead.
+func (*CompareEntitiesResponse_Comparison) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *CompareEntitiesResponse_Comparison) GetSourceIds() []string {
+	if x != nil {
+		return x.SourceIds
+	}
+	return nil
+}
+
+func (x *CompareEntitiesResponse_Comparison) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+type ResolveEntitiesResponse_ResolvedId struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids         []*IdWithProperty `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Probability float64           `protobuf:"fixed64,2,opt,name=probability,proto3" json:"probability,omitempty"`
+}
+
+func (x *ResolveEntitiesResponse_ResolvedId) Reset() {
+	*x = ResolveEntitiesResponse_ResolvedId{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveEntitiesResponse_ResolvedId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveEntitiesResponse_ResolvedId) ProtoMessage() {}
+
+func (x *ResolveEntitiesResponse_ResolvedId) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveEntitiesResponse_ResolvedId.ProtoReflect.Descriptor instead.
+func (*ResolveEntitiesResponse_ResolvedId) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *ResolveEntitiesResponse_ResolvedId) GetIds() []*IdWithProperty {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ResolveEntitiesResponse_ResolvedId) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+type ResolveEntitiesResponse_ResolvedEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceId    string                                `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	ResolvedIds []*ResolveEntitiesResponse_ResolvedId `protobuf:"bytes,2,rep,name=resolved_ids,json=resolvedIds,proto3" json:"resolved_ids,omitempty"`
+}
+
+func (x *ResolveEntitiesResponse_ResolvedEntity) Reset() {
+	*x = ResolveEntitiesResponse_ResolvedEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveEntitiesResponse_ResolvedEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveEntitiesResponse_ResolvedEntity) ProtoMessage() {}
+
+func (x *ResolveEntitiesResponse_ResolvedEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveEntitiesResponse_ResolvedEntity.ProtoReflect.Descriptor instead.
+func (*ResolveEntitiesResponse_ResolvedEntity) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *ResolveEntitiesResponse_ResolvedEntity) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *ResolveEntitiesResponse_ResolvedEntity) GetResolvedIds() []*ResolveEntitiesResponse_ResolvedId {
+	if x != nil {
+		return x.ResolvedIds
+	}
+	return nil
+}
+
+type ResolveCoordinatesRequest_Coordinate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+}
+
+func (x *ResolveCoordinatesRequest_Coordinate) Reset() {
+	*x = ResolveCoordinatesRequest_Coordinate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveCoordinatesRequest_Coordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveCoordinatesRequest_Coordinate) ProtoMessage() {}
+
+func (x *ResolveCoordinatesRequest_Coordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveCoordinatesRequest_Coordinate.ProtoReflect.Descriptor instead.
+func (*ResolveCoordinatesRequest_Coordinate) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *ResolveCoordinatesRequest_Coordinate) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *ResolveCoordinatesRequest_Coordinate) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type ResolveCoordinatesResponse_Place struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dcid string `protobuf:"bytes,1,opt,name=dcid,proto3" json:"dcid,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *ResolveCoordinatesResponse_Place) Reset() {
+	*x = ResolveCoordinatesResponse_Place{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveCoordinatesResponse_Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveCoordinatesResponse_Place) ProtoMessage() {}
+
+func (x *ResolveCoordinatesResponse_Place) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveCoordinatesResponse_Place.ProtoReflect.Descriptor instead.
+func (*ResolveCoordinatesResponse_Place) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *ResolveCoordinatesResponse_Place) GetDcid() string {
+	if x != nil {
+		return x.Dcid
+	}
+	return ""
+}
+
+func (x *ResolveCoordinatesResponse_Place) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResolveCoordinatesResponse_Place) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ResolveCoordinatesResponse_PlaceCoordinate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Ordered from the smallest place to the largest.
+	PlaceDcids []string `protobuf:"bytes,3,rep,name=place_dcids,json=placeDcids,proto3" json:"place_dcids,omitempty"`
+	// The places of place_dcids, in the same order.
+	Places []*ResolveCoordinatesResponse_Place `protobuf:"bytes,4,rep,name=places,proto3" json:"places,omitempty"`
+}
+
+func (x *ResolveCoordinatesResponse_PlaceCoordinate) Reset() {
+	*x = ResolveCoordinatesResponse_PlaceCoordinate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveCoordinatesResponse_PlaceCoordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveCoordinatesResponse_PlaceCoordinate) ProtoMessage() {}
+
+func (x *ResolveCoordinatesResponse_PlaceCoordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveCoordinatesResponse_PlaceCoordinate.ProtoReflect.Descriptor instead.
+func (*ResolveCoordinatesResponse_PlaceCoordinate) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *ResolveCoordinatesResponse_PlaceCoordinate) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *ResolveCoordinatesResponse_PlaceCoordinate) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *ResolveCoordinatesResponse_PlaceCoordinate) GetPlaceDcids() []string {
+	if x != nil {
+		return x.PlaceDcids
+	}
+	return nil
+}
+
+func (x *ResolveCoordinatesResponse_PlaceCoordinate) GetPlaces() []*ResolveCoordinatesResponse_Place {
+	if x != nil {
+		return x.Places
+	}
+	return nil
+}
+
+type ResolveGeometryRequest_LatLngRect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	South float64 `protobuf:"fixed64,1,opt,name=south,proto3" json:"south,omitempty"`
+	West  float64 `protobuf:"fixed64,2,opt,name=west,proto3" json:"west,omitempty"`
+	North float64 `protobuf:"fixed64,3,opt,name=north,proto3" json:"north,omitempty"`
+	East  float64 `protobuf:"fixed64,4,opt,name=east,proto3" json:"east,omitempty"`
+}
+
+func (x *ResolveGeometryRequest_LatLngRect) Reset() {
+	*x = ResolveGeometryRequest_LatLngRect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveGeometryRequest_LatLngRect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveGeometryRequest_LatLngRect) ProtoMessage() {}
+
+func (x *ResolveGeometryRequest_LatLngRect) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveGeometryRequest_LatLngRect.ProtoReflect.Descriptor instead.
+func (*ResolveGeometryRequest_LatLngRect) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *ResolveGeometryRequest_LatLngRect) GetSouth() float64 {
+	if x != nil {
+		return x.South
+	}
+	return 0
+}
+
+func (x *ResolveGeometryRequest_LatLngRect) GetWest() float64 {
+	if x != nil {
+		return x.West
+	}
+	return 0
+}
+
+func (x *ResolveGeometryRequest_LatLngRect) GetNorth() float64 {
+	if x != nil {
+		return x.North
+	}
+	return 0
+}
+
+func (x *ResolveGeometryRequest_LatLngRect) GetEast() float64 {
+	if x != nil {
+		return x.East
+	}
+	return 0
+}
+
+type ResolveGeometryResponse_Place struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dcid string `protobuf:"bytes,1,opt,name=dcid,proto3" json:"dcid,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The fraction of the area of the place that is in the shape.
+	OverlapFraction float64 `protobuf:"fixed64,3,opt,name=overlap_fraction,json=overlapFraction,proto3" json:"overlap_fraction,omitempty"`
+	// The fraction of the area of the shape that is in the place.
+	ShapeFraction float64 `protobuf:"fixed64,4,opt,name=shape_fraction,json=shapeFraction,proto3" json:"shape_fraction,omitempty"`
+}
+
+func (x *ResolveGeometryResponse_Place) Reset() {
+	*x = ResolveGeometryResponse_Place{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveGeometryResponse_Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveGeometryResponse_Place) ProtoMessage() {}
+
+func (x *ResolveGeometryResponse_Place) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveGeometryResponse_Place.ProtoReflect.Descriptor instead.
+func (*ResolveGeometryResponse_Place) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *ResolveGeometryResponse_Place) GetDcid() string {
+	if x != nil {
+		return x.Dcid
+	}
+	return ""
+}
+
+func (x *ResolveGeometryResponse_Place) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResolveGeometryResponse_Place) GetOverlapFraction() float64 {
+	if x != nil {
+		return x.OverlapFraction
+	}
+	return 0
+}
+
+func (x *ResolveGeometryResponse_Place) GetShapeFraction() float64 {
+	if x != nil {
+		return x.ShapeFraction
+	}
+	return 0
+}
+
+type FindNearbyPlacesResponse_Place struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dcid      string   `protobuf:"bytes,1,opt,name=dcid,proto3" json:"dcid,omitempty"`
+	Name      string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Types     []string `protobuf:"bytes,3,rep,name=types,proto3" json:"types,omitempty"`
+	Latitude  float64  `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64  `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// The distance from the requested point to the centroid of the place, in
+	// kilometers.
+	DistanceKm float64 `protobuf:"fixed64,6,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+}
+
+func (x *FindNearbyPlacesResponse_Place) Reset() {
+	*x = FindNearbyPlacesResponse_Place{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_recon_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindNearbyPlacesResponse_Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearbyPlacesResponse_Place) ProtoMessage() {}
+
+func (x *FindNearbyPlacesResponse_Place) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearbyPlacesResponse_Place.ProtoReflect.Descriptor instead.
+func (*FindNearbyPlacesResponse_Place) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *FindNearbyPlacesResponse_Place) GetDcid() string {
+	if x != nil {
+		return x.Dcid
+	}
+	return ""
 }
 
-func (x *ResolveEntitiesResponse_ResolvedEntity) GetSourceId() string {
+func (x *FindNearbyPlacesResponse_Place) GetName() string {
 	if x != nil {
-		return x.SourceId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ResolveEntitiesResponse_ResolvedEntity) GetResolvedIds() []*ResolveEntitiesResponse_ResolvedId {
+func (x *FindNearbyPlacesResponse_Place) GetTypes() []string {
 	if x != nil {
-		return x.ResolvedIds
+		return x.Types
 	}
 	return nil
 }
 
-type ResolveCoordinatesRequest_Coordinate struct {
+func (x *FindNearbyPlacesResponse_Place) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *FindNearbyPlacesResponse_Place) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *FindNearbyPlacesResponse_Place) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+type ResolveIdsResponse_Entity struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	InId   string   `protobuf:"bytes,1,opt,name=in_id,json=inId,proto3" json:"in_id,omitempty"`
+	OutIds []string `protobuf:"bytes,2,rep,name=out_ids,json=outIds,proto3" json:"out_ids,omitempty"`
 }
 
-func (x *ResolveCoordinatesRequest_Coordinate) Reset() {
-	*x = ResolveCoordinatesRequest_Coordinate{}
+func (x *ResolveIdsResponse_Entity) Reset() {
+	*x = ResolveIdsResponse_Entity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[17]
+		mi := &file_recon_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResolveCoordinatesRequest_Coordinate) String() string {
+func (x *ResolveIdsResponse_Entity) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolveCoordinatesRequest_Coordinate) ProtoMessage() {}
+func (*ResolveIdsResponse_Entity) ProtoMessage() {}
 
-func (x *ResolveCoordinatesRequest_Coordinate) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[17]
+func (x *ResolveIdsResponse_Entity) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -957,52 +1717,53 @@ func (x *ResolveCoordinatesRequest_Coordinate) ProtoReflect() protoreflect.Messa
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolveCoordinatesRequest_Coordinate.ProtoReflect.Descriptor instead.
-func (*ResolveCoordinatesRequest_Coordinate) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{7, 0}
+// Deprecated: Use ResolveIdsResponse_Entity.ProtoReflect.Descriptor instead.
+func (*ResolveIdsResponse_Entity) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{13, 0}
 }
 
-func (x *ResolveCoordinatesRequest_Coordinate) GetLatitude() float64 {
+func (x *ResolveIdsResponse_Entity) GetInId() string {
 	if x != nil {
-		return x.Latitude
+		return x.InId
 	}
-	return 0
+	return ""
 }
 
-func (x *ResolveCoordinatesRequest_Coordinate) GetLongitude() float64 {
+func (x *ResolveIdsResponse_Entity) GetOutIds() []string {
 	if x != nil {
-		return x.Longitude
+		return x.OutIds
 	}
-	return 0
+	return nil
 }
 
-type ResolveCoordinatesResponse_PlaceCoordinate struct {
+type CrosswalkIdsResponse_OutIds struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Latitude   float64  `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude  float64  `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	PlaceDcids []string `protobuf:"bytes,3,rep,name=place_dcids,json=placeDcids,proto3" json:"place_dcids,omitempty"`
+	Prop string   `protobuf:"bytes,1,opt,name=prop,proto3" json:"prop,omitempty"`
+	Vals []string `protobuf:"bytes,2,rep,name=vals,proto3" json:"vals,omitempty"`
+	// Whether the input ID maps to more than one ID of the property.
+	Ambiguous bool `protobuf:"varint,3,opt,name=ambiguous,proto3" json:"ambiguous,omitempty"`
 }
 
-func (x *ResolveCoordinatesResponse_PlaceCoordinate) Reset() {
-	*x = ResolveCoordinatesResponse_PlaceCoordinate{}
+func (x *CrosswalkIdsResponse_OutIds) Reset() {
+	*x = CrosswalkIdsResponse_OutIds{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[18]
+		mi := &file_recon_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResolveCoordinatesResponse_PlaceCoordinate) String() string {
+func (x *CrosswalkIdsResponse_OutIds) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolveCoordinatesResponse_PlaceCoordinate) ProtoMessage() {}
+func (*CrosswalkIdsResponse_OutIds) ProtoMessage() {}
 
-func (x *ResolveCoordinatesResponse_PlaceCoordinate) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[18]
+func (x *CrosswalkIdsResponse_OutIds) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1013,58 +1774,60 @@ func (x *ResolveCoordinatesResponse_PlaceCoordinate) ProtoReflect() protoreflect
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolveCoordinatesResponse_PlaceCoordinate.ProtoReflect.Descriptor instead.
-func (*ResolveCoordinatesResponse_PlaceCoordinate) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{8, 0}
+// Deprecated: Use CrosswalkIdsResponse_OutIds.ProtoReflect.Descriptor instead.
+func (*CrosswalkIdsResponse_OutIds) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{15, 0}
 }
 
-func (x *ResolveCoordinatesResponse_PlaceCoordinate) GetLatitude() float64 {
+func (x *CrosswalkIdsResponse_OutIds) GetProp() string {
 	if x != nil {
-		return x.Latitude
+		return x.Prop
 	}
-	return 0
+	return ""
 }
 
-func (x *ResolveCoordinatesResponse_PlaceCoordinate) GetLongitude() float64 {
+func (x *CrosswalkIdsResponse_OutIds) GetVals() []string {
 	if x != nil {
-		return x.Longitude
+		return x.Vals
 	}
-	return 0
+	return nil
 }
 
-func (x *ResolveCoordinatesResponse_PlaceCoordinate) GetPlaceDcids() []string {
+func (x *CrosswalkIdsResponse_OutIds) GetAmbiguous() bool {
 	if x != nil {
-		return x.PlaceDcids
+		return x.Ambiguous
 	}
-	return nil
+	return false
 }
 
-type ResolveIdsResponse_Entity struct {
+type CrosswalkIdsResponse_Entity struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	InId   string   `protobuf:"bytes,1,opt,name=in_id,json=inId,proto3" json:"in_id,omitempty"`
-	OutIds []string `protobuf:"bytes,2,rep,name=out_ids,json=outIds,proto3" json:"out_ids,omitempty"`
+	InId *IdWithProperty `protobuf:"bytes,1,opt,name=in_id,json=inId,proto3" json:"in_id,omitempty"`
+	// In the order of the requested out_props. Properties without a mapping
+	// have no value.
+	OutIds []*CrosswalkIdsResponse_OutIds `protobuf:"bytes,2,rep,name=out_ids,json=outIds,proto3" json:"out_ids,omitempty"`
 }
 
-func (x *ResolveIdsResponse_Entity) Reset() {
-	*x = ResolveIdsResponse_Entity{}
+func (x *CrosswalkIdsResponse_Entity) Reset() {
+	*x = CrosswalkIdsResponse_Entity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_recon_proto_msgTypes[19]
+		mi := &file_recon_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResolveIdsResponse_Entity) String() string {
+func (x *CrosswalkIdsResponse_Entity) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolveIdsResponse_Entity) ProtoMessage() {}
+func (*CrosswalkIdsResponse_Entity) ProtoMessage() {}
 
-func (x *ResolveIdsResponse_Entity) ProtoReflect() protoreflect.Message {
-	mi := &file_recon_proto_msgTypes[19]
+func (x *CrosswalkIdsResponse_Entity) ProtoReflect() protoreflect.Message {
+	mi := &file_recon_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1075,19 +1838,19 @@ func (x *ResolveIdsResponse_Entity) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolveIdsResponse_Entity.ProtoReflect.Descriptor instead.
-func (*ResolveIdsResponse_Entity) Descriptor() ([]byte, []int) {
-	return file_recon_proto_rawDescGZIP(), []int{10, 0}
+// Deprecated: Use CrosswalkIdsResponse_Entity.ProtoReflect.Descriptor instead.
+func (*CrosswalkIdsResponse_Entity) Descriptor() ([]byte, []int) {
+	return file_recon_proto_rawDescGZIP(), []int{15, 1}
 }
 
-func (x *ResolveIdsResponse_Entity) GetInId() string {
+func (x *CrosswalkIdsResponse_Entity) GetInId() *IdWithProperty {
 	if x != nil {
 		return x.InId
 	}
-	return ""
+	return nil
 }
 
-func (x *ResolveIdsResponse_Entity) GetOutIds() []string {
+func (x *CrosswalkIdsResponse_Entity) GetOutIds() []*CrosswalkIdsResponse_OutIds {
 	if x != nil {
 		return x.OutIds
 	}
@@ -1121,135 +1884,247 @@ var file_recon_proto_rawDesc = []byte{
 	0x61, 0x63, 0x65, 0x73, 0x1a, 0x2f, 0x0a, 0x05, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a,
 	0x04, 0x64, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x63, 0x69,
 	0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x04, 0x66, 0x75, 0x6c, 0x6c, 0x22, 0x6c, 0x0a, 0x14, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49,
-	0x6e, 0x66, 0x6f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a,
-	0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69,
-	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x22, 0x54, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x45, 0x6e,
-	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a,
-	0x0c, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x50, 0x61, 0x69, 0x72, 0x52, 0x0b, 0x65, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x50, 0x61, 0x69, 0x72, 0x73, 0x22, 0xbb, 0x01, 0x0a, 0x17, 0x43, 0x6f,
-	0x6d, 0x70, 0x61, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69,
-	0x73, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
-	0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x73, 0x1a, 0x4d, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x70,
-	0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x49, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69,
-	0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62,
-	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x83, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x6f,
-	0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x37, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x75, 0x62, 0x47, 0x72, 0x61, 0x70,
-	0x68, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x77,
-	0x61, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
-	0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x77, 0x61, 0x6e, 0x74, 0x65,
-	0x64, 0x49, 0x64, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x22, 0xde, 0x02,
-	0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x11, 0x72, 0x65, 0x73,
-	0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c,
-	0x76, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x6c,
-	0x76, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x5d, 0x0a, 0x0a, 0x52,
-	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x03, 0x69, 0x64, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x49, 0x64, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x70, 0x65,
-	0x72, 0x74, 0x79, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62,
-	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x70,
-	0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x81, 0x01, 0x0a, 0x0e, 0x52,
-	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a,
-	0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x52, 0x0a, 0x0c, 0x72, 0x65,
-	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x2f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52,
-	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x49,
-	0x64, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x49, 0x64, 0x73, 0x22, 0xb8,
-	0x01, 0x0a, 0x19, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69,
-	0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x53, 0x0a, 0x0b,
-	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e,
-	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69,
-	0x6e, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65,
-	0x73, 0x1a, 0x46, 0x0a, 0x0a, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c,
-	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
-	0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0xf0, 0x01, 0x0a, 0x1a, 0x52, 0x65,
-	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x11, 0x70, 0x6c, 0x61, 0x63,
-	0x65, 0x5f, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e,
-	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6c, 0x61,
-	0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x10, 0x70, 0x6c,
-	0x61, 0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x1a, 0x6c,
-	0x0a, 0x0f, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a,
-	0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
-	0x6c, 0x61, 0x63, 0x65, 0x5f, 0x64, 0x63, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0a, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x44, 0x63, 0x69, 0x64, 0x73, 0x22, 0x59, 0x0a, 0x11,
-	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x75,
-	0x74, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x75,
-	0x74, 0x50, 0x72, 0x6f, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x6f,
-	0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42,
-	0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x26, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52,
-	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69,
-	0x65, 0x73, 0x1a, 0x36, 0x0a, 0x06, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x13, 0x0a, 0x05,
-	0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x49,
-	0x64, 0x12, 0x17, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x49, 0x64, 0x73, 0x32, 0xea, 0x03, 0x0a, 0x05, 0x52,
-	0x65, 0x63, 0x6f, 0x6e, 0x12, 0x78, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x45,
-	0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64,
+	0x04, 0x66, 0x75, 0x6c, 0x6c, 0x22, 0x54, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3a, 0x0a, 0x0c, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x50, 0x61, 0x69, 0x72, 0x52, 0x0b,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x50, 0x61, 0x69, 0x72, 0x73, 0x22, 0xbb, 0x01, 0x0a, 0x17,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x69, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x64,
 	0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
 	0x72, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f, 0x65, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x78,
-	0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
-	0x73, 0x12, 0x23, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e,
-	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x52, 0x0b, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x73, 0x1a, 0x4d, 0x0a, 0x0a, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x72,
+	0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x83, 0x01, 0x0a, 0x16, 0x52, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x75, 0x62, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x30, 0x0a,
+	0x14, 0x77, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x77, 0x61, 0x6e,
+	0x74, 0x65, 0x64, 0x49, 0x64, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x22,
+	0xde, 0x02, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x11, 0x72,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d,
 	0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69,
-	0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2f, 0x72, 0x65,
-	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x85, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x73,
-	0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12,
-	0x26, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f,
-	0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22, 0x13, 0x2f, 0x63, 0x6f, 0x6f, 0x72, 0x64,
-	0x69, 0x6e, 0x61, 0x74, 0x65, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x3a, 0x01, 0x2a,
-	0x12, 0x65, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x12, 0x1e,
-	0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73,
-	0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x10, 0x72, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x5d, 0x0a,
+	0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x03, 0x69,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x49, 0x64, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72,
+	0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x81, 0x01, 0x0a,
+	0x0e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x52, 0x0a, 0x0c,
+	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x64, 0x49, 0x64, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x49, 0x64, 0x73,
+	0x22, 0xd9, 0x01, 0x0a, 0x19, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x53,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x1a, 0x46, 0x0a, 0x0a, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0xfd, 0x02, 0x0a,
+	0x1a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x11, 0x70,
+	0x6c, 0x61, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x50, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52,
+	0x10, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65,
+	0x73, 0x1a, 0x43, 0x0a, 0x05, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x63,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x63, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0xb3, 0x01, 0x0a, 0x0f, 0x50, 0x6c, 0x61, 0x63, 0x65,
+	0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x5f, 0x64, 0x63,
+	0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x44, 0x63, 0x69, 0x64, 0x73, 0x12, 0x45, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50,
+	0x6c, 0x61, 0x63, 0x65, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x22, 0x94, 0x02, 0x0a,
+	0x16, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x08, 0x67, 0x65, 0x6f, 0x5f, 0x6a,
+	0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x67, 0x65, 0x6f,
+	0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x53, 0x0a, 0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x62, 0x6f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x62, 0x6f,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x6c, 0x61, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x60, 0x0a, 0x0a, 0x4c, 0x61, 0x74, 0x4c,
+	0x6e, 0x67, 0x52, 0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6f, 0x75, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x6f, 0x75, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x77, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x77, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x72, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x6e, 0x6f, 0x72, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x61, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x65, 0x61, 0x73, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x73, 0x68,
+	0x61, 0x70, 0x65, 0x22, 0xe1, 0x01, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x47,
+	0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x42, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x52, 0x06, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x73, 0x1a, 0x81, 0x01, 0x0a, 0x05, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x63, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x70,
+	0x5f, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0f, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x70, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x25, 0x0a, 0x0e, 0x73, 0x68, 0x61, 0x70, 0x65, 0x5f, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x73, 0x68, 0x61, 0x70, 0x65, 0x46,
+	0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa5, 0x01, 0x0a, 0x17, 0x46, 0x69, 0x6e, 0x64,
+	0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x5f, 0x6b, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x4b, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22,
+	0xa0, 0x02, 0x0a, 0x18, 0x46, 0x69, 0x6e, 0x64, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x50, 0x6c,
+	0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x06,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4e,
+	0x65, 0x61, 0x72, 0x62, 0x79, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x1a,
+	0xa0, 0x01, 0x0a, 0x05, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x63, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x63, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6b, 0x6d,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x4b, 0x6d, 0x22, 0x59, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x6e, 0x5f, 0x70, 0x72,
+	0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x70,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x69,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x90, 0x01,
+	0x0a, 0x12, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x36, 0x0a, 0x06, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x13, 0x0a, 0x05, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x49, 0x64, 0x73,
+	0x22, 0x61, 0x0a, 0x13, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x77, 0x61, 0x6c, 0x6b, 0x49, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x73, 0x2e, 0x49, 0x64, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+	0x79, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x75, 0x74, 0x5f, 0x70, 0x72,
+	0x6f, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x75, 0x74, 0x50, 0x72,
+	0x6f, 0x70, 0x73, 0x22, 0xab, 0x02, 0x0a, 0x14, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x77, 0x61, 0x6c,
+	0x6b, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x08,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x72, 0x6f,
+	0x73, 0x73, 0x77, 0x61, 0x6c, 0x6b, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x1a, 0x4e, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x49, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x72, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x72, 0x6f, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x76, 0x61, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6d, 0x62, 0x69, 0x67, 0x75, 0x6f, 0x75,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x6d, 0x62, 0x69, 0x67, 0x75, 0x6f,
+	0x75, 0x73, 0x1a, 0x7d, 0x0a, 0x06, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x30, 0x0a, 0x05,
+	0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x49, 0x64, 0x57, 0x69, 0x74, 0x68,
+	0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x04, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x41,
+	0x0a, 0x07, 0x6f, 0x75, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x72,
+	0x6f, 0x73, 0x73, 0x77, 0x61, 0x6c, 0x6b, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x49, 0x64, 0x73, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x49, 0x64,
+	0x73, 0x32, 0xd0, 0x06, 0x0a, 0x05, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x12, 0x78, 0x0a, 0x0f, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x23,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x14, 0x22, 0x0f, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x78, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x6c, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x3a, 0x01, 0x2a, 0x22, 0x0f,
+	0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x12,
+	0x85, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
 	0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73,
-	0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x22, 0x0b, 0x2f, 0x69, 0x64, 0x2f, 0x72, 0x65, 0x73,
-	0x6f, 0x6c, 0x76, 0x65, 0x3a, 0x01, 0x2a, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6c, 0x76, 0x65, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x3a,
+	0x01, 0x2a, 0x22, 0x13, 0x2f, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x2f,
+	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x7a, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x12, 0x23, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x24, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x22, 0x11, 0x2f,
+	0x67, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x3a, 0x01, 0x2a, 0x12, 0x79, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x4e, 0x65, 0x61, 0x72, 0x62,
+	0x79, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79,
+	0x50, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x64,
+	0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x22, 0x0d, 0x2f, 0x70,
+	0x6c, 0x61, 0x63, 0x65, 0x2f, 0x6e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x3a, 0x01, 0x2a, 0x12, 0x65,
+	0x0a, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x64, 0x73, 0x12, 0x1e, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x10, 0x22, 0x0b, 0x2f, 0x69, 0x64, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x6d, 0x0a, 0x0c, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x77, 0x61,
+	0x6c, 0x6b, 0x49, 0x64, 0x73, 0x12, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x77, 0x61, 0x6c, 0x6b, 0x49, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x77, 0x61, 0x6c, 0x6b, 0x49,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x12, 0x3a, 0x01, 0x2a, 0x22, 0x0d, 0x2f, 0x69, 0x64, 0x2f, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x77, 0x61, 0x6c, 0x6b, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1264,60 +2139,83 @@ func file_recon_proto_rawDescGZIP() []byte {
 	return file_recon_proto_rawDescData
 }
 
-var file_recon_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_recon_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_recon_proto_goTypes = []interface{}{
 	(*ReconEntities)(nil),                              // 0: datacommons.ReconEntities
 	(*CoordinateRecon)(nil),                            // 1: datacommons.CoordinateRecon
-	(*EntityInfoCollection)(nil),                       // 2: datacommons.EntityInfoCollection
-	(*CompareEntitiesRequest)(nil),                     // 3: datacommons.CompareEntitiesRequest
-	(*CompareEntitiesResponse)(nil),                    // 4: datacommons.CompareEntitiesResponse
-	(*ResolveEntitiesRequest)(nil),                     // 5: datacommons.ResolveEntitiesRequest
-	(*ResolveEntitiesResponse)(nil),                    // 6: datacommons.ResolveEntitiesResponse
-	(*ResolveCoordinatesRequest)(nil),                  // 7: datacommons.ResolveCoordinatesRequest
-	(*ResolveCoordinatesResponse)(nil),                 // 8: datacommons.ResolveCoordinatesResponse
-	(*ResolveIdsRequest)(nil),                          // 9: datacommons.ResolveIdsRequest
-	(*ResolveIdsResponse)(nil),                         // 10: datacommons.ResolveIdsResponse
-	(*ReconEntities_Entity)(nil),                       // 11: datacommons.ReconEntities.Entity
-	(*ReconEntities_Entity_ID)(nil),                    // 12: datacommons.ReconEntities.Entity.ID
-	(*CoordinateRecon_Place)(nil),                      // 13: datacommons.CoordinateRecon.Place
-	(*CompareEntitiesResponse_Comparison)(nil),         // 14: datacommons.CompareEntitiesResponse.Comparison
-	(*ResolveEntitiesResponse_ResolvedId)(nil),         // 15: datacommons.ResolveEntitiesResponse.ResolvedId
-	(*ResolveEntitiesResponse_ResolvedEntity)(nil),     // 16: datacommons.ResolveEntitiesResponse.ResolvedEntity
-	(*ResolveCoordinatesRequest_Coordinate)(nil),       // 17: datacommons.ResolveCoordinatesRequest.Coordinate
-	(*ResolveCoordinatesResponse_PlaceCoordinate)(nil), // 18: datacommons.ResolveCoordinatesResponse.PlaceCoordinate
-	(*ResolveIdsResponse_Entity)(nil),                  // 19: datacommons.ResolveIdsResponse.Entity
-	(*EntityInfo)(nil),                                 // 20: datacommons.EntityInfo
-	(*EntityPair)(nil),                                 // 21: datacommons.EntityPair
-	(*EntitySubGraph)(nil),                             // 22: datacommons.EntitySubGraph
-	(*IdWithProperty)(nil),                             // 23: datacommons.IdWithProperty
+	(*CompareEntitiesRequest)(nil),                     // 2: datacommons.CompareEntitiesRequest
+	(*CompareEntitiesResponse)(nil),                    // 3: datacommons.CompareEntitiesResponse
+	(*ResolveEntitiesRequest)(nil),                     // 4: datacommons.ResolveEntitiesRequest
+	(*ResolveEntitiesResponse)(nil),                    // 5: datacommons.ResolveEntitiesResponse
+	(*ResolveCoordinatesRequest)(nil),                  // 6: datacommons.ResolveCoordinatesRequest
+	(*ResolveCoordinatesResponse)(nil),                 // 7: datacommons.ResolveCoordinatesResponse
+	(*ResolveGeometryRequest)(nil),                     // 8: datacommons.ResolveGeometryRequest
+	(*ResolveGeometryResponse)(nil),                    // 9: datacommons.ResolveGeometryResponse
+	(*FindNearbyPlacesRequest)(nil),                    // 10: datacommons.FindNearbyPlacesRequest
+	(*FindNearbyPlacesResponse)(nil),                   // 11: datacommons.FindNearbyPlacesResponse
+	(*ResolveIdsRequest)(nil),                          // 12: datacommons.ResolveIdsRequest
+	(*ResolveIdsResponse)(nil),                         // 13: datacommons.ResolveIdsResponse
+	(*CrosswalkIdsRequest)(nil),                        // 14: datacommons.CrosswalkIdsRequest
+	(*CrosswalkIdsResponse)(nil),                       // 15: datacommons.CrosswalkIdsResponse
+	(*ReconEntities_Entity)(nil),                       // 16: datacommons.ReconEntities.Entity
+	(*ReconEntities_Entity_ID)(nil),                    // 17: datacommons.ReconEntities.Entity.ID
+	(*CoordinateRecon_Place)(nil),                      // 18: datacommons.CoordinateRecon.Place
+	(*CompareEntitiesResponse_Comparison)(nil),         // 19: datacommons.CompareEntitiesResponse.Comparison
+	(*ResolveEntitiesResponse_ResolvedId)(nil),         // 20: datacommons.ResolveEntitiesResponse.ResolvedId
+	(*ResolveEntitiesResponse_ResolvedEntity)(nil),     // 21: datacommons.ResolveEntitiesResponse.ResolvedEntity
+	(*ResolveCoordinatesRequest_Coordinate)(nil),       // 22: datacommons.ResolveCoordinatesRequest.Coordinate
+	(*ResolveCoordinatesResponse_Place)(nil),           // 23: datacommons.ResolveCoordinatesResponse.Place
+	(*ResolveCoordinatesResponse_PlaceCoordinate)(nil), // 24: datacommons.ResolveCoordinatesResponse.PlaceCoordinate
+	(*ResolveGeometryRequest_LatLngRect)(nil),          // 25: datacommons.ResolveGeometryRequest.LatLngRect
+	(*ResolveGeometryResponse_Place)(nil),              // 26: datacommons.ResolveGeometryResponse.Place
+	(*FindNearbyPlacesResponse_Place)(nil),             // 27: datacommons.FindNearbyPlacesResponse.Place
+	(*ResolveIdsResponse_Entity)(nil),                  // 28: datacommons.ResolveIdsResponse.Entity
+	(*CrosswalkIdsResponse_OutIds)(nil),                // 29: datacommons.CrosswalkIdsResponse.OutIds
+	(*CrosswalkIdsResponse_Entity)(nil),                // 30: datacommons.CrosswalkIdsResponse.Entity
+	(*EntityPair)(nil),                                 // 31: datacommons.EntityPair
+	(*EntitySubGraph)(nil),                             // 32: datacommons.EntitySubGraph
+	(*IdWithProperty)(nil),                             // 33: datacommons.IdWithProperty
 }
 var file_recon_proto_depIdxs = []int32{
-	11, // 0: datacommons.ReconEntities.entities:type_name -> datacommons.ReconEntities.Entity
-	13, // 1: datacommons.CoordinateRecon.places:type_name -> datacommons.CoordinateRecon.Place
-	20, // 2: datacommons.EntityInfoCollection.entities:type_name -> datacommons.EntityInfo
-	21, // 3: datacommons.CompareEntitiesRequest.entity_pairs:type_name -> datacommons.EntityPair
-	14, // 4: datacommons.CompareEntitiesResponse.comparisons:type_name -> datacommons.CompareEntitiesResponse.Comparison
-	22, // 5: datacommons.ResolveEntitiesRequest.entities:type_name -> datacommons.EntitySubGraph
-	16, // 6: datacommons.ResolveEntitiesResponse.resolved_entities:type_name -> datacommons.ResolveEntitiesResponse.ResolvedEntity
-	17, // 7: datacommons.ResolveCoordinatesRequest.coordinates:type_name -> datacommons.ResolveCoordinatesRequest.Coordinate
-	18, // 8: datacommons.ResolveCoordinatesResponse.place_coordinates:type_name -> datacommons.ResolveCoordinatesResponse.PlaceCoordinate
-	19, // 9: datacommons.ResolveIdsResponse.entities:type_name -> datacommons.ResolveIdsResponse.Entity
-	12, // 10: datacommons.ReconEntities.Entity.ids:type_name -> datacommons.ReconEntities.Entity.ID
-	23, // 11: datacommons.ResolveEntitiesResponse.ResolvedId.ids:type_name -> datacommons.IdWithProperty
-	15, // 12: datacommons.ResolveEntitiesResponse.ResolvedEntity.resolved_ids:type_name -> datacommons.ResolveEntitiesResponse.ResolvedId
-	3,  // 13: datacommons.Recon.CompareEntities:input_type -> datacommons.CompareEntitiesRequest
-	5,  // 14: datacommons.Recon.ResolveEntities:input_type -> datacommons.ResolveEntitiesRequest
-	7,  // 15: datacommons.Recon.ResolveCoordinates:input_type -> datacommons.ResolveCoordinatesRequest
-	9,  // 16: datacommons.Recon.ResolveIds:input_type -> datacommons.ResolveIdsRequest
-	4,  // 17: datacommons.Recon.CompareEntities:output_type -> datacommons.CompareEntitiesResponse
-	6,  // 18: datacommons.Recon.ResolveEntities:output_type -> datacommons.ResolveEntitiesResponse
-	8,  // 19: datacommons.Recon.ResolveCoordinates:output_type -> datacommons.ResolveCoordinatesResponse
-	10, // 20: datacommons.Recon.ResolveIds:output_type -> datacommons.ResolveIdsResponse
-	17, // [17:21] is the sub-list for method output_type
-	13, // [13:17] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	16, // 0: datacommons.ReconEntities.entities:type_name -> datacommons.ReconEntities.Entity
+	18, // 1: datacommons.CoordinateRecon.places:type_name -> datacommons.CoordinateRecon.Place
+	31, // 2: datacommons.CompareEntitiesRequest.entity_pairs:type_name -> datacommons.EntityPair
+	19, // 3: datacommons.CompareEntitiesResponse.comparisons:type_name -> datacommons.CompareEntitiesResponse.Comparison
+	32, // 4: datacommons.ResolveEntitiesRequest.entities:type_name -> datacommons.EntitySubGraph
+	21, // 5: datacommons.ResolveEntitiesResponse.resolved_entities:type_name -> datacommons.ResolveEntitiesResponse.ResolvedEntity
+	22, // 6: datacommons.ResolveCoordinatesRequest.coordinates:type_name -> datacommons.ResolveCoordinatesRequest.Coordinate
+	24, // 7: datacommons.ResolveCoordinatesResponse.place_coordinates:type_name -> datacommons.ResolveCoordinatesResponse.PlaceCoordinate
+	25, // 8: datacommons.ResolveGeometryRequest.bounding_box:type_name -> datacommons.ResolveGeometryRequest.LatLngRect
+	26, // 9: datacommons.ResolveGeometryResponse.places:type_name -> datacommons.ResolveGeometryResponse.Place
+	27, // 10: datacommons.FindNearbyPlacesResponse.places:type_name -> datacommons.FindNearbyPlacesResponse.Place
+	28, // 11: datacommons.ResolveIdsResponse.entities:type_name -> datacommons.ResolveIdsResponse.Entity
+	33, // 12: datacommons.CrosswalkIdsRequest.ids:type_name -> datacommons.IdWithProperty
+	30, // 13: datacommons.CrosswalkIdsResponse.entities:type_name -> datacommons.CrosswalkIdsResponse.Entity
+	17, // 14: datacommons.ReconEntities.Entity.ids:type_name -> datacommons.ReconEntities.Entity.ID
+	33, // 15: datacommons.ResolveEntitiesResponse.ResolvedId.ids:type_name -> datacommons.IdWithProperty
+	20, // 16: datacommons.ResolveEntitiesResponse.ResolvedEntity.resolved_ids:type_name -> datacommons.ResolveEntitiesResponse.ResolvedId
+	23, // 17: datacommons.ResolveCoordinatesResponse.PlaceCoordinate.places:type_name -> datacommons.ResolveCoordinatesResponse.Place
+	33, // 18: datacommons.CrosswalkIdsResponse.Entity.in_id:type_name -> datacommons.IdWithProperty
+	29, // 19: datacommons.CrosswalkIdsResponse.Entity.out_ids:type_name -> datacommons.CrosswalkIdsResponse.OutIds
+	2,  // 20: datacommons.Recon.CompareEntities:input_type -> datacommons.CompareEntitiesRequest
+	4,  // 21: datacommons.Recon.ResolveEntities:input_type -> datacommons.ResolveEntitiesRequest
+	6,  // 22: datacommons.Recon.ResolveCoordinates:input_type -> datacommons.ResolveCoordinatesRequest
+	8,  // 23: datacommons.Recon.ResolveGeometry:input_type -> datacommons.ResolveGeometryRequest
+	10, // 24: datacommons.Recon.FindNearbyPlaces:input_type -> datacommons.FindNearbyPlacesRequest
+	12, // 25: datacommons.Recon.ResolveIds:input_type -> datacommons.ResolveIdsRequest
+	14, // 26: datacommons.Recon.CrosswalkIds:input_type -> datacommons.CrosswalkIdsRequest
+	3,  // 27: datacommons.Recon.CompareEntities:output_type -> datacommons.CompareEntitiesResponse
+	5,  // 28: datacommons.Recon.ResolveEntities:output_type -> datacommons.ResolveEntitiesResponse
+	7,  // 29: datacommons.Recon.ResolveCoordinates:output_type -> datacommons.ResolveCoordinatesResponse
+	9,  // 30: datacommons.Recon.ResolveGeometry:output_type -> datacommons.ResolveGeometryResponse
+	11, // 31: datacommons.Recon.FindNearbyPlaces:output_type -> datacommons.FindNearbyPlacesResponse
+	13, // 32: datacommons.Recon.ResolveIds:output_type -> datacommons.ResolveIdsResponse
+	15, // 33: datacommons.Recon.CrosswalkIds:output_type -> datacommons.CrosswalkIdsResponse
+	27, // [27:34] is the sub-list for method output_type
+	20, // [20:27] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_recon_proto_init() }
@@ -1352,7 +2250,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EntityInfoCollection); i {
+			switch v := v.(*CompareEntitiesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1364,7 +2262,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompareEntitiesRequest); i {
+			switch v := v.(*CompareEntitiesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1376,7 +2274,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompareEntitiesResponse); i {
+			switch v := v.(*ResolveEntitiesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1388,7 +2286,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveEntitiesRequest); i {
+			switch v := v.(*ResolveEntitiesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1400,7 +2298,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveEntitiesResponse); i {
+			switch v := v.(*ResolveCoordinatesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1412,7 +2310,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveCoordinatesRequest); i {
+			switch v := v.(*ResolveCoordinatesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1424,7 +2322,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveCoordinatesResponse); i {
+			switch v := v.(*ResolveGeometryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1436,7 +2334,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveIdsRequest); i {
+			switch v := v.(*ResolveGeometryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1448,7 +2346,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveIdsResponse); i {
+			switch v := v.(*FindNearbyPlacesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1460,7 +2358,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReconEntities_Entity); i {
+			switch v := v.(*FindNearbyPlacesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1472,7 +2370,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReconEntities_Entity_ID); i {
+			switch v := v.(*ResolveIdsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1484,7 +2382,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CoordinateRecon_Place); i {
+			switch v := v.(*ResolveIdsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1496,7 +2394,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompareEntitiesResponse_Comparison); i {
+			switch v := v.(*CrosswalkIdsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1508,7 +2406,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveEntitiesResponse_ResolvedId); i {
+			switch v := v.(*CrosswalkIdsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1520,7 +2418,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveEntitiesResponse_ResolvedEntity); i {
+			switch v := v.(*ReconEntities_Entity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1532,7 +2430,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveCoordinatesRequest_Coordinate); i {
+			switch v := v.(*ReconEntities_Entity_ID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1544,7 +2442,7 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveCoordinatesResponse_PlaceCoordinate); i {
+			switch v := v.(*CoordinateRecon_Place); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1556,6 +2454,114 @@ func file_recon_proto_init() {
 			}
 		}
 		file_recon_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareEntitiesResponse_Comparison); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveEntitiesResponse_ResolvedId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveEntitiesResponse_ResolvedEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveCoordinatesRequest_Coordinate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveCoordinatesResponse_Place); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveCoordinatesResponse_PlaceCoordinate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveGeometryRequest_LatLngRect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveGeometryResponse_Place); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindNearbyPlacesResponse_Place); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResolveIdsResponse_Entity); i {
 			case 0:
 				return &v.state
@@ -1567,6 +2573,34 @@ func file_recon_proto_init() {
 				return nil
 			}
 		}
+		file_recon_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CrosswalkIdsResponse_OutIds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_recon_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CrosswalkIdsResponse_Entity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_recon_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*ResolveGeometryRequest_GeoJson)(nil),
+		(*ResolveGeometryRequest_BoundingBox)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1574,7 +2608,7 @@ func file_recon_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_recon_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,8 +23,15 @@ type ReconClient interface {
 	ResolveEntities(ctx context.Context, in *ResolveEntitiesRequest, opts ...grpc.CallOption) (*ResolveEntitiesResponse, error)
 	// Resolve a list of places, given their latitude and longitude coordinates.
 	ResolveCoordinates(ctx context.Context, in *ResolveCoordinatesRequest, opts ...grpc.CallOption) (*ResolveCoordinatesResponse, error)
+	// Resolve a polygon or a bounding box to the places of a type that intersect
+	// it.
+	ResolveGeometry(ctx context.Context, in *ResolveGeometryRequest, opts ...grpc.CallOption) (*ResolveGeometryResponse, error)
+	// Find the places near a point, by the distance to their centroids.
+	FindNearbyPlaces(ctx context.Context, in *FindNearbyPlacesRequest, opts ...grpc.CallOption) (*FindNearbyPlacesResponse, error)
 	// Resolve a list of IDs, given the input prop and output prop.
 	ResolveIds(ctx context.Context, in *ResolveIdsRequest, opts ...grpc.CallOption) (*ResolveIdsResponse, error)
+	// Translate IDs of several ID properties to several ID properties at once.
+	CrosswalkIds(ctx context.Context, in *CrosswalkIdsRequest, opts ...grpc.CallOption) (*CrosswalkIdsResponse, error)
 }
 
 type reconClient struct {
@@ -62,6 +69,24 @@ func (c *reconClient) ResolveCoordinates(ctx context.Context, in *ResolveCoordin
 	return out, nil
 }
 
+func (c *reconClient) ResolveGeometry(ctx context.Context, in *ResolveGeometryRequest, opts ...grpc.CallOption) (*ResolveGeometryResponse, error) {
+	out := new(ResolveGeometryResponse)
+	err := c.cc.Invoke(ctx, "/datacommons.Recon/ResolveGeometry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reconClient) FindNearbyPlaces(ctx context.Context, in *FindNearbyPlacesRequest, opts ...grpc.CallOption) (*FindNearbyPlacesResponse, error) {
+	out := new(FindNearbyPlacesResponse)
+	err := c.cc.Invoke(ctx, "/datacommons.Recon/FindNearbyPlaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *reconClient) ResolveIds(ctx context.Context, in *ResolveIdsRequest, opts ...grpc.CallOption) (*ResolveIdsResponse, error) {
 	out := new(ResolveIdsResponse)
 	err := c.cc.Invoke(ctx, "/datacommons.Recon/ResolveIds", in, out, opts...)
@@ -71,6 +96,15 @@ func (c *reconClient) ResolveIds(ctx context.Context, in *ResolveIdsRequest, opt
 	return out, nil
 }
 
+func (c *reconClient) CrosswalkIds(ctx context.Context, in *CrosswalkIdsRequest, opts ...grpc.CallOption) (*CrosswalkIdsResponse, error) {
+	out := new(CrosswalkIdsResponse)
+	err := c.cc.Invoke(ctx, "/datacommons.Recon/CrosswalkIds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReconServer is the server API for Recon service.
 // All implementations should embed UnimplementedReconServer
 // for forward compatibility
@@ -81,8 +115,15 @@ type ReconServer interface {
 	ResolveEntities(context.Context, *ResolveEntitiesRequest) (*ResolveEntitiesResponse, error)
 	// Resolve a list of places, given their latitude and longitude coordinates.
 	ResolveCoordinates(context.Context, *ResolveCoordinatesRequest) (*ResolveCoordinatesResponse, error)
+	// Resolve a polygon or a bounding box to the places of a type that intersect
+	// it.
+	ResolveGeometry(context.Context, *ResolveGeometryRequest) (*ResolveGeometryResponse, error)
+	// Find the places near a point, by the distance to their centroids.
+	FindNearbyPlaces(context.Context, *FindNearbyPlacesRequest) (*FindNearbyPlacesResponse, error)
 	// Resolve a list of IDs, given the input prop and output prop.
 	ResolveIds(context.Context, *ResolveIdsRequest) (*ResolveIdsResponse, error)
+	// Translate IDs of several ID properties to several ID properties at once.
+	CrosswalkIds(context.Context, *CrosswalkIdsRequest) (*CrosswalkIdsResponse, error)
 }
 
 // UnimplementedReconServer should be embedded to have forward compatible implementations.
@@ -98,9 +139,18 @@ func (*UnimplementedReconServer) ResolveEntities(context.Context, *ResolveEntiti
 func (*UnimplementedReconServer) ResolveCoordinates(context.Context, *ResolveCoordinatesRequest) (*ResolveCoordinatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResolveCoordinates not implemented")
 }
+func (*UnimplementedReconServer) ResolveGeometry(context.Context, *ResolveGeometryRequest) (*ResolveGeometryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveGeometry not implemented")
+}
+func (*UnimplementedReconServer) FindNearbyPlaces(context.Context, *FindNearbyPlacesRequest) (*FindNearbyPlacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindNearbyPlaces not implemented")
+}
 func (*UnimplementedReconServer) ResolveIds(context.Context, *ResolveIdsRequest) (*ResolveIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResolveIds not implemented")
 }
+func (*UnimplementedReconServer) CrosswalkIds(context.Context, *CrosswalkIdsRequest) (*CrosswalkIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CrosswalkIds not implemented")
+}
 
 func RegisterReconServer(s *grpc.Server, srv ReconServer) {
 	s.RegisterService(&_Recon_serviceDesc, srv)
@@ -160,6 +210,42 @@ func _Recon_ResolveCoordinates_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Recon_ResolveGeometry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveGeometryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconServer).ResolveGeometry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacommons.Recon/ResolveGeometry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconServer).ResolveGeometry(ctx, req.(*ResolveGeometryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recon_FindNearbyPlaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNearbyPlacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconServer).FindNearbyPlaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacommons.Recon/FindNearbyPlaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconServer).FindNearbyPlaces(ctx, req.(*FindNearbyPlacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Recon_ResolveIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResolveIdsRequest)
 	if err := dec(in); err != nil {
@@ -178,6 +264,24 @@ func _Recon_ResolveIds_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Recon_CrosswalkIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrosswalkIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconServer).CrosswalkIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/datacommons.Recon/CrosswalkIds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconServer).CrosswalkIds(ctx, req.(*CrosswalkIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Recon_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "datacommons.Recon",
 	HandlerType: (*ReconServer)(nil),
@@ -194,10 +298,22 @@ var _Recon_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ResolveCoordinates",
 			Handler:    _Recon_ResolveCoordinates_Handler,
 		},
+		{
+			MethodName: "ResolveGeometry",
+			Handler:    _Recon_ResolveGeometry_Handler,
+		},
+		{
+			MethodName: "FindNearbyPlaces",
+			Handler:    _Recon_FindNearbyPlaces_Handler,
+		},
 		{
 			MethodName: "ResolveIds",
 			Handler:    _Recon_ResolveIds_Handler,
 		},
+		{
+			MethodName: "CrosswalkIds",
+			Handler:    _Recon_CrosswalkIds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "recon.proto",
@@ -0,0 +1,73 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminHandler creates an HTTP handler serving the admin API of the server.
+//
+// A GET of /admin/mappings returns the status of the schema mapping, with the
+// errors of the last reload. A POST to /admin/mappings/reload reloads the
+// schema mapping and returns the status.
+func (s *Server) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/mappings", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeStatus(w, http.StatusOK, s.MappingStatus())
+	})
+	mux.HandleFunc("/admin/mappings/reload", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		code := http.StatusOK
+		if err := s.ReloadMappings(r.Context()); err != nil {
+			code = http.StatusInternalServerError
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				code = http.StatusUnprocessableEntity
+			case codes.FailedPrecondition:
+				// The mappings were not read, so the error is not in the status of
+				// the last read.
+				mappingStatus := s.MappingStatus()
+				mappingStatus.Errors = []*MappingError{{Message: status.Convert(err).Message()}}
+				writeStatus(w, http.StatusConflict, mappingStatus)
+				return
+			}
+		}
+		writeStatus(w, code, s.MappingStatus())
+	})
+	return mux
+}
+
+func writeStatus(w http.ResponseWriter, code int, mappingStatus MappingStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(mappingStatus); err != nil {
+		log.Printf("Failed to write admin response: %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/datacommonsorg/mixer/internal/store"
+)
+
+// Maximum number of objects a query can resolve, over all its levels. It
+// bounds the reads of nested selections, which multiply at each level.
+const maxResolvedObjects = 10000
+
+// resolveFunc resolves a field for a batch of parent objects at once, which
+// turns a nested query into one batched read per field and level. The result
+// has one entry per parent: a scalar, an object, a list of objects or nil.
+type resolveFunc func(
+	ctx context.Context,
+	s *store.Store,
+	parents []interface{},
+	args map[string]interface{},
+) ([]interface{}, error)
+
+// fieldDef defines a field of an object type.
+type fieldDef struct {
+	// Type of the objects the field resolves to. Empty for scalar fields.
+	objType string
+	// Names of the required arguments.
+	required []string
+	resolve  resolveFunc
+}
+
+// orderedMap is a JSON object that keeps the order of its keys, so the
+// response follows the order of the fields in the query.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: map[string]interface{}{}}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Execute runs a query operation and returns the data of the response.
+func Execute(
+	ctx context.Context,
+	s *store.Store,
+	op *Operation,
+	variables map[string]interface{},
+) (interface{}, error) {
+	if len(op.Defaults) > 0 {
+		withDefaults := map[string]interface{}{}
+		for k, v := range op.Defaults {
+			withDefaults[k] = v
+		}
+		for k, v := range variables {
+			withDefaults[k] = v
+		}
+		variables = withDefaults
+	}
+	budget := maxResolvedObjects
+	result, err := executeSelections(
+		ctx, s, "Query", []interface{}{nil}, op.Selections, variables, &budget)
+	if err != nil {
+		return nil, err
+	}
+	return result[0], nil
+}
+
+// executeSelections resolves a selection set on a batch of objects of the
+// same type. The budget is the number of objects that can still be resolved.
+func executeSelections(
+	ctx context.Context,
+	s *store.Store,
+	objType string,
+	objs []interface{},
+	sels []*Field,
+	variables map[string]interface{},
+	budget *int,
+) ([]*orderedMap, error) {
+	result := make([]*orderedMap, len(objs))
+	for i := range objs {
+		result[i] = newOrderedMap()
+	}
+	for _, f := range sels {
+		if f.Name == "__typename" {
+			for i := range objs {
+				result[i].set(f.ResponseKey(), objType)
+			}
+			continue
+		}
+		def, ok := schema[objType][f.Name]
+		if !ok {
+			return nil, newError(f.Loc, "Cannot query field %q on type %q", f.Name, objType)
+		}
+		if def.objType == "" && len(f.Selections) > 0 {
+			return nil, newError(f.Loc, "Field %q of type %q must not have a selection", f.Name, objType)
+		}
+		if def.objType != "" && len(f.Selections) == 0 {
+			return nil, newError(f.Loc, "Field %q of type %q must have a selection", f.Name, objType)
+		}
+		args, err := bindArguments(f, def, variables)
+		if err != nil {
+			return nil, err
+		}
+		values, err := def.resolve(ctx, s, objs, args)
+		if err != nil {
+			return nil, newError(f.Loc, "%s", err)
+		}
+		if def.objType == "" {
+			for i := range objs {
+				result[i].set(f.ResponseKey(), values[i])
+			}
+			continue
+		}
+		// Resolve the sub-selection of all the children in one batch.
+		children := []interface{}{}
+		for _, v := range values {
+			if list, ok := v.([]interface{}); ok {
+				children = append(children, list...)
+			} else if v != nil {
+				children = append(children, v)
+			}
+		}
+		*budget -= len(children)
+		if *budget < 0 {
+			return nil, newError(f.Loc, "Query resolves more than %d objects", maxResolvedObjects)
+		}
+		childResult, err := executeSelections(
+			ctx, s, def.objType, children, f.Selections, variables, budget)
+		if err != nil {
+			return nil, err
+		}
+		pos := 0
+		for i, v := range values {
+			if list, ok := v.([]interface{}); ok {
+				items := make([]*orderedMap, len(list))
+				copy(items, childResult[pos:pos+len(list)])
+				pos += len(list)
+				result[i].set(f.ResponseKey(), items)
+			} else if v != nil {
+				result[i].set(f.ResponseKey(), childResult[pos])
+				pos++
+			} else {
+				result[i].set(f.ResponseKey(), nil)
+			}
+		}
+	}
+	return result, nil
+}
+
+// bindArguments substitutes variables in the arguments of a field and checks
+// the required arguments.
+func bindArguments(
+	f *Field, def *fieldDef, variables map[string]interface{},
+) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	for k, v := range f.Arguments {
+		bound, err := bindValue(v, variables)
+		if err != nil {
+			return nil, newError(f.Loc, "%s", err)
+		}
+		args[k] = bound
+	}
+	for _, r := range def.required {
+		if args[r] == nil {
+			return nil, newError(f.Loc, "Missing required argument %q of field %q", r, f.Name)
+		}
+	}
+	return args, nil
+}
+
+func bindValue(v interface{}, variables map[string]interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case variable:
+		bound, ok := variables[string(val)]
+		if !ok {
+			return nil, fmt.Errorf("Variable $%s is not provided", val)
+		}
+		return bound, nil
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			bound, err := bindValue(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = bound
+		}
+		return list, nil
+	case map[string]interface{}:
+		obj := map[string]interface{}{}
+		for k, item := range val {
+			bound, err := bindValue(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			obj[k] = bound
+		}
+		return obj, nil
+	}
+	return v, nil
+}
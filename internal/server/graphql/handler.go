@@ -0,0 +1,122 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/datacommonsorg/mixer/internal/store"
+)
+
+// request is the body of a GraphQL request.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// response is the body of a GraphQL response.
+type response struct {
+	Data   interface{} `json:"data"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Maximum size of a request body or query.
+const maxRequestBytes = 1 << 20
+
+type handler struct {
+	store *store.Store
+}
+
+// NewHandler creates an HTTP handler that serves GraphQL queries.
+//
+// Queries are sent either as a POST with a JSON body, or as a GET with the
+// "query", "operationName" and "variables" URL parameters. A GET without a
+// query returns the schema.
+func NewHandler(store *store.Store) http.Handler {
+	return &handler{store: store}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := &request{}
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if len(req.Query) > maxRequestBytes {
+			writeResponse(w, http.StatusRequestEntityTooLarge, &response{
+				Errors: []*Error{{Message: "Query is too large"}},
+			})
+			return
+		}
+		if req.Query == "" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte(Schema))
+			return
+		}
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				writeResponse(w, http.StatusBadRequest, &response{
+					Errors: []*Error{{Message: "Invalid variables: " + err.Error()}},
+				})
+				return
+			}
+		}
+	case http.MethodPost:
+		body := http.MaxBytesReader(w, r.Body, maxRequestBytes)
+		if err := json.NewDecoder(body).Decode(req); err != nil {
+			writeResponse(w, http.StatusBadRequest, &response{
+				Errors: []*Error{{Message: "Invalid request body: " + err.Error()}},
+			})
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	op, err := Parse(req.Query, req.OperationName)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, &response{Errors: []*Error{toError(err)}})
+		return
+	}
+	data, err := Execute(r.Context(), h.store, op, req.Variables)
+	if err != nil {
+		writeResponse(w, http.StatusOK, &response{Errors: []*Error{toError(err)}})
+		return
+	}
+	writeResponse(w, http.StatusOK, &response{Data: data})
+}
+
+func toError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Message: err.Error()}
+}
+
+func writeResponse(w http.ResponseWriter, code int, resp *response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(resp); err != nil {
+		log.Printf("Failed to write GraphQL response: %v", err)
+	}
+}
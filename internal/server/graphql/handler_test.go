@@ -0,0 +1,148 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/util"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	data := map[string]string{}
+	for key, value := range map[string]string{
+		bigtable.BtOutPropValPrefix + "geoId/06^name": `{"entities": [
+			{"value": "California"}]}`,
+		bigtable.BtOutPropValPrefix + "geoId/06^typeOf": `{"entities": [
+			{"dcid": "State", "name": "State", "types": ["Class"]}]}`,
+		bigtable.BtOutPropValPrefix + "geoId/06^containedInPlace": `{"entities": [
+			{"dcid": "country/USA", "name": "United States", "types": ["Country"]}]}`,
+		bigtable.BtPlacesInPrefix + "geoId/06^County": "geoId/06001,geoId/06085",
+		bigtable.BtOutPropValPrefix + "geoId/06001^name": `{"entities": [
+			{"value": "Alameda County"}]}`,
+		bigtable.BtOutPropValPrefix + "geoId/06085^name": `{"entities": [
+			{"value": "Santa Clara County"}]}`,
+	} {
+		tableValue, err := util.ZipAndEncode([]byte(value))
+		if err != nil {
+			t.Fatalf("util.ZipAndEncode(%s) = %v", value, err)
+		}
+		data[key] = tableValue
+	}
+	btTable, err := bigtable.SetupBigtable(ctx, data)
+	if err != nil {
+		t.Fatalf("SetupBigtable(...) = %v", err)
+	}
+	srv := httptest.NewServer(NewHandler(store.NewStore(nil, nil, btTable, nil)))
+	defer srv.Close()
+
+	manyDcids := make([]string, maxResolvedObjects+1)
+	for i := range manyDcids {
+		manyDcids[i] = fmt.Sprintf(`\"geoId/%d\"`, i)
+	}
+
+	for _, c := range []struct {
+		body string
+		code int
+		want string
+	}{
+		{
+			`{"query": "query Q($dcid: String!) { place(dcid: $dcid) { name types dcid ` +
+				`parents { name __typename } counties: children(type: \"County\") { dcid name } } }",
+			"variables": {"dcid": "geoId/06"}}`,
+			http.StatusOK,
+			`{"data":{"place":{"name":"California","types":["State"],"dcid":"geoId/06",` +
+				`"parents":[{"name":"United States","__typename":"Place"}],` +
+				`"counties":[{"dcid":"geoId/06001","name":"Alameda County"},` +
+				`{"dcid":"geoId/06085","name":"Santa Clara County"}]}}}`,
+		},
+		{
+			`{"query": "{ places(dcids: [\"geoId/06001\", \"geoId/06085\"]) { name } }"}`,
+			http.StatusOK,
+			`{"data":{"places":[{"name":"Alameda County"},{"name":"Santa Clara County"}]}}`,
+		},
+		{
+			`{"query": "{ place(dcid: \"geoId/06\") { population } }"}`,
+			http.StatusOK,
+			`{"data":null,"errors":[{"message":"Cannot query field \"population\" on type \"Place\"",` +
+				`"locations":[{"line":1,"column":29}]}]}`,
+		},
+		{
+			`{"query": "{ place { name } }"}`,
+			http.StatusOK,
+			`{"data":null,"errors":[{"message":"Missing required argument \"dcid\" of field \"place\"",` +
+				`"locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			// Default value of a variable.
+			`{"query": "query Q($dcid: String = \"geoId/06001\") { place(dcid: $dcid) { name } }"}`,
+			http.StatusOK,
+			`{"data":{"place":{"name":"Alameda County"}}}`,
+		},
+		{
+			`{"query": "{ places(dcids: [` + strings.Join(manyDcids, ",") + `]) { dcid } }"}`,
+			http.StatusOK,
+			fmt.Sprintf(`{"data":null,"errors":[{"message":"Query resolves more than %d objects",`+
+				`"locations":[{"line":1,"column":3}]}]}`, maxResolvedObjects),
+		},
+		{
+			`{"query": "` + strings.Repeat(" ", maxRequestBytes) + `{ place { name } }"}`,
+			http.StatusBadRequest,
+			`{"data":null,"errors":[{"message":"Invalid request body: http: request body too large"}]}`,
+		},
+		{
+			`{"query": "{ place(dcid: \"geoId/06\") { name }"}`,
+			http.StatusBadRequest,
+			`{"data":null,"errors":[{"message":"Expected name, found <EOF>",` +
+				`"locations":[{"line":1,"column":35}]}]}`,
+		},
+	} {
+		resp, err := http.Post(srv.URL, "application/json", strings.NewReader(c.body))
+		if err != nil {
+			t.Fatalf("http.Post(%s) = %v", c.body, err)
+		}
+		got, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("ioutil.ReadAll() = %v", err)
+		}
+		if resp.StatusCode != c.code {
+			t.Errorf("POST %s got status %d, want %d", c.body, resp.StatusCode, c.code)
+		}
+		if strings.TrimSpace(string(got)) != c.want {
+			t.Errorf("POST %s got %s, want %s", c.body, got, c.want)
+		}
+	}
+
+	// A GET without query returns the schema.
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("http.Get() = %v", err)
+	}
+	got, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(got) != Schema {
+		t.Errorf("GET got %s, want the schema", got)
+	}
+}
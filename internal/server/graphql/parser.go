@@ -0,0 +1,550 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphql serves a GraphQL endpoint over the knowledge graph and
+// stats.
+//
+// Only the subset of GraphQL needed to query the schema in schema.go is
+// supported: a single query operation with fields, aliases, arguments and
+// variables. Fragments, directives, mutations and introspection are not
+// supported.
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Maximum nesting of selection sets, and of list and object values.
+const maxDepth = 20
+
+// Location is a position in the query text.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is a GraphQL error.
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Locations) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%d:%d: %s", e.Locations[0].Line, e.Locations[0].Column, e.Message)
+}
+
+func newError(loc Location, format string, args ...interface{}) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	loc  Location
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "<EOF>"
+	}
+	return t.text
+}
+
+// lex splits the query into tokens.
+func lex(query string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(query)
+	line, lineStart := 1, 0
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		loc := Location{Line: line, Column: i - lineStart + 1}
+		switch {
+		case r == '\n':
+			line++
+			i++
+			lineStart = i
+		case r == ' ' || r == '\t' || r == '\r' || r == ',' || r == '\uFEFF':
+			i++
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case strings.ContainsRune("!$():=@[]{}|", r):
+			tokens = append(tokens, token{tokPunct, string(r), loc})
+			i++
+		case r == '.':
+			if i+2 < len(runes) && runes[i+1] == '.' && runes[i+2] == '.' {
+				tokens = append(tokens, token{tokPunct, "...", loc})
+				i += 3
+			} else {
+				return nil, newError(loc, "Unexpected character %q", r)
+			}
+		case r == '_' || isLetter(r):
+			j := i
+			for j < len(runes) && (runes[j] == '_' || isLetter(runes[j]) || isDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, token{tokName, string(runes[i:j]), loc})
+			i = j
+		case r == '-' || isDigit(r):
+			j := i + 1
+			kind := tokInt
+			for j < len(runes) && (isDigit(runes[j]) ||
+				strings.ContainsRune(".eE+-", runes[j])) {
+				if !isDigit(runes[j]) {
+					kind = tokFloat
+				}
+				j++
+			}
+			tokens = append(tokens, token{kind, string(runes[i:j]), loc})
+			i = j
+		case r == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\n' {
+					return nil, newError(loc, "Unterminated string")
+				}
+				if runes[j] != '\\' {
+					sb.WriteRune(runes[j])
+					continue
+				}
+				j++
+				if j == len(runes) {
+					break
+				}
+				switch runes[j] {
+				case 'n':
+					sb.WriteRune('\n')
+				case 't':
+					sb.WriteRune('\t')
+				case 'r':
+					sb.WriteRune('\r')
+				case 'b':
+					sb.WriteRune('\b')
+				case 'f':
+					sb.WriteRune('\f')
+				case 'u':
+					if j+4 >= len(runes) {
+						return nil, newError(loc, "Invalid unicode escape")
+					}
+					code, err := strconv.ParseUint(string(runes[j+1:j+5]), 16, 32)
+					if err != nil {
+						return nil, newError(loc, "Invalid unicode escape")
+					}
+					sb.WriteRune(rune(code))
+					j += 4
+				default:
+					sb.WriteRune(runes[j])
+				}
+			}
+			if j >= len(runes) {
+				return nil, newError(loc, "Unterminated string")
+			}
+			tokens = append(tokens, token{tokString, sb.String(), loc})
+			i = j + 1
+		default:
+			return nil, newError(loc, "Unexpected character %q", r)
+		}
+	}
+	tokens = append(tokens, token{
+		kind: tokEOF,
+		loc:  Location{Line: line, Column: len(runes) - lineStart + 1},
+	})
+	return tokens, nil
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// Field is a field in a selection set.
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]interface{}
+	Selections []*Field
+	Loc        Location
+}
+
+// ResponseKey is the key of the field in the response.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// variable is a reference to a query variable in an argument value.
+type variable string
+
+// Operation is a parsed query operation.
+type Operation struct {
+	Name       string
+	Selections []*Field
+	// Default values of the variables that have one.
+	Defaults map[string]interface{}
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	// Nesting of the selection set or value being parsed.
+	depth int
+}
+
+// enter enters a nested selection set or value, up to maxDepth.
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return newError(p.peek().loc, "Query is nested more than %d levels", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isPunct(text string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.text == text
+}
+
+func (p *parser) expectPunct(text string) error {
+	t := p.next()
+	if t.kind != tokPunct || t.text != text {
+		return newError(t.loc, "Expected %q, found %s", text, t)
+	}
+	return nil
+}
+
+func (p *parser) expectName() (token, error) {
+	t := p.next()
+	if t.kind != tokName {
+		return t, newError(t.loc, "Expected name, found %s", t)
+	}
+	return t, nil
+}
+
+// Parse parses a query document. When the document has several operations,
+// operationName selects the one to run.
+func Parse(query, operationName string) (*Operation, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	ops := []*Operation{}
+	for p.peek().kind != tokEOF {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) == 0 {
+		return nil, &Error{Message: "Missing query operation"}
+	}
+	if operationName == "" {
+		if len(ops) > 1 {
+			return nil, &Error{Message: "Missing operationName for a document with several operations"}
+		}
+		return ops[0], nil
+	}
+	for _, op := range ops {
+		if op.Name == operationName {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation %s", operationName)}
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{}
+	if t := p.peek(); t.kind == tokName {
+		if t.text == "fragment" {
+			return nil, newError(t.loc, "Fragments are not supported")
+		}
+		if t.text != "query" {
+			return nil, newError(t.loc, "Only query operations are supported, found %s", t)
+		}
+		p.next()
+		if p.peek().kind == tokName {
+			op.Name = p.next().text
+		}
+		if p.isPunct("(") {
+			defaults, err := p.parseVariableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+			op.Defaults = defaults
+		}
+	}
+	if p.isPunct("@") {
+		return nil, newError(p.peek().loc, "Directives are not supported")
+	}
+	sels, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = sels
+	return op, nil
+}
+
+// parseVariableDefinitions parses the variable definitions of an operation,
+// and gets the default values of the variables that have one. Variable types
+// are not checked; values come from the request as is.
+func (p *parser) parseVariableDefinitions() (map[string]interface{}, error) {
+	p.next()
+	var defaults map[string]interface{}
+	for !p.isPunct(")") {
+		if err := p.expectPunct("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		if err := p.skipType(); err != nil {
+			return nil, err
+		}
+		if p.isPunct("=") {
+			p.next()
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := value.(variable); ok {
+				return nil, newError(name.loc, "Default value of $%s must be a constant", name.text)
+			}
+			if defaults == nil {
+				defaults = map[string]interface{}{}
+			}
+			defaults[name.text] = value
+		}
+	}
+	p.next()
+	return defaults, nil
+}
+
+// skipType skips a variable type, like "String", "[String!]" or "Int!".
+func (p *parser) skipType() error {
+	if p.isPunct("[") {
+		p.next()
+		if err := p.enter(); err != nil {
+			return err
+		}
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		p.leave()
+		if err := p.expectPunct("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return err
+	}
+	if p.isPunct("!") {
+		p.next()
+	}
+	return nil
+}
+
+func (p *parser) parseSelectionSet() ([]*Field, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	fields := []*Field{}
+	for !p.isPunct("}") {
+		if p.isPunct("...") {
+			return nil, newError(p.peek().loc, "Fragments are not supported")
+		}
+		f, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	p.next()
+	if len(fields) == 0 {
+		return nil, newError(p.tokens[p.pos-1].loc, "Empty selection set")
+	}
+	return fields, nil
+}
+
+func (p *parser) parseField() (*Field, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	f := &Field{Name: name.text, Arguments: map[string]interface{}{}, Loc: name.loc}
+	if p.isPunct(":") {
+		p.next()
+		name, err = p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		f.Alias = f.Name
+		f.Name = name.text
+	}
+	if p.isPunct("(") {
+		p.next()
+		for !p.isPunct(")") {
+			arg, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return nil, err
+			}
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			f.Arguments[arg.text] = value
+		}
+		p.next()
+	}
+	if p.isPunct("@") {
+		return nil, newError(p.peek().loc, "Directives are not supported")
+	}
+	if p.isPunct("{") {
+		f.Selections, err = p.parseSelectionSet()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseValue() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return t.text, nil
+	case tokInt:
+		v, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return nil, newError(t.loc, "Invalid integer %s", t)
+		}
+		return float64(v), nil
+	case tokFloat:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, newError(t.loc, "Invalid float %s", t)
+		}
+		return v, nil
+	case tokName:
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		// Enum values are passed as strings.
+		return t.text, nil
+	case tokPunct:
+		switch t.text {
+		case "$":
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			return variable(name.text), nil
+		case "[":
+			if err := p.enter(); err != nil {
+				return nil, err
+			}
+			defer p.leave()
+			list := []interface{}{}
+			for !p.isPunct("]") {
+				if p.peek().kind == tokEOF {
+					return nil, newError(p.peek().loc, "Unterminated list")
+				}
+				v, err := p.parseValue()
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, v)
+			}
+			p.next()
+			return list, nil
+		case "{":
+			if err := p.enter(); err != nil {
+				return nil, err
+			}
+			defer p.leave()
+			obj := map[string]interface{}{}
+			for !p.isPunct("}") {
+				name, err := p.expectName()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expectPunct(":"); err != nil {
+					return nil, err
+				}
+				v, err := p.parseValue()
+				if err != nil {
+					return nil, err
+				}
+				obj[name.text] = v
+			}
+			p.next()
+			return obj, nil
+		}
+	}
+	return nil, newError(t.loc, "Unexpected %s", t)
+}
@@ -0,0 +1,115 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	for _, c := range []struct {
+		query         string
+		operationName string
+		want          *Operation
+	}{
+		{
+			`{ place(dcid: "geoId/06") { name } }`,
+			"",
+			&Operation{
+				Selections: []*Field{
+					{
+						Name:      "place",
+						Arguments: map[string]interface{}{"dcid": "geoId/06"},
+						Loc:       Location{1, 3},
+						Selections: []*Field{
+							{Name: "name", Arguments: map[string]interface{}{}, Loc: Location{1, 29}},
+						},
+					},
+				},
+			},
+		},
+		{
+			`# Comment
+			query A($sv: [String!]!, $n: Int = 3) {
+			  ca: place(dcid: "geoId/06") {
+			    stats(statVars: $sv, limit: 3, scale: -1.5e2, ok: true, x: null)
+			  }
+			}
+			query B { node(dcid: "a\"b") { dcid } }`,
+			"A",
+			&Operation{
+				Name:     "A",
+				Defaults: map[string]interface{}{"n": float64(3)},
+				Selections: []*Field{
+					{
+						Alias:     "ca",
+						Name:      "place",
+						Arguments: map[string]interface{}{"dcid": "geoId/06"},
+						Loc:       Location{3, 6},
+						Selections: []*Field{
+							{
+								Name: "stats",
+								Arguments: map[string]interface{}{
+									"statVars": variable("sv"),
+									"limit":    float64(3),
+									"scale":    -150.0,
+									"ok":       true,
+									"x":        nil,
+								},
+								Loc: Location{4, 8},
+							},
+						},
+					},
+				},
+			},
+		},
+	} {
+		got, err := Parse(c.query, c.operationName)
+		if err != nil {
+			t.Fatalf("Parse(%s) = %s", c.query, err)
+		}
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("Parse(%s) got diff %+v", c.query, diff)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, c := range []struct {
+		query string
+		want  string
+	}{
+		{`{ place(dcid: "geoId/06") { name }`, `1:35: Expected name, found <EOF>`},
+		{`mutation { a }`, `1:1: Only query operations are supported, found mutation`},
+		{`{ place { ...F } }`, `1:11: Fragments are not supported`},
+		{`{ place(dcid: "a) { name } }`, `1:15: Unterminated string`},
+		{`{ a } { b }`, `Missing operationName for a document with several operations`},
+		{`{}`, `1:2: Empty selection set`},
+		{`query Q($a: Int = $b) { a }`, `1:10: Default value of $a must be a constant`},
+		{strings.Repeat("{ a ", maxDepth+1), `1:83: Query is nested more than 20 levels`},
+	} {
+		_, err := Parse(c.query, "")
+		if err == nil {
+			t.Errorf("Parse(%s) expected error", c.query)
+			continue
+		}
+		if err.Error() != c.want {
+			t.Errorf("Parse(%s) = %s, want %s", c.query, err, c.want)
+		}
+	}
+}
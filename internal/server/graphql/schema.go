@@ -0,0 +1,418 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/server/node"
+	"github.com/datacommonsorg/mixer/internal/server/place"
+	"github.com/datacommonsorg/mixer/internal/server/stat"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/util"
+)
+
+// Schema is the GraphQL schema served by the handler, in schema definition
+// language.
+const Schema = `type Query {
+  place(dcid: String!): Place
+  places(dcids: [String!]!): [Place]
+  node(dcid: String!): Node
+}
+
+type Place {
+  dcid: String
+  name: String
+  types: [String]
+  parents(type: String): [Place]
+  children(type: String!): [Place]
+  stats(statVars: [String!]!, date: String): [Stat]
+  propertyValues(property: String!, direction: String, type: String): [Node]
+}
+
+type Node {
+  dcid: String
+  name: String
+  value: String
+  types: [String]
+  propertyValues(property: String!, direction: String, type: String): [Node]
+}
+
+type Stat {
+  statVar: String
+  date: String
+  value: Float
+  importName: String
+  provenanceUrl: String
+}
+`
+
+// schema holds the field definitions of each type in Schema.
+var schema map[string]map[string]*fieldDef
+
+func init() {
+	propertyValues := &fieldDef{
+		objType:  "Node",
+		required: []string{"property"},
+		resolve:  resolvePropertyValues,
+	}
+	nodeFields := map[string]*fieldDef{
+		"dcid":           {resolve: nodeField(func(n *model.Node) interface{} { return n.Dcid })},
+		"name":           {resolve: resolveName},
+		"value":          {resolve: nodeField(func(n *model.Node) interface{} { return n.Value })},
+		"types":          {resolve: resolveTypes},
+		"propertyValues": propertyValues,
+	}
+	schema = map[string]map[string]*fieldDef{
+		"Query": {
+			"place":  {objType: "Place", required: []string{"dcid"}, resolve: resolveNode},
+			"places": {objType: "Place", required: []string{"dcids"}, resolve: resolveNodes},
+			"node":   {objType: "Node", required: []string{"dcid"}, resolve: resolveNode},
+		},
+		"Place": {
+			"dcid":           nodeFields["dcid"],
+			"name":           nodeFields["name"],
+			"types":          nodeFields["types"],
+			"parents":        {objType: "Place", resolve: resolveParents},
+			"children":       {objType: "Place", required: []string{"type"}, resolve: resolveChildren},
+			"stats":          {objType: "Stat", required: []string{"statVars"}, resolve: resolveStats},
+			"propertyValues": propertyValues,
+		},
+		"Node": nodeFields,
+		"Stat": {
+			"statVar":       {resolve: statField(func(s *statValue) interface{} { return s.statVar })},
+			"date":          {resolve: statField(func(s *statValue) interface{} { return s.date })},
+			"value":         {resolve: statField(func(s *statValue) interface{} { return s.value })},
+			"importName":    {resolve: statField(func(s *statValue) interface{} { return s.importName })},
+			"provenanceUrl": {resolve: statField(func(s *statValue) interface{} { return s.provenanceURL })},
+		},
+	}
+}
+
+// statValue is a Stat object.
+type statValue struct {
+	statVar       string
+	date          string
+	value         float64
+	importName    string
+	provenanceURL string
+}
+
+func stringArg(args map[string]interface{}, name string) (string, error) {
+	v, ok := args[name]
+	if !ok || v == nil {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("Argument %q should be a string", name)
+	}
+	return s, nil
+}
+
+func stringListArg(args map[string]interface{}, name string) ([]string, error) {
+	v, ok := args[name]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	// A single value is accepted in place of a list.
+	if s, ok := v.(string); ok {
+		return []string{s}, nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Argument %q should be a list of strings", name)
+	}
+	result := []string{}
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("Argument %q should be a list of strings", name)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// dedupe returns the distinct dcids of nodes, which is the batch to read.
+func dedupe(nodes []interface{}) []string {
+	result := []string{}
+	seen := map[string]struct{}{}
+	for _, n := range nodes {
+		dcid := n.(*model.Node).Dcid
+		if _, ok := seen[dcid]; ok || dcid == "" {
+			continue
+		}
+		seen[dcid] = struct{}{}
+		result = append(result, dcid)
+	}
+	return result
+}
+
+func filterType(nodes []*model.Node, typ string) []interface{} {
+	result := []interface{}{}
+	for _, n := range nodes {
+		if typ == "" {
+			result = append(result, n)
+			continue
+		}
+		for _, t := range n.Types {
+			if t == typ {
+				result = append(result, n)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func nodeField(get func(*model.Node) interface{}) resolveFunc {
+	return func(
+		ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+	) ([]interface{}, error) {
+		result := make([]interface{}, len(parents))
+		for i, p := range parents {
+			result[i] = get(p.(*model.Node))
+		}
+		return result, nil
+	}
+}
+
+func statField(get func(*statValue) interface{}) resolveFunc {
+	return func(
+		ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+	) ([]interface{}, error) {
+		result := make([]interface{}, len(parents))
+		for i, p := range parents {
+			result[i] = get(p.(*statValue))
+		}
+		return result, nil
+	}
+}
+
+func resolveNode(
+	ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+) ([]interface{}, error) {
+	dcid, err := stringArg(args, "dcid")
+	if err != nil {
+		return nil, err
+	}
+	if !util.CheckValidDCIDs([]string{dcid}) {
+		return nil, fmt.Errorf("Invalid DCID %s", dcid)
+	}
+	return []interface{}{&model.Node{Dcid: dcid}}, nil
+}
+
+func resolveNodes(
+	ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+) ([]interface{}, error) {
+	dcids, err := stringListArg(args, "dcids")
+	if err != nil {
+		return nil, err
+	}
+	if !util.CheckValidDCIDs(dcids) {
+		return nil, fmt.Errorf("Invalid DCIDs")
+	}
+	nodes := []interface{}{}
+	for _, dcid := range dcids {
+		nodes = append(nodes, &model.Node{Dcid: dcid})
+	}
+	return []interface{}{nodes}, nil
+}
+
+// resolveName reads the names missing from the nodes in one batch.
+func resolveName(
+	ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+) ([]interface{}, error) {
+	missing := []interface{}{}
+	for _, p := range parents {
+		if n := p.(*model.Node); n.Name == "" && n.Dcid != "" {
+			missing = append(missing, n)
+		}
+	}
+	names := map[string][]*model.Node{}
+	if dcids := dedupe(missing); len(dcids) > 0 {
+		var err error
+		names, err = node.GetPropertyValuesHelper(ctx, s, dcids, "name", true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := make([]interface{}, len(parents))
+	for i, p := range parents {
+		n := p.(*model.Node)
+		if n.Name == "" && len(names[n.Dcid]) > 0 {
+			n.Name = names[n.Dcid][0].Value
+		}
+		if n.Name != "" {
+			result[i] = n.Name
+		}
+	}
+	return result, nil
+}
+
+// resolveTypes reads the types missing from the nodes in one batch.
+func resolveTypes(
+	ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+) ([]interface{}, error) {
+	missing := []interface{}{}
+	for _, p := range parents {
+		if n := p.(*model.Node); len(n.Types) == 0 && n.Dcid != "" {
+			missing = append(missing, n)
+		}
+	}
+	types := map[string][]*model.Node{}
+	if dcids := dedupe(missing); len(dcids) > 0 {
+		var err error
+		types, err = node.GetPropertyValuesHelper(ctx, s, dcids, "typeOf", true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := make([]interface{}, len(parents))
+	for i, p := range parents {
+		n := p.(*model.Node)
+		if len(n.Types) == 0 {
+			for _, t := range types[n.Dcid] {
+				n.Types = append(n.Types, t.Dcid)
+			}
+		}
+		result[i] = n.Types
+	}
+	return result, nil
+}
+
+func resolvePropertyValues(
+	ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+) ([]interface{}, error) {
+	prop, err := stringArg(args, "property")
+	if err != nil {
+		return nil, err
+	}
+	direction, err := stringArg(args, "direction")
+	if err != nil {
+		return nil, err
+	}
+	if direction != "" && direction != "in" && direction != "out" {
+		return nil, fmt.Errorf("Invalid direction %s", direction)
+	}
+	typ, err := stringArg(args, "type")
+	if err != nil {
+		return nil, err
+	}
+	values := map[string][]*model.Node{}
+	if dcids := dedupe(parents); len(dcids) > 0 {
+		values, err = node.GetPropertyValuesHelper(ctx, s, dcids, prop, direction != "in")
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := make([]interface{}, len(parents))
+	for i, p := range parents {
+		result[i] = filterType(values[p.(*model.Node).Dcid], typ)
+	}
+	return result, nil
+}
+
+func resolveParents(
+	ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+) ([]interface{}, error) {
+	return resolvePropertyValues(ctx, s, parents, map[string]interface{}{
+		"property":  "containedInPlace",
+		"direction": "out",
+		"type":      args["type"],
+	})
+}
+
+func resolveChildren(
+	ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+) ([]interface{}, error) {
+	typ, err := stringArg(args, "type")
+	if err != nil {
+		return nil, err
+	}
+	children := map[string][]interface{}{}
+	if dcids := dedupe(parents); len(dcids) > 0 {
+		resp, err := place.GetPlacesIn(
+			ctx, &pb.GetPlacesInRequest{Dcids: dcids, PlaceType: typ}, s)
+		if err != nil {
+			return nil, err
+		}
+		var payload []map[string]string
+		if err := json.Unmarshal([]byte(resp.GetPayload()), &payload); err != nil {
+			return nil, err
+		}
+		for _, item := range payload {
+			children[item["dcid"]] = append(
+				children[item["dcid"]],
+				&model.Node{Dcid: item["place"], Types: []string{typ}})
+		}
+	}
+	result := make([]interface{}, len(parents))
+	for i, p := range parents {
+		result[i] = append([]interface{}{}, children[p.(*model.Node).Dcid]...)
+	}
+	return result, nil
+}
+
+func resolveStats(
+	ctx context.Context, s *store.Store, parents []interface{}, args map[string]interface{},
+) ([]interface{}, error) {
+	statVars, err := stringListArg(args, "statVars")
+	if err != nil {
+		return nil, err
+	}
+	date, err := stringArg(args, "date")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, len(parents))
+	for i := range result {
+		result[i] = []interface{}{}
+	}
+	dcids := dedupe(parents)
+	if len(dcids) == 0 || len(statVars) == 0 {
+		return result, nil
+	}
+	resp, err := stat.GetStatSet(
+		ctx, &pb.GetStatSetRequest{Places: dcids, StatVars: statVars, Date: date}, s)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range parents {
+		dcid := p.(*model.Node).Dcid
+		stats := []interface{}{}
+		for _, sv := range statVars {
+			point := resp.GetData()[sv].GetStat()[dcid]
+			if point == nil {
+				continue
+			}
+			meta := resp.GetMetadata()[point.GetMetaHash()]
+			stats = append(stats, &statValue{
+				statVar:       sv,
+				date:          point.GetDate(),
+				value:         point.GetValue(),
+				importName:    meta.GetImportName(),
+				provenanceURL: meta.GetProvenanceUrl(),
+			})
+		}
+		result[i] = stats
+	}
+	return result, nil
+}
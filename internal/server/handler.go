@@ -24,6 +24,7 @@ import (
 	"github.com/datacommonsorg/mixer/internal/server/place"
 	"github.com/datacommonsorg/mixer/internal/server/placepage"
 	"github.com/datacommonsorg/mixer/internal/server/recon"
+	"github.com/datacommonsorg/mixer/internal/server/schema"
 	"github.com/datacommonsorg/mixer/internal/server/search"
 	"github.com/datacommonsorg/mixer/internal/server/stat"
 	"github.com/datacommonsorg/mixer/internal/server/statvar"
@@ -34,14 +35,14 @@ import (
 func (s *Server) Translate(ctx context.Context, in *pb.TranslateRequest) (
 	*pb.TranslateResponse, error,
 ) {
-	return translator.Translate(ctx, in, s.metadata)
+	return translator.Translate(ctx, in, s.getMetadata())
 }
 
 // Query implements API for Mixer.Query.
 func (s *Server) Query(ctx context.Context, in *pb.QueryRequest) (
 	*pb.QueryResponse, error,
 ) {
-	return translator.Query(ctx, in, s.metadata, s.store)
+	return translator.Query(ctx, in, s.getMetadata(), s.store)
 }
 
 // GetStatValue implements API for Mixer.GetStatValue.
@@ -209,6 +210,13 @@ func (s *Server) SearchStatVar(
 	return statvar.SearchStatVar(ctx, in, s.store, s.cache)
 }
 
+// LookupStatVar implements API for Mixer.LookupStatVar.
+func (s *Server) LookupStatVar(
+	ctx context.Context, in *pb.LookupStatVarRequest,
+) (*pb.LookupStatVarResponse, error) {
+	return statvar.LookupStatVar(ctx, in, s.store, s.cache)
+}
+
 // GetPropertyLabels implements API for Mixer.GetPropertyLabels.
 func (s *Server) GetPropertyLabels(
 	ctx context.Context, in *pb.GetPropertyLabelsRequest,
@@ -220,13 +228,37 @@ func (s *Server) GetPropertyLabels(
 func (s *Server) GetPropertyValues(
 	ctx context.Context, in *pb.GetPropertyValuesRequest,
 ) (*pb.GetPropertyValuesResponse, error) {
-	return node.GetPropertyValues(ctx, in, s.store)
+	return node.GetPropertyValues(ctx, in, s.store, s.getMetadata())
 }
 
 // GetTriples implements API for Mixer.GetTriples.
 func (s *Server) GetTriples(ctx context.Context, in *pb.GetTriplesRequest,
 ) (*pb.GetTriplesResponse, error) {
-	return node.GetTriples(ctx, in, s.store, s.metadata)
+	return node.GetTriples(ctx, in, s.store, s.getMetadata())
+}
+
+// Traverse implements API for Mixer.Traverse.
+func (s *Server) Traverse(ctx context.Context, in *pb.TraverseRequest,
+) (*pb.TraverseResponse, error) {
+	return node.Traverse(ctx, in, s.store)
+}
+
+// FindPaths implements API for Mixer.FindPaths.
+func (s *Server) FindPaths(ctx context.Context, in *pb.FindPathsRequest,
+) (*pb.FindPathsResponse, error) {
+	return node.FindPaths(ctx, in, s.store)
+}
+
+// GetTypeSchema implements API for Mixer.GetTypeSchema.
+func (s *Server) GetTypeSchema(ctx context.Context, in *pb.GetTypeSchemaRequest,
+) (*pb.GetTypeSchemaResponse, error) {
+	return schema.GetTypeSchema(ctx, in, s.store, s.getMetadata())
+}
+
+// GetPropertySchema implements API for Mixer.GetPropertySchema.
+func (s *Server) GetPropertySchema(ctx context.Context, in *pb.GetPropertySchemaRequest,
+) (*pb.GetPropertySchemaResponse, error) {
+	return schema.GetPropertySchema(ctx, in, s.store)
 }
 
 // GetPlacePageData implements API for Mixer.GetPlacePageData.
@@ -253,7 +285,10 @@ func (s *Server) GetBioPageData(
 func (s *Server) Search(
 	ctx context.Context, in *pb.SearchRequest,
 ) (*pb.SearchResponse, error) {
-	return search.Search(ctx, in, s.store.BqClient, s.metadata.Bq)
+	if s.searchIndex != nil {
+		return s.searchIndex.Search(in), nil
+	}
+	return search.Search(ctx, in, s.store.BqClient, s.getMetadata().Bq)
 }
 
 // GetVersion implements API for Mixer.GetVersion.
@@ -275,32 +310,51 @@ func (s *Server) ResolveIds(
 	return recon.ResolveIds(ctx, in, s.store)
 }
 
+// CrosswalkIds implements API for Recon.CrosswalkIds.
+func (s *Server) CrosswalkIds(
+	ctx context.Context, in *pb.CrosswalkIdsRequest,
+) (*pb.CrosswalkIdsResponse, error) {
+	return recon.CrosswalkIds(ctx, in, s.store)
+}
+
 // ResolveEntities implements API for ReconServer.ResolveEntities.
 func (s *Server) ResolveEntities(
 	ctx context.Context, in *pb.ResolveEntitiesRequest,
 ) (*pb.ResolveEntitiesResponse, error) {
-	return recon.ResolveEntities(ctx, in, s.store)
+	return recon.ResolveEntities(ctx, in, s.store, s.nameIndex)
 }
 
 // ResolveCoordinates implements API for ReconServer.ResolveCoordinates.
 func (s *Server) ResolveCoordinates(
 	ctx context.Context, in *pb.ResolveCoordinatesRequest,
 ) (*pb.ResolveCoordinatesResponse, error) {
+	if s.boundaryIndex != nil {
+		return s.boundaryIndex.ResolveCoordinates(in), nil
+	}
 	return recon.ResolveCoordinates(ctx, in, s.store)
 }
 
+// ResolveGeometry implements API for ReconServer.ResolveGeometry.
+func (s *Server) ResolveGeometry(
+	ctx context.Context, in *pb.ResolveGeometryRequest,
+) (*pb.ResolveGeometryResponse, error) {
+	if s.boundaryIndex != nil {
+		return s.boundaryIndex.ResolveGeometry(in)
+	}
+	return recon.ResolveGeometry(ctx, in, s.store)
+}
+
+// FindNearbyPlaces implements API for ReconServer.FindNearbyPlaces.
+func (s *Server) FindNearbyPlaces(
+	ctx context.Context, in *pb.FindNearbyPlacesRequest,
+) (*pb.FindNearbyPlacesResponse, error) {
+	return recon.FindNearbyPlaces(ctx, in, s.store)
+}
+
 // CompareEntities implements API for Recon.CompareEntities.
 func (s *Server) CompareEntities(
 	ctx context.Context, in *pb.CompareEntitiesRequest,
 ) (
 	*pb.CompareEntitiesResponse, error) {
-	// TODO(spaceenter): Implement.
-	return &pb.CompareEntitiesResponse{
-		Comparisons: []*pb.CompareEntitiesResponse_Comparison{
-			{
-				SourceIds:   []string{"aaa", "bbb"},
-				Probability: 0.67,
-			},
-		},
-	}, nil
+	return recon.CompareEntities(ctx, in)
 }
@@ -0,0 +1,345 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	pubsub "cloud.google.com/go/pubsub"
+	"cloud.google.com/go/storage"
+	"github.com/datacommonsorg/mixer/internal/parser/mcf"
+	dcpubsub "github.com/datacommonsorg/mixer/internal/pubsub"
+	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/store/sqldb"
+	"github.com/datacommonsorg/mixer/internal/translator/solver"
+	"github.com/datacommonsorg/mixer/internal/translator/types"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MappingError is an error in the schema mapping files.
+type MappingError struct {
+	// Name of the file. Empty if the error is not specific to a mapping file.
+	File    string `json:"file,omitempty"`
+	Message string `json:"message"`
+}
+
+// MappingStatus is the status of the schema mapping, reported by the admin
+// API.
+type MappingStatus struct {
+	// Time when the mappings in use were loaded.
+	LoadTime time.Time `json:"loadTime"`
+	// Number of mappings in use.
+	NumMappings int `json:"numMappings"`
+	// Time when the mapping files were last read.
+	CheckTime time.Time `json:"checkTime"`
+	// Errors of the last read. The mappings in use are kept if there is any.
+	Errors []*MappingError `json:"errors,omitempty"`
+}
+
+// parseGcsPath splits a GCS path like "gs://bucket/folder" into the bucket and
+// the object prefix.
+func parseGcsPath(p string) (string, string, bool) {
+	if !strings.HasPrefix(p, "gs://") {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(p, "gs://"), "/", 2)
+	if len(parts) == 1 {
+		return parts[0], "", true
+	}
+	return parts[0], parts[1], true
+}
+
+// readMappingFiles reads the content of the *.mcf schema mapping files in a
+// local directory or a GCS folder, keyed by file name.
+func readMappingFiles(ctx context.Context, schemaPath string) (map[string]string, error) {
+	result := map[string]string{}
+	if bucket, prefix, ok := parseGcsPath(schemaPath); ok {
+		client, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer client.Close()
+		bkt := client.Bucket(bucket)
+		it := bkt.Objects(ctx, &storage.Query{Prefix: prefix})
+		for {
+			attrs, err := it.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !strings.HasSuffix(attrs.Name, ".mcf") {
+				continue
+			}
+			r, err := bkt.Object(attrs.Name).NewReader(ctx)
+			if err != nil {
+				return nil, err
+			}
+			content, err := ioutil.ReadAll(r)
+			r.Close()
+			if err != nil {
+				return nil, err
+			}
+			result[attrs.Name] = string(content)
+		}
+		return result, nil
+	}
+	files, err := ioutil.ReadDir(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), ".mcf") {
+			content, err := ioutil.ReadFile(filepath.Join(schemaPath, f.Name()))
+			if err != nil {
+				return nil, err
+			}
+			result[f.Name()] = string(content)
+		}
+	}
+	return result, nil
+}
+
+// parseMappings parses and validates the schema mapping files. The errors of
+// all the files are returned, so they can be fixed at once.
+func parseMappings(files map[string]string, bqDataset string) (
+	[]*types.Mapping, []*MappingError) {
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []*types.Mapping{}
+	errs := []*MappingError{}
+	// File of each mapping, to report the references to undefined entities.
+	fileOf := map[*types.Mapping]string{}
+	for _, name := range names {
+		mappings, err := mcf.ParseMapping(files[name], bqDataset)
+		if err == nil {
+			_, err = solver.GetFuncDeps(mappings)
+		}
+		if err != nil {
+			errs = append(errs, &MappingError{File: name, Message: status.Convert(err).Message()})
+			continue
+		}
+		for _, m := range mappings {
+			fileOf[m] = name
+		}
+		result = append(result, mappings...)
+	}
+	// Entities referred to by other nodes, like provenances, need a dcid.
+	hasDcid := map[types.Entity]struct{}{}
+	for _, m := range result {
+		if m.Pred == "dcid" {
+			hasDcid[m.Sub] = struct{}{}
+		}
+	}
+	for _, m := range result {
+		if e, ok := m.Obj.(types.Entity); ok {
+			if _, ok := hasDcid[e]; !ok {
+				errs = append(errs, &MappingError{
+					File:    fileOf[m],
+					Message: fmt.Sprintf("Entity %s of %v has no dcid mapping", e, m.Pred),
+				})
+			}
+		}
+	}
+	if len(result) == 0 && len(errs) == 0 {
+		errs = append(errs, &MappingError{Message: "No schema mapping files"})
+	}
+	return result, errs
+}
+
+// loadMappings reads the schema mapping files and the subtype map.
+func loadMappings(ctx context.Context, bqDataset, schemaPath, tableTypesPath string) (
+	[]*types.Mapping, map[string]string, []*MappingError) {
+	subTypeMap, err := solver.GetSubTypeMap(tableTypesPath)
+	if err != nil {
+		return nil, nil, []*MappingError{
+			{File: filepath.Base(tableTypesPath), Message: err.Error()}}
+	}
+	files, err := readMappingFiles(ctx, schemaPath)
+	if err != nil {
+		return nil, nil, []*MappingError{{Message: err.Error()}}
+	}
+	mappings, errs := parseMappings(files, bqDataset)
+	return mappings, subTypeMap, errs
+}
+
+// getMetadata gets the metadata, which is swapped when the schema mapping is
+// reloaded.
+func (s *Server) getMetadata() *resource.Metadata {
+	s.metadataLock.RLock()
+	defer s.metadataLock.RUnlock()
+	return s.metadata
+}
+
+// MappingStatus gets the status of the schema mapping.
+func (s *Server) MappingStatus() MappingStatus {
+	s.metadataLock.RLock()
+	defer s.metadataLock.RUnlock()
+	return s.mappingStatus
+}
+
+// ReloadMappings reads the schema mapping files and table_types.json again.
+// If they are valid, the mappings and the subtype map are swapped at once, and
+// the cached query results are dropped. Otherwise the mappings in use are kept,
+// and the errors are reported in the mapping status.
+func (s *Server) ReloadMappings(ctx context.Context) error {
+	current := s.getMetadata()
+	if err := s.checkReloadable(current); err != nil {
+		return err
+	}
+	mappings, subTypeMap, errs := loadMappings(
+		ctx, current.Bq, current.SchemaPath, current.TableTypesPath)
+
+	s.metadataLock.Lock()
+	defer s.metadataLock.Unlock()
+	now := time.Now()
+	s.mappingStatus.CheckTime = now
+	s.mappingStatus.Errors = errs
+	if len(errs) > 0 {
+		return status.Errorf(codes.InvalidArgument,
+			"Failed to reload schema mapping with %d errors, the first is: %s %s",
+			len(errs), errs[0].File, errs[0].Message)
+	}
+	metadata := *s.metadata
+	metadata.Mappings = mappings
+	metadata.SubTypeMap = subTypeMap
+	metadata.OutArcInfo = map[string]map[string][]types.OutArcInfo{}
+	metadata.InArcInfo = map[string][]types.InArcInfo{}
+	metadata.MappingGeneration++
+	s.metadata = &metadata
+	s.mappingStatus.LoadTime = now
+	s.mappingStatus.NumMappings = len(mappings)
+	if s.store.QueryCache != nil {
+		s.store.QueryCache.Purge()
+	}
+	log.Printf("Reloaded %d schema mappings from %s", len(mappings), metadata.SchemaPath)
+	return nil
+}
+
+// checkReloadable checks that the schema mapping of the metadata can be
+// reloaded. It can not with a SQLite database, whose tables are created from
+// the mappings at startup and would not match reloaded mappings.
+func (s *Server) checkReloadable(metadata *resource.Metadata) error {
+	if metadata == nil || metadata.SchemaPath == "" {
+		return status.Errorf(codes.FailedPrecondition, "No schema mapping to reload")
+	}
+	if _, ok := s.store.SQLDb.(*sqldb.SQLite); ok {
+		return status.Errorf(codes.FailedPrecondition,
+			"Schema mapping of a SQLite database can not be reloaded, restart the server instead")
+	}
+	return nil
+}
+
+// localMappingVersion gets the version of the local schema mapping files and
+// table_types.json, from their size and modification time.
+func localMappingVersion(schemaPath, tableTypesPath string) (string, error) {
+	files, err := ioutil.ReadDir(schemaPath)
+	if err != nil {
+		return "", err
+	}
+	tableTypes, err := os.Stat(tableTypesPath)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, f := range append(files, tableTypes) {
+		if f == tableTypes || strings.HasSuffix(f.Name(), ".mcf") {
+			fmt.Fprintf(&sb, "%s %d %d\n", f.Name(), f.Size(), f.ModTime().UnixNano())
+		}
+	}
+	return sb.String(), nil
+}
+
+// WatchMappings checks the local schema mapping files at every interval, and
+// reloads them when they change. Mappings in GCS are reloaded on notification
+// instead, see SubscribeMappingUpdate.
+func (s *Server) WatchMappings(ctx context.Context, interval time.Duration) error {
+	metadata := s.getMetadata()
+	if err := s.checkReloadable(metadata); err != nil {
+		return err
+	}
+	if _, _, ok := parseGcsPath(metadata.SchemaPath); ok {
+		return status.Errorf(codes.InvalidArgument,
+			"Schema mapping in GCS should be reloaded on notification")
+	}
+	version, err := localMappingVersion(metadata.SchemaPath, metadata.TableTypesPath)
+	if err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			v, err := localMappingVersion(metadata.SchemaPath, metadata.TableTypesPath)
+			if err != nil {
+				log.Printf("Failed to check schema mapping files: %v", err)
+				continue
+			}
+			if v == version {
+				continue
+			}
+			version = v
+			if err := s.ReloadMappings(ctx); err != nil {
+				log.Printf("Failed to reload schema mapping: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// SubscribeMappingUpdate subscribes to the changes of the schema mapping files
+// in GCS, and reloads them on change.
+func (s *Server) SubscribeMappingUpdate(
+	ctx context.Context, pubsubProject, subscriberPrefix, pubsubTopic string,
+) error {
+	if err := s.checkReloadable(s.getMetadata()); err != nil {
+		return err
+	}
+	return dcpubsub.Subscribe(
+		ctx,
+		pubsubProject,
+		subscriberPrefix,
+		pubsubTopic,
+		func(ctx context.Context, msg *pubsub.Message) error {
+			if objectID, ok := msg.Attributes["objectId"]; ok {
+				if !strings.HasSuffix(objectID, ".mcf") {
+					return nil
+				}
+			}
+			log.Println("Receive notification for schema mapping update")
+			return s.ReloadMappings(ctx)
+		},
+	)
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/datacommonsorg/mixer/internal/store/sqldb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const placeMapping = `
+Node: E:Place->E1
+typeOf: Place
+dcid: C:Place->id
+name: C:Place->name
+functionalDeps: dcid
+`
+
+func TestParseMappings(t *testing.T) {
+	for _, c := range []struct {
+		files   map[string]string
+		want    int
+		wantErr []*MappingError
+	}{
+		{map[string]string{"place.mcf": placeMapping}, 4, []*MappingError{}},
+		{
+			map[string]string{
+				"place.mcf": placeMapping,
+				"bad.mcf":   "Node: E:Bad->E1\ntypeOf Bad",
+				"deps.mcf":  "Node: E:Deps->E1\ntypeOf: Deps\nfunctionalDeps: dcid",
+			},
+			4,
+			[]*MappingError{
+				{File: "bad.mcf", Message: "invalid schema mapping mcf at line 2: typeOf Bad"},
+				{File: "deps.mcf", Message: "No functional deps for `dc.Deps`->E1: dcid"},
+			},
+		},
+		{
+			map[string]string{"obs.mcf": "Node: E:Obs->E1\ntypeOf: Obs\ndcid: C:Obs->id\n" +
+				"observationAbout: E:Obs->E2"},
+			3,
+			[]*MappingError{
+				{File: "obs.mcf", Message: "Entity `dc.Obs`->E2 of observationAbout has no dcid mapping"},
+			},
+		},
+		{map[string]string{}, 0, []*MappingError{{Message: "No schema mapping files"}}},
+	} {
+		got, gotErr := parseMappings(c.files, "dc")
+		if len(got) != c.want {
+			t.Errorf("parseMappings(%v) got %d mappings, want %d", c.files, len(got), c.want)
+		}
+		if diff := cmp.Diff(gotErr, c.wantErr); diff != "" {
+			t.Errorf("parseMappings(%v) got errors diff %+v", c.files, diff)
+		}
+	}
+}
+
+func TestReloadMappings(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := ioutil.WriteFile(path.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() = %s", err)
+		}
+	}
+	write("table_types.json", `{"table_types": [{"parent": "Place", "children": ["State"]}]}`)
+	write("place.mcf", placeMapping)
+	metadata, err := NewMetadata("dc", "", "", dir)
+	if err != nil {
+		t.Fatalf("NewMetadata() = %s", err)
+	}
+	metadata.TableTypesPath = path.Join(dir, "table_types.json")
+	s := NewMixerServer(nil, nil, nil, metadata, nil, nil)
+	s.SetQueryCache(sqldb.NewCache(10, time.Minute))
+	s.store.QueryCache.Put(0, "key", [][]interface{}{{"geoId/06"}})
+	handler := s.AdminHandler()
+
+	// An invalid mapping file is reported, and the mappings in use are kept.
+	write("bad.mcf", "Node: E:Bad->E1\ntypeOf Bad")
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/admin/mappings/reload", nil))
+	if resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("POST /admin/mappings/reload = %d, want %d", resp.Code, http.StatusUnprocessableEntity)
+	}
+	if s.getMetadata() != metadata {
+		t.Errorf("Metadata is swapped with invalid mappings")
+	}
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/admin/mappings", nil))
+	var got MappingStatus
+	if err := json.Unmarshal(resp.Body.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) = %s", resp.Body, err)
+	}
+	wantErr := []*MappingError{
+		{File: "bad.mcf", Message: "invalid schema mapping mcf at line 2: typeOf Bad"}}
+	if diff := cmp.Diff(got.Errors, wantErr); diff != "" {
+		t.Errorf("GET /admin/mappings got errors diff %+v", diff)
+	}
+	if got.NumMappings != 4 {
+		t.Errorf("GET /admin/mappings got %d mappings, want 4", got.NumMappings)
+	}
+
+	// Fixed mapping files are swapped in, and the query cache is dropped.
+	write("bad.mcf", "Node: E:Bad->E1\ntypeOf: Bad\ndcid: C:Bad->id\nfunctionalDeps: dcid")
+	if err := s.ReloadMappings(context.Background()); err != nil {
+		t.Fatalf("ReloadMappings() = %s", err)
+	}
+	if got := len(s.getMetadata().Mappings); got != 7 {
+		t.Errorf("ReloadMappings() got %d mappings, want 7", got)
+	}
+	if got := s.getMetadata().SubTypeMap["State"]; got != "Place" {
+		t.Errorf("ReloadMappings() got SubTypeMap[State] = %s, want Place", got)
+	}
+	if got := s.MappingStatus(); got.NumMappings != 7 || len(got.Errors) != 0 {
+		t.Errorf("MappingStatus() = %+v, want 7 mappings and no errors", got)
+	}
+	if got := s.store.QueryCache.Len(); got != 0 {
+		t.Errorf("QueryCache.Len() = %d after reload, want 0", got)
+	}
+	if got := s.getMetadata().MappingGeneration; got != metadata.MappingGeneration+1 {
+		t.Errorf("ReloadMappings() got generation %d, want %d", got, metadata.MappingGeneration+1)
+	}
+}
+
+func TestReloadMappingsSQLite(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(path.Join(dir, "place.mcf"), []byte(placeMapping), 0644); err != nil {
+		t.Fatalf("WriteFile() = %s", err)
+	}
+	metadata, err := NewMetadata("dc", "", "", dir)
+	if err != nil {
+		t.Fatalf("NewMetadata() = %s", err)
+	}
+	db, err := sqldb.NewSQLite(metadata.Mappings, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewSQLite() = %s", err)
+	}
+	defer db.Close()
+	s := NewMixerServer(nil, nil, nil, metadata, nil, nil)
+	s.SetSQLDb(db)
+	// The tables of the database would not match the reloaded mappings.
+	if err := s.ReloadMappings(context.Background()); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ReloadMappings() = %v, want FailedPrecondition", err)
+	}
+	if err := s.WatchMappings(context.Background(), time.Minute); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("WatchMappings() = %v, want FailedPrecondition", err)
+	}
+
+	// The admin API reports the error in the mapping status.
+	resp := httptest.NewRecorder()
+	s.AdminHandler().ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/admin/mappings/reload", nil))
+	if resp.Code != http.StatusConflict {
+		t.Errorf("POST /admin/mappings/reload = %d, want %d", resp.Code, http.StatusConflict)
+	}
+	var got MappingStatus
+	if err := json.Unmarshal(resp.Body.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) = %s", resp.Body, err)
+	}
+	wantErr := []*MappingError{{
+		Message: "Schema mapping of a SQLite database can not be reloaded, restart the server instead"}}
+	if diff := cmp.Diff(got.Errors, wantErr); diff != "" {
+		t.Errorf("POST /admin/mappings/reload got errors diff %+v", diff)
+	}
+	if len(s.MappingStatus().Errors) != 0 {
+		t.Errorf("MappingStatus() = %+v, want no errors", s.MappingStatus())
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"google.golang.org/api/iterator"
+)
+
+// Values read from BigQuery are ordered by this key, so an offset into the
+// rows that are not in the cache is stable across pages.
+const tripleKeyExpr = "CONCAT(t.subject_id, '^', t.predicate, '^', " +
+	"IFNULL(t.object_id, ''), '^', IFNULL(t.object_value, ''))"
+
+// readPropertyValuesTail reads the property values of a node from BigQuery,
+// skipping the nodes in "seen" which have been served from cache.
+func readPropertyValuesTail(
+	ctx context.Context,
+	store *store.Store,
+	dataset string,
+	dcid string,
+	prop string,
+	typ string,
+	arcOut bool,
+	seen []string,
+	offset int,
+	limit int,
+) ([]*model.Node, error) {
+	// For out arcs the neighbor is the object, which can be a node or a value.
+	// For in arcs the neighbor is always a node.
+	selfCol, idExpr, valueExpr := "subject_id", "t.object_id", "t.object_value"
+	if !arcOut {
+		selfCol, idExpr, valueExpr = "object_id", "t.subject_id", "CAST(NULL AS STRING)"
+	}
+	keyExpr := fmt.Sprintf("IFNULL(%s, %s)", idExpr, valueExpr)
+	qStr := fmt.Sprintf(
+		"SELECT %[2]s AS dcid, %[3]s AS value, t.prov_id AS prov_id, "+
+			"(SELECT ANY_VALUE(n.object_value) FROM `%[1]s`.Triple AS n "+
+			"WHERE n.subject_id = %[2]s AND n.predicate = 'name') AS name "+
+			"FROM `%[1]s`.Triple AS t "+
+			"WHERE t.%[4]s = @dcid AND t.predicate = @prop "+
+			"AND %[5]s NOT IN UNNEST(@seen)",
+		dataset, idExpr, valueExpr, selfCol, keyExpr)
+	params := []bigquery.QueryParameter{
+		{Name: "dcid", Value: dcid},
+		{Name: "prop", Value: prop},
+		{Name: "seen", Value: seen},
+		{Name: "limit", Value: limit},
+		{Name: "offset", Value: offset},
+	}
+	if typ != "" {
+		qStr += fmt.Sprintf(
+			" AND %s IN (SELECT subject_id FROM `%s`.Triple "+
+				"WHERE predicate = 'typeOf' AND object_id = @type)", idExpr, dataset)
+		params = append(params, bigquery.QueryParameter{Name: "type", Value: typ})
+	}
+	qStr += fmt.Sprintf(" ORDER BY %s LIMIT @limit OFFSET @offset", keyExpr)
+
+	q := store.BqClient.Query(qStr)
+	q.Parameters = params
+	it, err := q.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := []*model.Node{}
+	for {
+		var row struct {
+			Dcid   bigquery.NullString `bigquery:"dcid"`
+			Value  bigquery.NullString `bigquery:"value"`
+			ProvID bigquery.NullString `bigquery:"prov_id"`
+			Name   bigquery.NullString `bigquery:"name"`
+		}
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		node := &model.Node{
+			Dcid:   row.Dcid.StringVal,
+			Value:  row.Value.StringVal,
+			ProvID: row.ProvID.StringVal,
+			Name:   row.Name.StringVal,
+		}
+		if typ != "" {
+			node.Types = []string{typ}
+		}
+		result = append(result, node)
+	}
+	return result, nil
+}
+
+// readTriplesTail reads the triples of a node from BigQuery, skipping the
+// triples in "seen" which have been served from cache. Each entry in "seen" is
+// a key built by tripleKey().
+func readTriplesTail(
+	ctx context.Context,
+	store *store.Store,
+	dataset string,
+	dcid string,
+	seen []string,
+	offset int,
+	limit int,
+) ([]*model.Triple, error) {
+	qStr := fmt.Sprintf(
+		"SELECT t.subject_id, t.predicate, t.object_id, t.object_value, t.prov_id "+
+			"FROM `%[1]s`.Triple AS t "+
+			"WHERE (t.subject_id = @dcid OR t.object_id = @dcid) "+
+			"AND %[2]s NOT IN UNNEST(@seen) "+
+			"ORDER BY %[2]s LIMIT @limit OFFSET @offset",
+		dataset, tripleKeyExpr)
+	q := store.BqClient.Query(qStr)
+	q.Parameters = []bigquery.QueryParameter{
+		{Name: "dcid", Value: dcid},
+		{Name: "seen", Value: seen},
+		{Name: "limit", Value: limit},
+		{Name: "offset", Value: offset},
+	}
+	it, err := q.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := []*model.Triple{}
+	for {
+		var row struct {
+			SubjectID   bigquery.NullString `bigquery:"subject_id"`
+			Predicate   bigquery.NullString `bigquery:"predicate"`
+			ObjectID    bigquery.NullString `bigquery:"object_id"`
+			ObjectValue bigquery.NullString `bigquery:"object_value"`
+			ProvID      bigquery.NullString `bigquery:"prov_id"`
+		}
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &model.Triple{
+			SubjectID:    row.SubjectID.StringVal,
+			Predicate:    row.Predicate.StringVal,
+			ObjectID:     row.ObjectID.StringVal,
+			ObjectValue:  row.ObjectValue.StringVal,
+			ProvenanceID: row.ProvID.StringVal,
+		})
+	}
+	return result, nil
+}
+
+// tripleKey builds the key of a triple that matches tripleKeyExpr.
+func tripleKey(t *model.Triple) string {
+	return t.SubjectID + "^" + t.Predicate + "^" + t.ObjectID + "^" + t.ObjectValue
+}
+
+// nodeKey builds the key of a neighbor node used to skip cached nodes when
+// reading from BigQuery.
+func nodeKey(n *model.Node) string {
+	if n.Dcid != "" {
+		return n.Dcid
+	}
+	return n.Value
+}
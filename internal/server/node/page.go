@@ -0,0 +1,139 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pageCursor holds the state encoded in a page token.
+//
+// Offsets is keyed by pageKey() and counts the items already returned for the
+// key. Keys that are absent from a decoded cursor have been fully returned.
+type pageCursor struct {
+	// Property, ValueType and Direction are those of a GetPropertyValues
+	// request, and Dcids is the hash of the dcids of the request. They are used
+	// to reject tokens that are replayed against a different request.
+	Property  string         `json:"p,omitempty"`
+	ValueType string         `json:"t,omitempty"`
+	Direction string         `json:"d,omitempty"`
+	Dcids     string         `json:"h,omitempty"`
+	Offsets   map[string]int `json:"o"`
+}
+
+func pageKey(dcid, direction string) string {
+	return dcid + "^" + direction
+}
+
+// checkPageCursor checks that a decoded cursor was issued for a request with
+// the same parameters as the next cursor of the current request.
+func checkPageCursor(cursor, next *pageCursor) error {
+	if cursor.Property != next.Property ||
+		cursor.ValueType != next.ValueType ||
+		cursor.Direction != next.Direction ||
+		cursor.Dcids != next.Dcids {
+		return status.Errorf(codes.InvalidArgument, "Page token does not match the request")
+	}
+	return nil
+}
+
+// encodePageToken encodes a cursor into an opaque page token. An empty token
+// is returned when there is nothing left to fetch.
+func encodePageToken(cursor *pageCursor) (string, error) {
+	if cursor == nil || len(cursor.Offsets) == 0 {
+		return "", nil
+	}
+	jsonRaw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(jsonRaw), nil
+}
+
+// decodePageToken decodes a page token produced by encodePageToken.
+func decodePageToken(token string) (*pageCursor, error) {
+	jsonRaw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+	}
+	cursor := &pageCursor{}
+	if err := json.Unmarshal(jsonRaw, cursor); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+	}
+	for _, offset := range cursor.Offsets {
+		if offset < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+		}
+	}
+	return cursor, nil
+}
+
+// pageRange returns the [start, end) range of a page of the given size
+// starting at offset, clamped to a list of the given length.
+func pageRange(length, offset, size int) (int, int) {
+	start := offset
+	if start > length {
+		start = length
+	}
+	end := offset + size
+	if end > length {
+		end = length
+	}
+	return start, end
+}
+
+// isNodesCacheTruncated checks whether the cached property values might be
+// incomplete. The cache keeps at most BtCacheLimit nodes of each type.
+func isNodesCacheTruncated(nodes []*model.Node) bool {
+	count := map[string]int{}
+	for _, node := range nodes {
+		for _, t := range node.Types {
+			count[t]++
+			if count[t] >= bigtable.BtCacheLimit {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTriplesCacheTruncated checks whether the cached triples of a node might
+// be incomplete. The cache keeps at most BtCacheLimit triples for each
+// direction, predicate and neighbor type.
+func isTriplesCacheTruncated(dcid string, triples []*model.Triple) bool {
+	count := map[string]int{}
+	for _, t := range triples {
+		isOut := "0"
+		neighborTypes := t.SubjectTypes
+		if t.SubjectID == dcid {
+			isOut = "1"
+			neighborTypes = t.ObjectTypes
+		}
+		for _, nt := range neighborTypes {
+			key := isOut + t.Predicate + nt
+			count[key]++
+			if count[key] >= bigtable.BtCacheLimit {
+				return true
+			}
+		}
+	}
+	return false
+}
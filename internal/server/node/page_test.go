@@ -0,0 +1,295 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+)
+
+func TestPageToken(t *testing.T) {
+	for _, c := range []*pageCursor{
+		{Offsets: map[string]int{"geoId/06^": 10}},
+		{
+			Property:  "containedInPlace",
+			ValueType: "City",
+			Direction: "in",
+			Dcids:     util.HashStrings([]string{"geoId/06"}),
+			Offsets: map[string]int{
+				"geoId/06^in":  100,
+				"geoId/06^out": 3,
+			},
+		},
+	} {
+		token, err := encodePageToken(c)
+		if err != nil {
+			t.Fatalf("encodePageToken(%v) = %s", c, err)
+		}
+		got, err := decodePageToken(token)
+		if err != nil {
+			t.Fatalf("decodePageToken(%s) = %s", token, err)
+		}
+		if diff := cmp.Diff(got, c); diff != "" {
+			t.Errorf("decodePageToken(%s) got diff %+v", token, diff)
+		}
+	}
+
+	token, err := encodePageToken(&pageCursor{Offsets: map[string]int{}})
+	if err != nil || token != "" {
+		t.Errorf("encodePageToken() of empty cursor = %s, %v", token, err)
+	}
+
+	for _, token := range []string{"%%%", "bm90IGpzb24", "eyJvIjp7ImEiOi0xfX0"} {
+		if _, err := decodePageToken(token); err == nil {
+			t.Errorf("decodePageToken(%s) expected error", token)
+		}
+	}
+}
+
+func TestCheckPageCursor(t *testing.T) {
+	next := &pageCursor{
+		Property:  "containedInPlace",
+		ValueType: "City",
+		Direction: "in",
+		Dcids:     util.HashStrings([]string{"geoId/06", "geoId/08"}),
+	}
+	if err := checkPageCursor(&pageCursor{
+		Property:  "containedInPlace",
+		ValueType: "City",
+		Direction: "in",
+		Dcids:     util.HashStrings([]string{"geoId/08", "geoId/06"}),
+	}, next); err != nil {
+		t.Errorf("checkPageCursor() = %s for the same request", err)
+	}
+	for _, cursor := range []*pageCursor{
+		{Property: "name", ValueType: "City", Direction: "in", Dcids: next.Dcids},
+		{Property: "containedInPlace", Direction: "in", Dcids: next.Dcids},
+		{Property: "containedInPlace", ValueType: "City", Dcids: next.Dcids},
+		{
+			Property:  "containedInPlace",
+			ValueType: "City",
+			Direction: "in",
+			Dcids:     util.HashStrings([]string{"geoId/06"}),
+		},
+	} {
+		if err := checkPageCursor(cursor, next); err == nil {
+			t.Errorf("checkPageCursor(%+v) expected error", cursor)
+		}
+	}
+}
+
+func TestPageRange(t *testing.T) {
+	for _, c := range []struct {
+		length, offset, size int
+		start, end           int
+	}{
+		{10, 0, 3, 0, 3},
+		{10, 8, 3, 8, 10},
+		{10, 10, 3, 10, 10},
+		{10, 12, 3, 10, 10},
+		{0, 0, 5, 0, 0},
+	} {
+		start, end := pageRange(c.length, c.offset, c.size)
+		if start != c.start || end != c.end {
+			t.Errorf("pageRange(%d, %d, %d) = %d, %d, want %d, %d",
+				c.length, c.offset, c.size, start, end, c.start, c.end)
+		}
+	}
+}
+
+func buildNodes(n int, typ string) []*model.Node {
+	result := []*model.Node{}
+	for i := 0; i < n; i++ {
+		result = append(result, &model.Node{
+			Dcid:  fmt.Sprintf("%s/%d", typ, i),
+			Types: []string{typ},
+		})
+	}
+	return result
+}
+
+// buildTriples builds n triples of counties contained in geoId/06.
+func buildTriples(n int) []*model.Triple {
+	result := []*model.Triple{}
+	for i := 0; i < n; i++ {
+		result = append(result, &model.Triple{
+			SubjectID:    fmt.Sprintf("geoId/%d", i),
+			SubjectTypes: []string{"County"},
+			Predicate:    "containedInPlace",
+			ObjectID:     "geoId/06",
+		})
+	}
+	return result
+}
+
+// newOfflineBqClient creates a BigQuery client that fails any request, to
+// check that BigQuery is not read.
+func newOfflineBqClient(t *testing.T) *bigquery.Client {
+	client, err := bigquery.NewClient(context.Background(), "project",
+		option.WithoutAuthentication(), option.WithEndpoint("http://localhost:0"))
+	if err != nil {
+		t.Fatalf("NewClient() = %s", err)
+	}
+	return client
+}
+
+func TestIsCacheTruncated(t *testing.T) {
+	full := buildNodes(bigtable.BtCacheLimit, "City")
+	mixed := append(
+		buildNodes(bigtable.BtCacheLimit-1, "City"),
+		buildNodes(bigtable.BtCacheLimit-1, "County")...)
+	if !isNodesCacheTruncated(full) {
+		t.Errorf("isNodesCacheTruncated() = false for a full type")
+	}
+	if isNodesCacheTruncated(mixed) {
+		t.Errorf("isNodesCacheTruncated() = true for types under the limit")
+	}
+
+	triples := buildTriples(bigtable.BtCacheLimit)
+	if !isTriplesCacheTruncated("geoId/06", triples) {
+		t.Errorf("isTriplesCacheTruncated() = false for a full group")
+	}
+	if isTriplesCacheTruncated("geoId/06", triples[1:]) {
+		t.Errorf("isTriplesCacheTruncated() = true for groups under the limit")
+	}
+}
+
+func TestPagePropertyValues(t *testing.T) {
+	ctx := context.Background()
+	s := &store.Store{}
+	cached := append(buildNodes(3, "City"), buildNodes(2, "County")...)
+
+	for _, c := range []struct {
+		typ     string
+		offset  int
+		limit   int
+		want    []*model.Node
+		hasMore bool
+	}{
+		{"", 0, 2, cached[0:2], true},
+		{"", 2, 2, cached[2:4], true},
+		{"", 4, 2, cached[4:5], false},
+		{"County", 0, 2, cached[3:5], false},
+		{"City", 1, 1, cached[1:2], true},
+		{"City", 5, 1, []*model.Node{}, false},
+	} {
+		got, hasMore, err := pagePropertyValues(
+			ctx, s, nil, "geoId/06", "containedInPlace", c.typ, false, cached,
+			c.offset, c.limit, true)
+		if err != nil {
+			t.Fatalf("pagePropertyValues() = %s", err)
+		}
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("pagePropertyValues(%s, %d, %d) got diff %+v",
+				c.typ, c.offset, c.limit, diff)
+		}
+		if hasMore != c.hasMore {
+			t.Errorf("pagePropertyValues(%s, %d, %d) hasMore = %t, want %t",
+				c.typ, c.offset, c.limit, hasMore, c.hasMore)
+		}
+	}
+}
+
+func TestPageTriples(t *testing.T) {
+	ctx := context.Background()
+	s := &store.Store{}
+	cached := []*model.Triple{
+		{SubjectID: "geoId/06", Predicate: "name", ObjectValue: "California"},
+		{SubjectID: "geoId/06", Predicate: "typeOf", ObjectID: "State"},
+		{SubjectID: "geoId/06085", Predicate: "containedInPlace", ObjectID: "geoId/06"},
+	}
+
+	for _, c := range []struct {
+		offset   int
+		pageSize int
+		want     []*model.Triple
+		hasMore  bool
+	}{
+		{0, 2, cached[0:2], true},
+		{2, 2, cached[2:3], false},
+		{0, 3, cached, false},
+	} {
+		got, hasMore, err := pageTriples(
+			ctx, s, nil, "geoId/06", cached, c.offset, c.pageSize, true)
+		if err != nil {
+			t.Fatalf("pageTriples() = %s", err)
+		}
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("pageTriples(%d, %d) got diff %+v", c.offset, c.pageSize, diff)
+		}
+		if hasMore != c.hasMore {
+			t.Errorf("pageTriples(%d, %d) hasMore = %t, want %t",
+				c.offset, c.pageSize, hasMore, c.hasMore)
+		}
+	}
+}
+
+func TestPageFirstPageFromCache(t *testing.T) {
+	// BigQuery requests are retried until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s := &store.Store{BqClient: newOfflineBqClient(t)}
+	metadata := &resource.Metadata{Bq: "dataset"}
+
+	// The first page of a truncated cache is served from the cache, and has
+	// more nodes in BigQuery.
+	nodes := buildNodes(bigtable.BtCacheLimit, "City")
+	gotNodes, hasMore, err := pagePropertyValues(
+		ctx, s, metadata, "geoId/06", "containedInPlace", "", false, nodes,
+		0, bigtable.BtCacheLimit+10, false)
+	if err != nil {
+		t.Fatalf("pagePropertyValues() = %s", err)
+	}
+	if len(gotNodes) != bigtable.BtCacheLimit || !hasMore {
+		t.Errorf("pagePropertyValues() = %d nodes, hasMore %t, want %d nodes and more",
+			len(gotNodes), hasMore, bigtable.BtCacheLimit)
+	}
+	triples := buildTriples(bigtable.BtCacheLimit)
+	gotTriples, hasMore, err := pageTriples(
+		ctx, s, metadata, "geoId/06", triples, 0, bigtable.BtCacheLimit+10, false)
+	if err != nil {
+		t.Fatalf("pageTriples() = %s", err)
+	}
+	if len(gotTriples) != bigtable.BtCacheLimit || !hasMore {
+		t.Errorf("pageTriples() = %d triples, hasMore %t, want %d triples and more",
+			len(gotTriples), hasMore, bigtable.BtCacheLimit)
+	}
+
+	// The first page of a complete cache has no more.
+	gotNodes, hasMore, err = pagePropertyValues(
+		ctx, s, metadata, "geoId/06", "containedInPlace", "", false, nodes[1:],
+		0, bigtable.BtCacheLimit+10, false)
+	if err != nil || len(gotNodes) != bigtable.BtCacheLimit-1 || hasMore {
+		t.Errorf("pagePropertyValues() = %d nodes, hasMore %t, %v, want all nodes and no more",
+			len(gotNodes), hasMore, err)
+	}
+	gotTriples, hasMore, err = pageTriples(
+		ctx, s, metadata, "geoId/06", triples[1:], 0, bigtable.BtCacheLimit+10, false)
+	if err != nil || len(gotTriples) != bigtable.BtCacheLimit-1 || hasMore {
+		t.Errorf("pageTriples() = %d triples, hasMore %t, %v, want all triples and no more",
+			len(gotTriples), hasMore, err)
+	}
+}
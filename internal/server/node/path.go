@@ -0,0 +1,373 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"sort"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPathDepth  = 3
+	maxPathDepth      = 6
+	defaultPathFanOut = 100
+	defaultPathCount  = 10
+	maxPathCount      = 100
+	// maxPathFrontier bounds the number of nodes expanded in one BFS layer.
+	maxPathFrontier = 5000
+)
+
+// edge links a node to one of its neighbors.
+type edge struct {
+	// The dcid of the neighbor.
+	dcid string
+	prop string
+	// Whether the edge is an out arc of the node.
+	arcOut bool
+}
+
+// bfsSide holds the search state from one end of the path.
+type bfsSide struct {
+	dist map[string]int
+	// Edges to the previous nodes on the shortest paths from the end.
+	parents  map[string][]edge
+	frontier []string
+	depth    int
+	// Whether some nodes or edges were skipped.
+	truncated bool
+}
+
+func newBfsSide(dcid string) *bfsSide {
+	return &bfsSide{
+		dist:     map[string]int{dcid: 0},
+		parents:  map[string][]edge{},
+		frontier: []string{dcid},
+	}
+}
+
+// pathFilter decides which properties can be followed.
+type pathFilter struct {
+	allowed map[string]struct{}
+	blocked map[string]struct{}
+}
+
+func (f *pathFilter) keep(prop string) bool {
+	if _, ok := f.blocked[prop]; ok {
+		return false
+	}
+	if len(f.allowed) == 0 {
+		return true
+	}
+	_, ok := f.allowed[prop]
+	return ok
+}
+
+// FindPaths implements API for Mixer.FindPaths.
+func FindPaths(
+	ctx context.Context,
+	in *pb.FindPathsRequest,
+	store *store.Store,
+) (*pb.FindPathsResponse, error) {
+	source := in.GetSource()
+	target := in.GetTarget()
+	maxDepth := int(in.GetMaxDepth())
+	fanOut := int(in.GetMaxFanOut())
+	maxPaths := int(in.GetMaxPaths())
+
+	// Check arguments
+	if source == "" || target == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required arguments")
+	}
+	if !util.CheckValidDCIDs([]string{source, target}) {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid DCIDs")
+	}
+	if maxDepth < 0 || maxDepth > maxPathDepth {
+		return nil, status.Errorf(
+			codes.InvalidArgument, "max_depth should be between 1 and %d", maxPathDepth)
+	}
+	if maxPaths < 0 || maxPaths > maxPathCount {
+		return nil, status.Errorf(
+			codes.InvalidArgument, "max_paths should be between 1 and %d", maxPathCount)
+	}
+	if fanOut < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid max_fan_out")
+	}
+	if maxDepth == 0 {
+		maxDepth = defaultPathDepth
+	}
+	if fanOut == 0 {
+		fanOut = defaultPathFanOut
+	}
+	if maxPaths == 0 {
+		maxPaths = defaultPathCount
+	}
+	filter := &pathFilter{allowed: map[string]struct{}{}, blocked: map[string]struct{}{}}
+	for _, prop := range in.GetAllowedProperties() {
+		filter.allowed[prop] = struct{}{}
+	}
+	for _, prop := range in.GetBlockedProperties() {
+		filter.blocked[prop] = struct{}{}
+	}
+
+	if source == target {
+		return &pb.FindPathsResponse{
+			Paths: []*pb.Path{{Dcids: []string{source}}},
+		}, nil
+	}
+
+	fwd := newBfsSide(source)
+	bwd := newBfsSide(target)
+	for fwd.depth+bwd.depth < maxDepth {
+		// Expand the side with the smaller frontier.
+		side, other := fwd, bwd
+		if len(bwd.frontier) < len(fwd.frontier) {
+			side, other = bwd, fwd
+		}
+		if len(side.frontier) == 0 {
+			break
+		}
+		if err := expand(ctx, store, side, filter, fanOut); err != nil {
+			return nil, err
+		}
+		meets := meetingNodes(side, other)
+		if len(meets) > 0 {
+			return &pb.FindPathsResponse{
+				Paths:     buildPaths(fwd, bwd, meets, maxPaths),
+				Truncated: fwd.truncated || bwd.truncated,
+			}, nil
+		}
+	}
+	return &pb.FindPathsResponse{Truncated: fwd.truncated || bwd.truncated}, nil
+}
+
+// expand advances one side of the search by one layer, and records whether
+// it skipped some nodes of the frontier or some of their neighbors.
+func expand(
+	ctx context.Context,
+	store *store.Store,
+	side *bfsSide,
+	filter *pathFilter,
+	fanOut int,
+) error {
+	frontier := side.frontier
+	if len(frontier) > maxPathFrontier {
+		frontier = frontier[:maxPathFrontier]
+		side.truncated = true
+	}
+	neighbors, trimmed, err := readNeighbors(ctx, store, frontier, filter, fanOut)
+	if err != nil {
+		return err
+	}
+	if trimmed {
+		side.truncated = true
+	}
+	side.depth++
+	next := []string{}
+	for _, dcid := range frontier {
+		for _, e := range neighbors[dcid] {
+			d, ok := side.dist[e.dcid]
+			if !ok {
+				side.dist[e.dcid] = side.depth
+				next = append(next, e.dcid)
+			} else if d != side.depth {
+				continue
+			}
+			side.parents[e.dcid] = append(
+				side.parents[e.dcid], edge{dcid: dcid, prop: e.prop, arcOut: e.arcOut})
+		}
+	}
+	side.frontier = next
+	return nil
+}
+
+// meetingNodes returns the nodes of the latest layer of "side" that have been
+// reached by "other", which have the smallest total distance.
+func meetingNodes(side, other *bfsSide) []string {
+	result := []string{}
+	best := -1
+	for _, dcid := range side.frontier {
+		d, ok := other.dist[dcid]
+		if !ok {
+			continue
+		}
+		if best == -1 || d < best {
+			best = d
+			result = []string{}
+		}
+		if d == best {
+			result = append(result, dcid)
+		}
+	}
+	return result
+}
+
+// halfPaths enumerates the shortest paths from the end of a side to dcid, as
+// lists of nodes and the edges between them, up to limit paths.
+func halfPaths(side *bfsSide, dcid string, limit int) ([][]string, [][]edge) {
+	parents := side.parents[dcid]
+	if len(parents) == 0 {
+		return [][]string{{dcid}}, [][]edge{{}}
+	}
+	nodes := [][]string{}
+	edges := [][]edge{}
+	for _, p := range parents {
+		prevNodes, prevEdges := halfPaths(side, p.dcid, limit-len(nodes))
+		for i := range prevNodes {
+			n := append(append([]string{}, prevNodes[i]...), dcid)
+			e := append(append([]edge{}, prevEdges[i]...), p)
+			nodes = append(nodes, n)
+			edges = append(edges, e)
+			if len(nodes) == limit {
+				return nodes, edges
+			}
+		}
+	}
+	return nodes, edges
+}
+
+// buildPaths joins the shortest paths of both sides at the meeting nodes.
+func buildPaths(fwd, bwd *bfsSide, meets []string, limit int) []*pb.Path {
+	sort.Strings(meets)
+	result := []*pb.Path{}
+	for _, m := range meets {
+		fwdNodes, fwdEdges := halfPaths(fwd, m, limit)
+		bwdNodes, bwdEdges := halfPaths(bwd, m, limit)
+		for i := range fwdNodes {
+			for j := range bwdNodes {
+				path := &pb.Path{Dcids: append([]string{}, fwdNodes[i]...)}
+				for _, e := range fwdEdges[i] {
+					path.Edges = append(path.Edges, &pb.PathEdge{
+						Property:  e.prop,
+						Direction: direction(e.arcOut),
+					})
+				}
+				// The backward half goes from the target to the meeting node, so
+				// it is reversed, as is the direction of its edges.
+				for k := len(bwdNodes[j]) - 2; k >= 0; k-- {
+					e := bwdEdges[j][k]
+					path.Dcids = append(path.Dcids, bwdNodes[j][k])
+					path.Edges = append(path.Edges, &pb.PathEdge{
+						Property:  e.prop,
+						Direction: direction(!e.arcOut),
+					})
+				}
+				result = append(result, path)
+				if len(result) == limit {
+					return result
+				}
+			}
+		}
+	}
+	return result
+}
+
+func direction(arcOut bool) string {
+	if arcOut {
+		return "out"
+	}
+	return "in"
+}
+
+// readNeighbors reads the neighbors of nodes along all the properties that
+// pass the filter. At most fanOut neighbors are kept for each property and
+// direction, and whether any were dropped is returned.
+func readNeighbors(
+	ctx context.Context,
+	store *store.Store,
+	dcids []string,
+	filter *pathFilter,
+	fanOut int,
+) (map[string][]edge, bool, error) {
+	labels, err := readPropertyLabels(ctx, store, dcids)
+	if err != nil {
+		return nil, false, err
+	}
+	// Group the nodes by property and direction so each group is read in one
+	// batch.
+	type group struct {
+		prop   string
+		arcOut bool
+	}
+	groups := []group{}
+	groupDcids := map[group][]string{}
+	for _, dcid := range dcids {
+		for _, arcOut := range []bool{true, false} {
+			props := labels[dcid].InLabels
+			if arcOut {
+				props = labels[dcid].OutLabels
+			}
+			for _, prop := range props {
+				if !filter.keep(prop) {
+					continue
+				}
+				g := group{prop, arcOut}
+				if _, ok := groupDcids[g]; !ok {
+					groups = append(groups, g)
+				}
+				groupDcids[g] = append(groupDcids[g], dcid)
+			}
+		}
+	}
+
+	groupResult := make([]map[string][]edge, len(groups))
+	groupTrimmed := make([]bool, len(groups))
+	errs, errCtx := errgroup.WithContext(ctx)
+	for i, g := range groups {
+		i, g := i, g
+		errs.Go(func() error {
+			nodes, err := readPropertyValues(
+				errCtx, store, bigtable.BuildPropertyValuesKey(groupDcids[g], g.prop, g.arcOut))
+			if err != nil {
+				return err
+			}
+			groupResult[i] = map[string][]edge{}
+			for dcid, neighbors := range nodes {
+				for _, n := range neighbors {
+					// Values are not nodes and can not be on a path.
+					if n.Dcid == "" {
+						continue
+					}
+					if len(groupResult[i][dcid]) == fanOut {
+						groupTrimmed[i] = true
+						break
+					}
+					groupResult[i][dcid] = append(
+						groupResult[i][dcid], edge{dcid: n.Dcid, prop: g.prop, arcOut: g.arcOut})
+				}
+			}
+			return nil
+		})
+	}
+	if err := errs.Wait(); err != nil {
+		return nil, false, err
+	}
+	result := map[string][]edge{}
+	trimmed := false
+	for i, r := range groupResult {
+		for dcid, edges := range r {
+			result[dcid] = append(result[dcid], edges...)
+		}
+		trimmed = trimmed || groupTrimmed[i]
+	}
+	return result, trimmed, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestFindPaths(t *testing.T) {
+	ctx := context.Background()
+	data := map[string]string{}
+	for key, value := range map[string]string{
+		bigtable.BtArcsPrefix + "bio/gene1":    `{"inLabels": [], "outLabels": ["encodes"]}`,
+		bigtable.BtArcsPrefix + "bio/protein1": `{"inLabels": ["encodes"], "outLabels": ["associatedWith"]}`,
+		bigtable.BtArcsPrefix + "bio/protein2": `{"inLabels": ["encodes"], "outLabels": ["associatedWith"]}`,
+		bigtable.BtArcsPrefix + "bio/disease1": `{"inLabels": ["associatedWith", "relatedTo"], "outLabels": ["name"]}`,
+		bigtable.BtArcsPrefix + "bio/drug1":    `{"inLabels": [], "outLabels": ["relatedTo"]}`,
+
+		bigtable.BtOutPropValPrefix + "bio/gene1^encodes": `{"entities": [
+			{"dcid": "bio/protein1"}, {"dcid": "bio/protein2"}]}`,
+		bigtable.BtInPropValPrefix + "bio/protein1^encodes":         `{"entities": [{"dcid": "bio/gene1"}]}`,
+		bigtable.BtInPropValPrefix + "bio/protein2^encodes":         `{"entities": [{"dcid": "bio/gene1"}]}`,
+		bigtable.BtOutPropValPrefix + "bio/protein1^associatedWith": `{"entities": [{"dcid": "bio/disease1"}]}`,
+		bigtable.BtOutPropValPrefix + "bio/protein2^associatedWith": `{"entities": [{"dcid": "bio/disease1"}]}`,
+		bigtable.BtInPropValPrefix + "bio/disease1^associatedWith": `{"entities": [
+			{"dcid": "bio/protein1"}, {"dcid": "bio/protein2"}]}`,
+		bigtable.BtInPropValPrefix + "bio/disease1^relatedTo": `{"entities": [{"dcid": "bio/drug1"}]}`,
+		bigtable.BtOutPropValPrefix + "bio/disease1^name":     `{"entities": [{"value": "Disease 1"}]}`,
+		bigtable.BtOutPropValPrefix + "bio/drug1^relatedTo":   `{"entities": [{"dcid": "bio/disease1"}]}`,
+	} {
+		tableValue, err := util.ZipAndEncode([]byte(value))
+		if err != nil {
+			t.Fatalf("util.ZipAndEncode(%s) = %v", value, err)
+		}
+		data[key] = tableValue
+	}
+	btTable, err := bigtable.SetupBigtable(ctx, data)
+	if err != nil {
+		t.Fatalf("SetupBigtable(...) = %v", err)
+	}
+	s := store.NewStore(nil, nil, btTable, nil)
+
+	for _, c := range []struct {
+		desc      string
+		in        *pb.FindPathsRequest
+		want      []*pb.Path
+		truncated bool
+	}{
+		{
+			"forward",
+			&pb.FindPathsRequest{Source: "bio/gene1", Target: "bio/disease1"},
+			[]*pb.Path{
+				{
+					Dcids: []string{"bio/gene1", "bio/protein1", "bio/disease1"},
+					Edges: []*pb.PathEdge{
+						{Property: "encodes", Direction: "out"},
+						{Property: "associatedWith", Direction: "out"},
+					},
+				},
+				{
+					Dcids: []string{"bio/gene1", "bio/protein2", "bio/disease1"},
+					Edges: []*pb.PathEdge{
+						{Property: "encodes", Direction: "out"},
+						{Property: "associatedWith", Direction: "out"},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"backward",
+			&pb.FindPathsRequest{Source: "bio/disease1", Target: "bio/gene1", MaxPaths: 1},
+			[]*pb.Path{
+				{
+					Dcids: []string{"bio/disease1", "bio/protein1", "bio/gene1"},
+					Edges: []*pb.PathEdge{
+						{Property: "associatedWith", Direction: "in"},
+						{Property: "encodes", Direction: "in"},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"three hops",
+			&pb.FindPathsRequest{
+				Source: "bio/drug1", Target: "bio/gene1", MaxFanOut: 1,
+			},
+			[]*pb.Path{
+				{
+					Dcids: []string{"bio/drug1", "bio/disease1", "bio/protein1", "bio/gene1"},
+					Edges: []*pb.PathEdge{
+						{Property: "relatedTo", Direction: "out"},
+						{Property: "associatedWith", Direction: "in"},
+						{Property: "encodes", Direction: "in"},
+					},
+				},
+			},
+			// bio/protein2 is dropped by the fan-out.
+			true,
+		},
+		{
+			"too deep",
+			&pb.FindPathsRequest{Source: "bio/drug1", Target: "bio/gene1", MaxDepth: 2},
+			nil,
+			false,
+		},
+		{
+			"blocked",
+			&pb.FindPathsRequest{
+				Source:            "bio/gene1",
+				Target:            "bio/disease1",
+				BlockedProperties: []string{"associatedWith"},
+			},
+			nil,
+			false,
+		},
+		{
+			"not allowed",
+			&pb.FindPathsRequest{
+				Source:            "bio/gene1",
+				Target:            "bio/disease1",
+				AllowedProperties: []string{"encodes", "relatedTo"},
+			},
+			nil,
+			false,
+		},
+	} {
+		resp, err := FindPaths(ctx, c.in, s)
+		if err != nil {
+			t.Fatalf("FindPaths(%s) = %v", c.desc, err)
+		}
+		if diff := cmp.Diff(resp.GetPaths(), c.want, protocmp.Transform()); diff != "" {
+			t.Errorf("FindPaths(%s) got diff %+v", c.desc, diff)
+		}
+		if resp.GetTruncated() != c.truncated {
+			t.Errorf("FindPaths(%s) truncated = %t, want %t", c.desc, resp.GetTruncated(), c.truncated)
+		}
+	}
+}
+
+func TestExpandTruncated(t *testing.T) {
+	ctx := context.Background()
+	btTable, err := bigtable.SetupBigtable(ctx, map[string]string{})
+	if err != nil {
+		t.Fatalf("SetupBigtable(...) = %v", err)
+	}
+	s := store.NewStore(nil, nil, btTable, nil)
+	filter := &pathFilter{}
+	for _, c := range []struct {
+		size      int
+		truncated bool
+	}{
+		{maxPathFrontier, false},
+		{maxPathFrontier + 1, true},
+	} {
+		side := newBfsSide("bio/gene1")
+		side.frontier = []string{}
+		for i := 0; i < c.size; i++ {
+			side.frontier = append(side.frontier, fmt.Sprintf("bio/gene%d", i))
+		}
+		if err := expand(ctx, s, side, filter, defaultPathFanOut); err != nil {
+			t.Fatalf("expand(%d nodes) = %v", c.size, err)
+		}
+		if side.truncated != c.truncated {
+			t.Errorf("expand(%d nodes) truncated = %t, want %t", c.size, side.truncated, c.truncated)
+		}
+	}
+}
@@ -38,6 +38,24 @@ func GetPropertyLabels(ctx context.Context,
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid DCIDs")
 	}
 
+	result, err := readPropertyLabels(ctx, store, dcids)
+	if err != nil {
+		return nil, err
+	}
+	jsonRaw, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetPropertyLabelsResponse{Payload: string(jsonRaw)}, nil
+}
+
+// readPropertyLabels reads the in and out property labels of nodes from base
+// and branch cache.
+func readPropertyLabels(
+	ctx context.Context,
+	store *store.Store,
+	dcids []string,
+) (map[string]*model.PropLabelCache, error) {
 	rowList := bigtable.BuildPropertyLabelKey(dcids)
 
 	baseDataMap, branchDataMap, err := bigtable.Read(
@@ -75,9 +93,5 @@ func GetPropertyLabels(ctx context.Context,
 			}
 		}
 	}
-	jsonRaw, err := json.Marshal(result)
-	if err != nil {
-		return nil, err
-	}
-	return &pb.GetPropertyLabelsResponse{Payload: string(jsonRaw)}, nil
+	return result, nil
 }
@@ -20,6 +20,7 @@ import (
 
 	cbt "cloud.google.com/go/bigtable"
 	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/server/resource"
 	"github.com/datacommonsorg/mixer/internal/store/bigtable"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,13 +32,18 @@ import (
 )
 
 // GetPropertyValues implements API for Mixer.GetPropertyValues.
-func GetPropertyValues(ctx context.Context,
-	in *pb.GetPropertyValuesRequest, store *store.Store) (*pb.GetPropertyValuesResponse, error) {
+func GetPropertyValues(
+	ctx context.Context,
+	in *pb.GetPropertyValuesRequest,
+	store *store.Store,
+	metadata *resource.Metadata,
+) (*pb.GetPropertyValuesResponse, error) {
 	dcids := in.GetDcids()
 	prop := in.GetProperty()
 	typ := in.GetValueType()
 	direction := in.GetDirection()
 	limit := int(in.GetLimit())
+	token := in.GetPageToken()
 
 	// Check arguments
 	if prop == "" || len(dcids) == 0 {
@@ -46,6 +52,27 @@ func GetPropertyValues(ctx context.Context,
 	if !util.CheckValidDCIDs(dcids) {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid DCIDs")
 	}
+	if token != "" && limit <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing limit for page token")
+	}
+	next := &pageCursor{
+		Property:  prop,
+		ValueType: typ,
+		Direction: direction,
+		Dcids:     util.HashStrings(dcids),
+		Offsets:   map[string]int{},
+	}
+	var cursor *pageCursor
+	if token != "" {
+		var err error
+		cursor, err = decodePageToken(token)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPageCursor(cursor, next); err != nil {
+			return nil, err
+		}
+	}
 
 	// Get in, out or both direction
 	var (
@@ -78,25 +105,125 @@ func GetPropertyValues(ctx context.Context,
 	for _, dcid := range dcids {
 		result[dcid] = map[string][]*model.Node{}
 	}
-	for dcid, nodes := range inRes {
-		trimedNodes := trimNodes(nodes, typ, limit)
-		if len(trimedNodes) > 0 {
-			result[dcid]["in"] = trimedNodes
-
+	// Without a limit, all the cached nodes are returned in one response.
+	if limit == 0 {
+		for dcid, nodes := range inRes {
+			trimedNodes := trimNodes(nodes, typ, limit)
+			if len(trimedNodes) > 0 {
+				result[dcid]["in"] = trimedNodes
+			}
 		}
-	}
-	for dcid, nodes := range outRes {
-		trimedNodes := trimNodes(nodes, typ, limit)
-		if len(trimedNodes) > 0 {
-			result[dcid]["out"] = trimedNodes
+		for dcid, nodes := range outRes {
+			trimedNodes := trimNodes(nodes, typ, limit)
+			if len(trimedNodes) > 0 {
+				result[dcid]["out"] = trimedNodes
+			}
+		}
+		jsonRaw, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
 		}
+		return &pb.GetPropertyValuesResponse{Payload: string(jsonRaw)}, nil
 	}
 
+	for _, arc := range []struct {
+		direction string
+		arcOut    bool
+		res       map[string][]*model.Node
+		enabled   bool
+	}{
+		{"in", false, inRes, inArc},
+		{"out", true, outRes, outArc},
+	} {
+		if !arc.enabled {
+			continue
+		}
+		for _, dcid := range dcids {
+			key := pageKey(dcid, arc.direction)
+			offset := 0
+			if cursor != nil {
+				var ok bool
+				if offset, ok = cursor.Offsets[key]; !ok {
+					continue
+				}
+			}
+			page, hasMore, err := pagePropertyValues(
+				ctx, store, metadata, dcid, prop, typ, arc.arcOut,
+				arc.res[dcid], offset, limit, cursor != nil)
+			if err != nil {
+				return nil, err
+			}
+			if len(page) > 0 {
+				result[dcid][arc.direction] = page
+			}
+			if hasMore {
+				next.Offsets[key] = offset + len(page)
+			}
+		}
+	}
+	nextToken, err := encodePageToken(next)
+	if err != nil {
+		return nil, err
+	}
 	jsonRaw, err := json.Marshal(result)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GetPropertyValuesResponse{Payload: string(jsonRaw)}, nil
+	return &pb.GetPropertyValuesResponse{
+		Payload:       string(jsonRaw),
+		NextPageToken: nextToken,
+	}, nil
+}
+
+// pagePropertyValues gets one page of the property values of a node, starting
+// at offset. Cached nodes come first, followed by the nodes only available in
+// BigQuery when the cache is truncated.
+//
+// BigQuery is only read for the pages after the first one, when readBigQuery is
+// set, so that the first page is served from the cache for all the dcids.
+func pagePropertyValues(
+	ctx context.Context,
+	store *store.Store,
+	metadata *resource.Metadata,
+	dcid string,
+	prop string,
+	typ string,
+	arcOut bool,
+	cached []*model.Node,
+	offset int,
+	limit int,
+	readBigQuery bool,
+) ([]*model.Node, bool, error) {
+	nodes := trimNodes(cached, typ, 0)
+	start, end := pageRange(len(nodes), offset, limit)
+	page := nodes[start:end]
+	if !isNodesCacheTruncated(cached) {
+		return page, end < len(nodes), nil
+	}
+	if store.BqClient == nil || metadata == nil {
+		// The rest of the nodes are not reachable without BigQuery.
+		return page, end < len(nodes), nil
+	}
+	need := limit - len(page)
+	if need == 0 || !readBigQuery {
+		return page, true, nil
+	}
+	seen := make([]string, 0, len(cached))
+	for _, node := range cached {
+		seen = append(seen, nodeKey(node))
+	}
+	// Read one extra node to tell whether there is a next page.
+	tail, err := readPropertyValuesTail(
+		ctx, store, metadata.Bq, dcid, prop, typ, arcOut, seen,
+		offset+len(page)-len(nodes), need+1)
+	if err != nil {
+		return nil, false, err
+	}
+	hasMore := len(tail) > need
+	if hasMore {
+		tail = tail[:need]
+	}
+	return append(append([]*model.Node{}, page...), tail...), hasMore, nil
 }
 
 // GetPropertyValuesHelper get property values.
@@ -0,0 +1,186 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxTraversalSteps is the maximum number of steps in a traversal path.
+	maxTraversalSteps = 10
+	// defaultTraversalLimit is the number of neighboring nodes followed from
+	// each node at a step without limit.
+	defaultTraversalLimit = 100
+	// maxTraversalPaths bounds the number of paths kept after each step, for all
+	// the starting nodes.
+	maxTraversalPaths = 5000
+)
+
+// traversalResult holds the traversal result of one starting node.
+type traversalResult struct {
+	// Nodes reached at the end of the path.
+	Nodes []*model.Node `json:"nodes"`
+	// Each path is a list of dcids (or a value for the last hop), starting from
+	// the starting node and ending at one of the nodes.
+	Paths [][]string `json:"paths"`
+}
+
+// Traverse implements API for Mixer.Traverse.
+func Traverse(
+	ctx context.Context,
+	in *pb.TraverseRequest,
+	store *store.Store,
+) (*pb.TraverseResponse, error) {
+	dcids := in.GetDcids()
+	steps := in.GetSteps()
+
+	// Check arguments
+	if len(dcids) == 0 || len(steps) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required arguments")
+	}
+	if !util.CheckValidDCIDs(dcids) {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid DCIDs")
+	}
+	if len(steps) > maxTraversalSteps {
+		return nil, status.Errorf(
+			codes.InvalidArgument, "Too many steps, at most %d are allowed", maxTraversalSteps)
+	}
+	for i, step := range steps {
+		if step.GetProperty() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "Missing property in step %d", i)
+		}
+		if d := step.GetDirection(); d != "" && d != "in" && d != "out" {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid direction in step %d: %s", i, d)
+		}
+		if step.GetLimit() < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid limit in step %d", i)
+		}
+	}
+
+	// Paths reached so far, keyed by the starting dcid.
+	paths := map[string][][]string{}
+	for _, dcid := range dcids {
+		paths[dcid] = [][]string{{dcid}}
+	}
+	// The last nodes of the paths, keyed by dcid or value.
+	lastNodes := map[string]*model.Node{}
+	truncated := false
+
+	for i, step := range steps {
+		// Nodes at the end of the current paths. These are read in one batch.
+		frontier := []string{}
+		seen := map[string]struct{}{}
+		for _, dcid := range dcids {
+			for _, path := range paths[dcid] {
+				last := path[len(path)-1]
+				if _, ok := seen[last]; !ok {
+					seen[last] = struct{}{}
+					frontier = append(frontier, last)
+				}
+			}
+		}
+		if len(frontier) == 0 {
+			break
+		}
+		neighbors, err := GetPropertyValuesHelper(
+			ctx, store, frontier, step.GetProperty(), step.GetDirection() != "in")
+		if err != nil {
+			return nil, err
+		}
+		isLastStep := i == len(steps)-1
+		next := map[string][]*model.Node{}
+		lastNodes = map[string]*model.Node{}
+		limit := int(step.GetLimit())
+		if limit == 0 {
+			limit = defaultTraversalLimit
+		}
+		for _, dcid := range frontier {
+			for _, node := range trimNodes(neighbors[dcid], step.GetValueType(), limit) {
+				// Values can not be followed, so they only end a path.
+				if node.Dcid == "" && !isLastStep {
+					continue
+				}
+				next[dcid] = append(next[dcid], node)
+				lastNodes[nodeKey(node)] = node
+			}
+		}
+		numPaths := 0
+		for _, dcid := range dcids {
+			var dropped bool
+			paths[dcid], dropped = extendPaths(paths[dcid], next, maxTraversalPaths-numPaths)
+			numPaths += len(paths[dcid])
+			truncated = truncated || dropped
+		}
+	}
+
+	result := map[string]*traversalResult{}
+	for _, dcid := range dcids {
+		res := &traversalResult{Nodes: []*model.Node{}, Paths: paths[dcid]}
+		added := map[string]struct{}{}
+		for _, path := range paths[dcid] {
+			last := path[len(path)-1]
+			if _, ok := added[last]; ok {
+				continue
+			}
+			added[last] = struct{}{}
+			res.Nodes = append(res.Nodes, lastNodes[last])
+		}
+		result[dcid] = res
+	}
+	jsonRaw, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TraverseResponse{Payload: string(jsonRaw), Truncated: truncated}, nil
+}
+
+// extendPaths extends each path with the neighbors of its last node, keeping at
+// most limit paths, and reports whether some were dropped. Paths without
+// neighbors are dropped, and a path never visits a node twice.
+func extendPaths(
+	paths [][]string,
+	neighbors map[string][]*model.Node,
+	limit int,
+) ([][]string, bool) {
+	result := [][]string{}
+	for _, path := range paths {
+		visited := map[string]struct{}{}
+		for _, dcid := range path {
+			visited[dcid] = struct{}{}
+		}
+		for _, node := range neighbors[path[len(path)-1]] {
+			key := nodeKey(node)
+			if _, ok := visited[key]; ok {
+				continue
+			}
+			if len(result) >= limit {
+				return result, true
+			}
+			newPath := make([]string, len(path), len(path)+1)
+			copy(newPath, path)
+			result = append(result, append(newPath, key))
+		}
+	}
+	return result, false
+}
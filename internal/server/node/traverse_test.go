@@ -0,0 +1,173 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTraverse(t *testing.T) {
+	ctx := context.Background()
+	data := map[string]string{}
+	for key, nodes := range map[string]string{
+		bigtable.BtInPropValPrefix + "geoId/06085^containedInPlace": `{"entities": [
+			{"dcid": "geoId/0649670", "name": "Mountain View", "types": ["City"]},
+			{"dcid": "geoId/0668000", "name": "San Jose", "types": ["City"]},
+			{"dcid": "geoId/06085504", "name": "Tract", "types": ["CensusTract"]}
+		]}`,
+		bigtable.BtInPropValPrefix + "geoId/0649670^containedInPlace": `{"entities": [
+			{"dcid": "nces/1", "name": "School 1", "types": ["School"]},
+			{"dcid": "nces/2", "name": "School 2", "types": ["School"]}
+		]}`,
+		bigtable.BtInPropValPrefix + "geoId/0668000^containedInPlace": `{"entities": [
+			{"dcid": "nces/3", "name": "School 3", "types": ["School"]}
+		]}`,
+		bigtable.BtOutPropValPrefix + "geoId/0649670^containedInPlace": `{"entities": [
+			{"dcid": "geoId/06085", "name": "Santa Clara County", "types": ["County"]}
+		]}`,
+	} {
+		tableValue, err := util.ZipAndEncode([]byte(nodes))
+		if err != nil {
+			t.Fatalf("util.ZipAndEncode(%s) = %v", nodes, err)
+		}
+		data[key] = tableValue
+	}
+	btTable, err := bigtable.SetupBigtable(ctx, data)
+	if err != nil {
+		t.Fatalf("SetupBigtable(...) = %v", err)
+	}
+	s := store.NewStore(nil, nil, btTable, nil)
+
+	for _, c := range []struct {
+		steps []*pb.TraversalStep
+		want  *traversalResult
+	}{
+		{
+			[]*pb.TraversalStep{
+				{Property: "containedInPlace", Direction: "in", ValueType: "City"},
+				{Property: "containedInPlace", Direction: "in", ValueType: "School"},
+			},
+			&traversalResult{
+				Nodes: []*model.Node{
+					{Dcid: "nces/1", Name: "School 1", Types: []string{"School"}},
+					{Dcid: "nces/2", Name: "School 2", Types: []string{"School"}},
+					{Dcid: "nces/3", Name: "School 3", Types: []string{"School"}},
+				},
+				Paths: [][]string{
+					{"geoId/06085", "geoId/0649670", "nces/1"},
+					{"geoId/06085", "geoId/0649670", "nces/2"},
+					{"geoId/06085", "geoId/0668000", "nces/3"},
+				},
+			},
+		},
+		{
+			[]*pb.TraversalStep{
+				{Property: "containedInPlace", Direction: "in", Limit: 1},
+				{Property: "containedInPlace", Direction: "in", Limit: 1},
+			},
+			&traversalResult{
+				Nodes: []*model.Node{
+					{Dcid: "nces/1", Name: "School 1", Types: []string{"School"}},
+				},
+				Paths: [][]string{
+					{"geoId/06085", "geoId/0649670", "nces/1"},
+				},
+			},
+		},
+		{
+			// The path does not go back to the starting node.
+			[]*pb.TraversalStep{
+				{Property: "containedInPlace", Direction: "in", ValueType: "City"},
+				{Property: "containedInPlace"},
+			},
+			&traversalResult{
+				Nodes: []*model.Node{},
+				Paths: [][]string{},
+			},
+		},
+	} {
+		resp, err := Traverse(ctx, &pb.TraverseRequest{
+			Dcids: []string{"geoId/06085"},
+			Steps: c.steps,
+		}, s)
+		if err != nil {
+			t.Fatalf("Traverse() = %v", err)
+		}
+		if resp.GetTruncated() {
+			t.Errorf("Traverse(%v) got truncated paths", c.steps)
+		}
+		var got map[string]*traversalResult
+		if err := json.Unmarshal([]byte(resp.GetPayload()), &got); err != nil {
+			t.Fatalf("json.Unmarshal() = %v", err)
+		}
+		if diff := cmp.Diff(got["geoId/06085"], c.want); diff != "" {
+			t.Errorf("Traverse(%v) got diff %+v", c.steps, diff)
+		}
+	}
+}
+
+func TestExtendPaths(t *testing.T) {
+	neighbors := map[string][]*model.Node{
+		"a": {{Dcid: "b"}, {Dcid: "c"}},
+		"b": {{Dcid: "a"}, {Dcid: "d"}},
+		"c": {{Value: "1"}},
+	}
+	paths := [][]string{{"a", "b"}, {"a", "c"}}
+	for _, c := range []struct {
+		limit     int
+		want      [][]string
+		truncated bool
+	}{
+		{10, [][]string{{"a", "b", "d"}, {"a", "c", "1"}}, false},
+		{2, [][]string{{"a", "b", "d"}, {"a", "c", "1"}}, false},
+		{1, [][]string{{"a", "b", "d"}}, true},
+		{0, [][]string{}, true},
+	} {
+		got, truncated := extendPaths(paths, neighbors, c.limit)
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("extendPaths(%d) got diff %+v", c.limit, diff)
+		}
+		if truncated != c.truncated {
+			t.Errorf("extendPaths(%d) got truncated %v, want %v", c.limit, truncated, c.truncated)
+		}
+	}
+}
+
+func TestTraverseInvalidArgument(t *testing.T) {
+	ctx := context.Background()
+	s := &store.Store{}
+	for _, in := range []*pb.TraverseRequest{
+		{Dcids: []string{"geoId/06"}},
+		{Steps: []*pb.TraversalStep{{Property: "containedInPlace"}}},
+		{Dcids: []string{"geoId/06"}, Steps: []*pb.TraversalStep{{}}},
+		{
+			Dcids: []string{"geoId/06"},
+			Steps: []*pb.TraversalStep{{Property: "containedInPlace", Direction: "up"}},
+		},
+	} {
+		if _, err := Traverse(ctx, in, s); err == nil {
+			t.Errorf("Traverse(%v) expected error", in)
+		}
+	}
+}
@@ -57,17 +57,13 @@ func getObsTriples(
 	store *store.Store,
 	metadata *resource.Metadata,
 	obsDcids []string) (map[string][]*model.Triple, error) {
-	dcidList := ""
-	for _, dcid := range obsDcids {
-		dcidList += fmt.Sprintf("\"%s\" ", dcid)
-	}
 	selectStatment := "SELECT ?o ?provenance "
 	tripleStatment := "?o typeOf StatVarObservation . ?o provenance ?provenance . "
 	for _, prop := range obsProps {
 		selectStatment += fmt.Sprintf("?%s ", prop.name)
 		tripleStatment += fmt.Sprintf("?o %s ?%s . ", prop.name, prop.name)
 	}
-	tripleStatment += fmt.Sprintf("?o dcid (%s)", dcidList)
+	tripleStatment += "?o dcid $dcids"
 	sparql := fmt.Sprintf(
 		`%s
 				WHERE {
@@ -75,7 +71,12 @@ func getObsTriples(
 				}
 				`, selectStatment, tripleStatment,
 	)
-	resp, err := translator.Query(ctx, &pb.QueryRequest{Sparql: sparql}, metadata, store)
+	resp, err := translator.Query(ctx, &pb.QueryRequest{
+		Sparql: sparql,
+		Parameters: map[string]*pb.QueryParameter{
+			"dcids": {Values: obsDcids},
+		},
+	}, metadata, store)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +141,8 @@ func GetTriples(
 	*pb.GetTriplesResponse, error) {
 	dcids := in.GetDcids()
 	limit := in.GetLimit()
+	pageSize := int(in.GetPageSize())
+	token := in.GetPageToken()
 
 	if len(dcids) == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "Missing argument: dcids")
@@ -147,6 +150,35 @@ func GetTriples(
 	if !util.CheckValidDCIDs(dcids) {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid DCIDs")
 	}
+	if pageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid page_size")
+	}
+	if pageSize > 0 && limit > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Only one of limit and page_size can be set")
+	}
+	if token != "" && pageSize == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing page_size for page token")
+	}
+	next := &pageCursor{Dcids: util.HashStrings(dcids), Offsets: map[string]int{}}
+	var cursor *pageCursor
+	if token != "" {
+		var err error
+		cursor, err = decodePageToken(token)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPageCursor(cursor, next); err != nil {
+			return nil, err
+		}
+		// Only nodes that have more triples are kept in the token.
+		pageDcids := []string{}
+		for _, dcid := range dcids {
+			if _, ok := cursor.Offsets[pageKey(dcid, "")]; ok {
+				pageDcids = append(pageDcids, dcid)
+			}
+		}
+		dcids = pageDcids
+	}
 
 	// Need to fetch additional information for observation node.
 	var regDcids, obsDcids []string
@@ -167,12 +199,34 @@ func GetTriples(
 			return nil, err
 		}
 		for dcid := range allTriplesCache {
-			resultsMap[dcid] = applyLimit(dcid, allTriplesCache[dcid].Triples, limit)
+			var triples []*model.Triple
+			if allTriplesCache[dcid] != nil {
+				triples = allTriplesCache[dcid].Triples
+			}
+			if pageSize == 0 {
+				resultsMap[dcid] = applyLimit(dcid, triples, limit)
+				continue
+			}
+			key := pageKey(dcid, "")
+			offset := 0
+			if cursor != nil {
+				offset = cursor.Offsets[key]
+			}
+			page, hasMore, err := pageTriples(
+				ctx, store, metadata, dcid, triples, offset, pageSize, cursor != nil)
+			if err != nil {
+				return nil, err
+			}
+			resultsMap[dcid] = page
+			if hasMore {
+				next.Offsets[key] = offset + len(page)
+			}
 		}
 	}
 
-	// Observation DCIDs.
-	if len(obsDcids) > 0 {
+	// Observation DCIDs. These have few triples so they are returned in the
+	// first page.
+	if len(obsDcids) > 0 && cursor == nil {
 		obsResult, err := getObsTriples(ctx, store, metadata, obsDcids)
 		if err != nil {
 			return nil, err
@@ -182,12 +236,59 @@ func GetTriples(
 		}
 	}
 
+	nextToken, err := encodePageToken(next)
+	if err != nil {
+		return nil, err
+	}
 	// Format the json response and encode it in base64 as necessary.
 	jsonRaw, err := json.Marshal(resultsMap)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GetTriplesResponse{Payload: string(jsonRaw)}, nil
+	return &pb.GetTriplesResponse{Payload: string(jsonRaw), NextPageToken: nextToken}, nil
+}
+
+// pageTriples gets one page of the triples of a node, starting at offset.
+// Cached triples come first, followed by the triples only available in
+// BigQuery when the cache is truncated.
+//
+// BigQuery is only read for the pages after the first one, when readBigQuery is
+// set, so that the first page is served from the cache for all the dcids.
+func pageTriples(
+	ctx context.Context,
+	store *store.Store,
+	metadata *resource.Metadata,
+	dcid string,
+	cached []*model.Triple,
+	offset int,
+	pageSize int,
+	readBigQuery bool,
+) ([]*model.Triple, bool, error) {
+	start, end := pageRange(len(cached), offset, pageSize)
+	page := cached[start:end]
+	if !isTriplesCacheTruncated(dcid, cached) ||
+		store.BqClient == nil || metadata == nil {
+		return page, end < len(cached), nil
+	}
+	need := pageSize - len(page)
+	if need == 0 || !readBigQuery {
+		return page, true, nil
+	}
+	seen := make([]string, 0, len(cached))
+	for _, t := range cached {
+		seen = append(seen, tripleKey(t))
+	}
+	// Read one extra triple to tell whether there is a next page.
+	tail, err := readTriplesTail(
+		ctx, store, metadata.Bq, dcid, seen, offset+len(page)-len(cached), need+1)
+	if err != nil {
+		return nil, false, err
+	}
+	hasMore := len(tail) > need
+	if hasMore {
+		tail = tail[:need]
+	}
+	return append(append([]*model.Triple{}, page...), tail...), hasMore, nil
 }
 
 func convertTriplesCache(dcid string, jsonRaw []byte) (interface{}, error) {
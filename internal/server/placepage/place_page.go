@@ -47,6 +47,9 @@ const (
 	maxSimilarPlace = 5
 	maxNearbyPlace  = 5
 	minPopulation   = 10000
+	// Number of the nearest places from the centroid index that are ranked by
+	// population for the nearby places.
+	maxNearbyCandidate = 50
 )
 
 const (
@@ -489,16 +492,10 @@ func getSimilarPlaces(
 // Get nearby places.
 func getNearbyPlaces(ctx context.Context, store *store.Store, dcid string,
 ) ([]string, error) {
-	resp, err := node.GetPropertyValuesHelper(
-		ctx, store, []string{dcid}, "nearbyPlaces", true)
+	places, err := getNearbyCandidates(ctx, store, dcid)
 	if err != nil {
 		return nil, err
 	}
-	places := []string{}
-	for _, node := range resp[dcid] {
-		tokens := strings.Split(node.Value, "@")
-		places = append(places, tokens[0])
-	}
 	placePop, err := getLatestPop(ctx, store, places)
 	if err != nil {
 		return nil, err
@@ -521,6 +518,36 @@ func getNearbyPlaces(ctx context.Context, store *store.Store, dcid string,
 	return getDcids(result[0:maxNearbyPlace]), nil
 }
 
+// getNearbyCandidates gets the places near a place. They are the nearest
+// places of the same type in the centroid index if it has the place, or the
+// nearbyPlaces property of the place otherwise.
+func getNearbyCandidates(ctx context.Context, store *store.Store, dcid string,
+) ([]string, error) {
+	places := []string{}
+	if store.Centroids != nil {
+		if place, ok := store.Centroids.Get(dcid); ok && len(place.Types) > 0 {
+			nearby, _ := store.Centroids.Search(
+				place.Latitude, place.Longitude, 0, maxNearbyCandidate+1, place.Types[0])
+			for _, p := range nearby {
+				if p.Dcid != dcid {
+					places = append(places, p.Dcid)
+				}
+			}
+			return places, nil
+		}
+	}
+	resp, err := node.GetPropertyValuesHelper(
+		ctx, store, []string{dcid}, "nearbyPlaces", true)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range resp[dcid] {
+		tokens := strings.Split(node.Value, "@")
+		places = append(places, tokens[0])
+	}
+	return places, nil
+}
+
 // GetPlacePageData implements API for Mixer.GetPlacePageData.
 //
 // TODO(shifucun):For each related place, it is supposed to have dcid, name and
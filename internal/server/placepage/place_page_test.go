@@ -15,8 +15,11 @@
 package placepage
 
 import (
+	"context"
 	"testing"
 
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/geo"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -74,3 +77,20 @@ func TestGetCohort(t *testing.T) {
 		}
 	}
 }
+
+func TestGetNearbyCandidates(t *testing.T) {
+	s := &store.Store{Centroids: geo.NewCentroidIndex([]*geo.Place{
+		{Dcid: "geoId/0649670", Types: []string{"City"}, Latitude: 37.3861, Longitude: -122.0839},
+		{Dcid: "geoId/0677000", Types: []string{"City"}, Latitude: 37.3688, Longitude: -122.0363},
+		{Dcid: "geoId/0644000", Types: []string{"City"}, Latitude: 34.0522, Longitude: -118.2437},
+		{Dcid: "geoId/06085", Types: []string{"County"}, Latitude: 37.2318, Longitude: -121.6951},
+	})}
+	got, err := getNearbyCandidates(context.Background(), s, "geoId/0649670")
+	if err != nil {
+		t.Fatalf("getNearbyCandidates() = %s", err)
+	}
+	want := []string{"geoId/0677000", "geoId/0644000"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("getNearbyCandidates() got diff %+v", diff)
+	}
+}
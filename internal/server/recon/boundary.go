@@ -0,0 +1,262 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/golang/geo/s2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stringList is a JSON string or list of strings.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*l = []string{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// geoJSONFeature is a GeoJSON feature of a place boundary. The place is
+// described by the properties of the feature.
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Properties struct {
+		Dcid             string     `json:"dcid"`
+		Name             string     `json:"name"`
+		TypeOf           stringList `json:"typeOf"`
+		ContainedInPlace stringList `json:"containedInPlace"`
+	} `json:"properties"`
+}
+
+// geoJSONFile is a GeoJSON feature collection or a single feature.
+type geoJSONFile struct {
+	Type     string            `json:"type"`
+	Features []*geoJSONFeature `json:"features"`
+	geoJSONFeature
+}
+
+// BoundaryIndex is an in-memory index of place boundaries that resolves
+// coordinates without Bigtable.
+type BoundaryIndex struct {
+	index *s2.ShapeIndex
+	// Boundary -> the place it bounds.
+	places map[*s2.Polygon]*NameEntry
+}
+
+// LoadBoundaryIndex builds a boundary index from the GeoJSON files (*.geojson
+// and *.json) and the shapefiles (*.shp) of a directory. Each feature should
+// have the "dcid" property of its place, and can have "name", "typeOf" and
+// "containedInPlace".
+//
+// The properties of a shapefile are read from the attribute table (*.dbf) of
+// the same name, where "containedInPlace" is truncated to "containedI" and
+// lists are comma-separated. Shapefiles should be in longitude and latitude,
+// as their projection (*.prj) is not read.
+func LoadBoundaryIndex(dir string) (*BoundaryIndex, error) {
+	files := []string{}
+	for _, pattern := range []string{"*.geojson", "*.json", "*.shp"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no GeoJSON file or shapefile in %s", dir)
+	}
+	sort.Strings(files)
+	idx := &BoundaryIndex{index: s2.NewShapeIndex(), places: map[*s2.Polygon]*NameEntry{}}
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if filepath.Ext(file) == ".shp" {
+			dbf, err := ioutil.ReadFile(strings.TrimSuffix(file, ".shp") + ".dbf")
+			if err != nil {
+				return nil, fmt.Errorf("missing attribute table of shapefile %s: %s", file, err)
+			}
+			if err := idx.addShapefile(b, dbf); err != nil {
+				return nil, fmt.Errorf("invalid shapefile %s: %s", file, err)
+			}
+			continue
+		}
+		if err := idx.add(b); err != nil {
+			return nil, fmt.Errorf("invalid GeoJSON file %s: %s", file, err)
+		}
+	}
+	// Build the index now rather than on the first query.
+	idx.index.Build()
+	log.Printf("Built boundary index of %d places", len(idx.places))
+	return idx, nil
+}
+
+// add adds the features of a GeoJSON file to the index.
+func (idx *BoundaryIndex) add(geoJSON []byte) error {
+	var f geoJSONFile
+	if err := json.Unmarshal(geoJSON, &f); err != nil {
+		return err
+	}
+	features := f.Features
+	switch f.Type {
+	case "FeatureCollection":
+	case "Feature":
+		features = []*geoJSONFeature{&f.geoJSONFeature}
+	default:
+		return fmt.Errorf("unrecognized GeoJSON object: %s", f.Type)
+	}
+	for i, feature := range features {
+		dcid := feature.Properties.Dcid
+		if dcid == "" {
+			return fmt.Errorf("feature %d has no dcid", i)
+		}
+		polygon, err := parseGeoJSON(string(feature.Geometry))
+		if err != nil {
+			return fmt.Errorf("feature %s: %s", dcid, err)
+		}
+		idx.addPlace(polygon, dcid, feature.Properties.Name,
+			feature.Properties.TypeOf, feature.Properties.ContainedInPlace)
+	}
+	return nil
+}
+
+// addShapefile adds the polygons of a shapefile to the index, with the
+// properties of their records in the attribute table.
+func (idx *BoundaryIndex) addShapefile(shp, dbf []byte) error {
+	polygons, err := readShapefile(shp)
+	if err != nil {
+		return err
+	}
+	records, err := readDBF(dbf)
+	if err != nil {
+		return fmt.Errorf("attribute table: %s", err)
+	}
+	if len(polygons) != len(records) {
+		return fmt.Errorf("%d shapes but %d attribute records", len(polygons), len(records))
+	}
+	for i, polygon := range polygons {
+		record := records[i]
+		// Skip null shapes and deleted records.
+		if polygon == nil || record == nil {
+			continue
+		}
+		dcid := dbfValue(record, "dcid")
+		if dcid == "" {
+			return fmt.Errorf("record %d has no dcid", i+1)
+		}
+		idx.addPlace(polygon, dcid, dbfValue(record, "name"),
+			dbfList(dbfValue(record, "typeOf")),
+			dbfList(dbfValue(record, "containedInPlace")))
+	}
+	return nil
+}
+
+// addPlace adds the boundary of a place to the index.
+func (idx *BoundaryIndex) addPlace(
+	polygon *s2.Polygon, dcid, name string, types, containedInPlace []string) {
+	if name == "" {
+		name = dcid
+	}
+	ancestors := []string{}
+	for _, v := range containedInPlace {
+		ancestors = append(ancestors, strings.TrimPrefix(v, "dcid:"))
+	}
+	idx.index.Add(polygon)
+	idx.places[polygon] = &NameEntry{
+		Dcid:      dcid,
+		Names:     []string{name},
+		Types:     types,
+		Ancestors: ancestors,
+	}
+}
+
+// ResolveCoordinates resolves coordinates to the places whose boundaries
+// contain them, like ResolveCoordinates does with Bigtable.
+func (idx *BoundaryIndex) ResolveCoordinates(in *pb.ResolveCoordinatesRequest) *pb.ResolveCoordinatesResponse {
+	wantedTypes := map[string]struct{}{}
+	for _, t := range in.GetPlaceTypes() {
+		wantedTypes[t] = struct{}{}
+	}
+	query := s2.NewContainsPointQuery(idx.index, s2.VertexModelSemiOpen)
+	res := &pb.ResolveCoordinatesResponse{}
+	for _, co := range in.GetCoordinates() {
+		point := s2.PointFromLatLng(s2.LatLngFromDegrees(co.GetLatitude(), co.GetLongitude()))
+		entries := map[string]*NameEntry{}
+		areas := map[string]float64{}
+		places := []string{}
+		for _, shape := range query.ContainingShapes(point) {
+			polygon := shape.(*s2.Polygon)
+			entry := idx.places[polygon]
+			if _, ok := entries[entry.Dcid]; ok || !hasAny(entry.Types, wantedTypes) {
+				continue
+			}
+			entries[entry.Dcid] = entry
+			areas[entry.Dcid] = polygon.Area()
+			places = append(places, entry.Dcid)
+		}
+		if len(places) == 0 {
+			continue
+		}
+		placeCoordinates := &pb.ResolveCoordinatesResponse_PlaceCoordinate{
+			Latitude:  co.GetLatitude(),
+			Longitude: co.GetLongitude(),
+		}
+		for _, place := range sortPlaces(places, entries, areas) {
+			placeCoordinates.PlaceDcids = append(placeCoordinates.PlaceDcids, place.GetDcid())
+			placeCoordinates.Places = append(placeCoordinates.Places, place)
+		}
+		res.PlaceCoordinates = append(res.PlaceCoordinates, placeCoordinates)
+	}
+	return res
+}
+
+// ResolveGeometry resolves a shape to the places of a type that overlap it,
+// like ResolveGeometry does with Bigtable.
+func (idx *BoundaryIndex) ResolveGeometry(in *pb.ResolveGeometryRequest) (*pb.ResolveGeometryResponse, error) {
+	if in.GetPlaceType() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required argument: place_type")
+	}
+	shape, err := parseShape(in)
+	if err != nil {
+		return nil, err
+	}
+	wantedTypes := map[string]struct{}{in.GetPlaceType(): {}}
+	places := []*geometryPlace{}
+	for polygon, entry := range idx.places {
+		if hasAny(entry.Types, wantedTypes) {
+			places = append(places, &geometryPlace{entry: entry, polygon: polygon})
+		}
+	}
+	return &pb.ResolveGeometryResponse{Places: overlappingPlaces(shape, places)}, nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"runtime"
+	"strings"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// California, roughly.
+const californiaFeature = `{
+  "type": "Feature",
+  "properties": {"dcid": "geoId/06", "name": "California", "typeOf": "State"},
+  "geometry": {
+    "type": "Polygon",
+    "coordinates": [[[-124.4, 32.5], [-114.1, 32.5], [-114.1, 42.0], [-124.4, 42.0], [-124.4, 32.5]]]
+  }
+}`
+
+func TestBoundaryIndex(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	readGeometry := func(name string) string {
+		b, err := ioutil.ReadFile(path.Join(path.Dir(filename), "test_data", name))
+		if err != nil {
+			t.Fatalf("ioutil.ReadFile(%s) = %s", name, err)
+		}
+		return string(b)
+	}
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"us.geojson": fmt.Sprintf(`{"type": "FeatureCollection", "features": [%s, {
+		  "type": "Feature",
+		  "properties": {
+		    "dcid": "geoId/0649670", "name": "Mountain View", "typeOf": ["City"],
+		    "containedInPlace": ["dcid:geoId/06"]
+		  },
+		  "geometry": %s
+		}]}`, californiaFeature, readGeometry("mountain_view_geo_json.json")),
+		"mexico.json": fmt.Sprintf(`{
+		  "type": "Feature",
+		  "properties": {"dcid": "country/MEX", "typeOf": "Country"},
+		  "geometry": %s
+		}`, readGeometry("mexico_geo_json.json")),
+		"README.md": "Not GeoJSON.",
+	} {
+		if err := ioutil.WriteFile(path.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() = %s", err)
+		}
+	}
+	idx, err := LoadBoundaryIndex(dir)
+	if err != nil {
+		t.Fatalf("LoadBoundaryIndex() = %s", err)
+	}
+
+	coordinates := []*pb.ResolveCoordinatesRequest_Coordinate{
+		{Latitude: 37.42, Longitude: -122.08},
+		{Latitude: 32.41, Longitude: -102.11},
+		{Latitude: 26.55, Longitude: -102.85},
+	}
+	for _, c := range []struct {
+		placeTypes []string
+		want       []*pb.ResolveCoordinatesResponse_PlaceCoordinate
+	}{
+		{
+			nil,
+			[]*pb.ResolveCoordinatesResponse_PlaceCoordinate{
+				{
+					Latitude: 37.42, Longitude: -122.08,
+					PlaceDcids: []string{"geoId/0649670", "geoId/06"},
+					Places: []*pb.ResolveCoordinatesResponse_Place{
+						{Dcid: "geoId/0649670", Name: "Mountain View", Type: "City"},
+						{Dcid: "geoId/06", Name: "California", Type: "State"},
+					},
+				},
+				{
+					Latitude: 26.55, Longitude: -102.85,
+					PlaceDcids: []string{"country/MEX"},
+					Places: []*pb.ResolveCoordinatesResponse_Place{
+						{Dcid: "country/MEX", Name: "country/MEX", Type: "Country"},
+					},
+				},
+			},
+		},
+		{
+			[]string{"State"},
+			[]*pb.ResolveCoordinatesResponse_PlaceCoordinate{
+				{
+					Latitude: 37.42, Longitude: -122.08,
+					PlaceDcids: []string{"geoId/06"},
+					Places: []*pb.ResolveCoordinatesResponse_Place{
+						{Dcid: "geoId/06", Name: "California", Type: "State"},
+					},
+				},
+			},
+		},
+	} {
+		got := idx.ResolveCoordinates(&pb.ResolveCoordinatesRequest{
+			Coordinates: coordinates,
+			PlaceTypes:  c.placeTypes,
+		})
+		if diff := cmp.Diff(got.GetPlaceCoordinates(), c.want, protocmp.Transform()); diff != "" {
+			t.Errorf("ResolveCoordinates(%v) got diff %+v", c.placeTypes, diff)
+		}
+	}
+
+	// Mountain View is in the box, and California is partly in it.
+	got, err := idx.ResolveGeometry(&pb.ResolveGeometryRequest{
+		Shape: &pb.ResolveGeometryRequest_BoundingBox{
+			BoundingBox: &pb.ResolveGeometryRequest_LatLngRect{
+				South: 37.2, West: -122.3, North: 37.6, East: -121.8}},
+		PlaceType: "City",
+	})
+	if err != nil {
+		t.Fatalf("ResolveGeometry() = %s", err)
+	}
+	if len(got.GetPlaces()) != 1 || got.GetPlaces()[0].GetDcid() != "geoId/0649670" ||
+		got.GetPlaces()[0].GetOverlapFraction() < 0.99 {
+		t.Errorf("ResolveGeometry() = %v, want all of geoId/0649670", got)
+	}
+}
+
+func TestBoundaryIndexShapefile(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string][]byte{
+		// Shells are clockwise and holes counter-clockwise.
+		"us.shp": encodeShapefile([][][][2]float64{
+			{
+				{{-124.4, 32.5}, {-124.4, 42.0}, {-114.1, 42.0}, {-114.1, 32.5}, {-124.4, 32.5}},
+				{{-120.2, 38.9}, {-119.9, 38.9}, {-119.9, 39.3}, {-120.2, 39.3}, {-120.2, 38.9}},
+			},
+			nil,
+			{
+				{{-122.2, 37.2}, {-122.2, 37.5}, {-121.8, 37.5}, {-121.8, 37.2}, {-122.2, 37.2}},
+			},
+		}),
+		"us.dbf": encodeDBF([]string{"DCID", "NAME", "TYPEOF", "CONTAINEDI"}, [][]string{
+			{"geoId/06", "California", "State", ""},
+			{"geoId/32", "Nevada", "State", ""},
+			{"geoId/06085", "Santa Clara County", "County, AdministrativeArea2", "dcid:geoId/06"},
+		}),
+	} {
+		if err := ioutil.WriteFile(path.Join(dir, name), content, 0644); err != nil {
+			t.Fatalf("WriteFile() = %s", err)
+		}
+	}
+	idx, err := LoadBoundaryIndex(dir)
+	if err != nil {
+		t.Fatalf("LoadBoundaryIndex() = %s", err)
+	}
+	// The second point is in the hole of California, and the third one is in
+	// California only.
+	got := idx.ResolveCoordinates(&pb.ResolveCoordinatesRequest{
+		Coordinates: []*pb.ResolveCoordinatesRequest_Coordinate{
+			{Latitude: 37.42, Longitude: -122.08},
+			{Latitude: 39.1, Longitude: -120.05},
+			{Latitude: 40.0, Longitude: -121.0},
+		},
+	})
+	want := []*pb.ResolveCoordinatesResponse_PlaceCoordinate{
+		{
+			Latitude: 37.42, Longitude: -122.08,
+			PlaceDcids: []string{"geoId/06085", "geoId/06"},
+			Places: []*pb.ResolveCoordinatesResponse_Place{
+				{Dcid: "geoId/06085", Name: "Santa Clara County", Type: "County"},
+				{Dcid: "geoId/06", Name: "California", Type: "State"},
+			},
+		},
+		{
+			Latitude: 40.0, Longitude: -121.0,
+			PlaceDcids: []string{"geoId/06"},
+			Places: []*pb.ResolveCoordinatesResponse_Place{
+				{Dcid: "geoId/06", Name: "California", Type: "State"},
+			},
+		},
+	}
+	if diff := cmp.Diff(got.GetPlaceCoordinates(), want, protocmp.Transform()); diff != "" {
+		t.Errorf("ResolveCoordinates() got diff %+v", diff)
+	}
+}
+
+func TestLoadBoundaryIndexError(t *testing.T) {
+	for _, content := range []string{
+		`{"type": "Feature", "properties": {}, "geometry": {"type": "Point", "coordinates": [0, 0]}}`,
+		`{"type": "Feature", "properties": {"dcid": "p"}, "geometry": {"type": "Point", "coordinates": [0, 0]}}`,
+		`{"type": "GeometryCollection"}`,
+	} {
+		dir := t.TempDir()
+		if err := ioutil.WriteFile(path.Join(dir, "p.geojson"), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() = %s", err)
+		}
+		if _, err := LoadBoundaryIndex(dir); err == nil {
+			t.Errorf("LoadBoundaryIndex(%s) = nil, want error", content)
+		}
+	}
+	if _, err := LoadBoundaryIndex(t.TempDir()); err == nil {
+		t.Errorf("LoadBoundaryIndex(empty directory) = nil, want error")
+	}
+	square := [][][2]float64{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}}
+	for name, files := range map[string]map[string][]byte{
+		"missing dbf": {"p.shp": encodeShapefile([][][][2]float64{square})},
+		"missing dcid": {
+			"p.shp": encodeShapefile([][][][2]float64{square}),
+			"p.dbf": encodeDBF([]string{"name"}, [][]string{{"P"}}),
+		},
+		"missing record": {
+			"p.shp": encodeShapefile([][][][2]float64{square, square}),
+			"p.dbf": encodeDBF([]string{"dcid"}, [][]string{{"p"}}),
+		},
+		"not a shapefile": {
+			"p.shp": []byte("Not a shapefile."),
+			"p.dbf": encodeDBF([]string{"dcid"}, [][]string{{"p"}}),
+		},
+	} {
+		dir := t.TempDir()
+		for file, content := range files {
+			if err := ioutil.WriteFile(path.Join(dir, file), content, 0644); err != nil {
+				t.Fatalf("WriteFile() = %s", err)
+			}
+		}
+		if _, err := LoadBoundaryIndex(dir); err == nil || !strings.Contains(err.Error(), "p.shp") {
+			t.Errorf("LoadBoundaryIndex(%s) = %v, want shapefile error", name, err)
+		}
+	}
+}
+
+// encodeShapefile encodes polygons, as rings of longitude and latitude, in a
+// shapefile. A nil polygon is a null shape.
+func encodeShapefile(polygons [][][][2]float64) []byte {
+	records := &bytes.Buffer{}
+	for i, rings := range polygons {
+		content := &bytes.Buffer{}
+		if rings == nil {
+			binary.Write(content, binary.LittleEndian, int32(shpNull))
+		} else {
+			numPoints := 0
+			parts := []int32{}
+			for _, ring := range rings {
+				parts = append(parts, int32(numPoints))
+				numPoints += len(ring)
+			}
+			binary.Write(content, binary.LittleEndian, int32(shpPolygon))
+			binary.Write(content, binary.LittleEndian, [4]float64{})
+			binary.Write(content, binary.LittleEndian, int32(len(rings)))
+			binary.Write(content, binary.LittleEndian, int32(numPoints))
+			binary.Write(content, binary.LittleEndian, parts)
+			for _, ring := range rings {
+				binary.Write(content, binary.LittleEndian, ring)
+			}
+		}
+		binary.Write(records, binary.BigEndian, int32(i+1))
+		binary.Write(records, binary.BigEndian, int32(content.Len()/2))
+		records.Write(content.Bytes())
+	}
+	header := make([]byte, shpHeaderSize)
+	binary.BigEndian.PutUint32(header[0:4], shpFileCode)
+	binary.BigEndian.PutUint32(header[24:28], uint32((shpHeaderSize+records.Len())/2))
+	binary.LittleEndian.PutUint32(header[28:32], 1000)
+	binary.LittleEndian.PutUint32(header[32:36], shpPolygon)
+	return append(header, records.Bytes()...)
+}
+
+// encodeDBF encodes records in a dBASE table of character fields.
+func encodeDBF(fields []string, records [][]string) []byte {
+	const fieldSize = 40
+	b := &bytes.Buffer{}
+	headerSize := 32 + 32*len(fields) + 1
+	recordSize := 1 + fieldSize*len(fields)
+	b.Write([]byte{3, 122, 1, 1})
+	binary.Write(b, binary.LittleEndian, uint32(len(records)))
+	binary.Write(b, binary.LittleEndian, uint16(headerSize))
+	binary.Write(b, binary.LittleEndian, uint16(recordSize))
+	b.Write(make([]byte, 20))
+	for _, field := range fields {
+		descriptor := make([]byte, 32)
+		copy(descriptor, field)
+		descriptor[11] = 'C'
+		descriptor[16] = fieldSize
+		b.Write(descriptor)
+	}
+	b.WriteByte(0x0D)
+	for _, record := range records {
+		b.WriteByte(' ')
+		for _, v := range record {
+			b.WriteString(fmt.Sprintf("%-40s", v))
+		}
+	}
+	return b.Bytes()
+}
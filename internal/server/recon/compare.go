@@ -0,0 +1,335 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The comparison score is the log odds that two entities are the same. Each
+// piece of evidence adds its log-likelihood ratio to the prior log odds, and
+// the sum is turned into a probability with the logistic function.
+const (
+	// Prior log odds that the entities of a pair are the same, about 12%.
+	priorLogOdds = -2.0
+	// Log-likelihood ratios of sharing or not sharing the value of an ID
+	// property. IDs identify an entity, so they outweigh all other evidence.
+	dcidMatchLLR    = 8.0
+	dcidMismatchLLR = -8.0
+	idMatchLLR      = 6.0
+	idMismatchLLR   = -6.0
+	// Log-likelihood ratios of having a common type or not.
+	typeMatchLLR    = 0.5
+	typeMismatchLLR = -2.5
+	// Log-likelihood ratio of being contained in a common place.
+	parentMatchLLR = 1.0
+	// Log-likelihood ratio of one entity being contained in the other.
+	containmentLLR = -4.0
+)
+
+// Properties of the names of an entity.
+var nameProps = []string{"name", "alternateName"}
+
+// entityFeatures holds the properties of an entity that are compared.
+type entityFeatures struct {
+	// ID property -> ID values.
+	ids     map[string]map[string]struct{}
+	names   []string
+	types   map[string]struct{}
+	parents map[string]struct{}
+	// Whether the latitude and longitude are known.
+	hasCoord bool
+	lat, lng float64
+}
+
+// refValue gets the dcid of a reference value. References to nodes of the
+// sub-graph are resolved to the dcids of the nodes when known.
+func refValue(graph *pb.McfGraph, v string) string {
+	v = strings.TrimPrefix(v, "dcid:")
+	if strings.HasPrefix(v, "l:") {
+		if node, ok := graph.GetNodes()[strings.TrimPrefix(v, "l:")]; ok {
+			if dcid := getPropVal(node, "dcid"); dcid != "" {
+				return dcid
+			}
+		}
+	}
+	return v
+}
+
+// getPropVals gets all the values of a property.
+func getPropVals(node *pb.McfGraph_PropertyValues, prop string) []string {
+	result := []string{}
+	for _, tv := range node.GetPvs()[prop].GetTypedValues() {
+		if v := strings.Trim(tv.GetValue(), `"`); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// getEntityFeatures gets the features of an entity from its sub-graph or IDs.
+func getEntityFeatures(entity *pb.EntitySubGraph) (*entityFeatures, error) {
+	f := &entityFeatures{
+		ids:     map[string]map[string]struct{}{},
+		types:   map[string]struct{}{},
+		parents: map[string]struct{}{},
+	}
+	addID := func(prop, val string) {
+		if _, ok := f.ids[prop]; !ok {
+			f.ids[prop] = map[string]struct{}{}
+		}
+		f.ids[prop][val] = struct{}{}
+	}
+	sourceID := entity.GetSourceId()
+	switch t := entity.GraphRepresentation.(type) {
+	case *pb.EntitySubGraph_SubGraph:
+		graph := entity.GetSubGraph()
+		node, ok := graph.GetNodes()[sourceID]
+		if !ok {
+			return nil, status.Errorf(
+				codes.InvalidArgument, "Source ID %s is not a node of the sub-graph", sourceID)
+		}
+		for _, prop := range rankedIDProps {
+			for _, v := range getPropVals(node, prop) {
+				addID(prop, strings.TrimPrefix(v, "dcid:"))
+			}
+		}
+		for _, prop := range nameProps {
+			f.names = append(f.names, getPropVals(node, prop)...)
+		}
+		for _, v := range getPropVals(node, "typeOf") {
+			f.types[refValue(graph, v)] = struct{}{}
+		}
+		for _, v := range getPropVals(node, "containedInPlace") {
+			f.parents[refValue(graph, v)] = struct{}{}
+		}
+		lats, lngs := getPropVals(node, "latitude"), getPropVals(node, "longitude")
+		if len(lats) > 0 && len(lngs) > 0 {
+			lat, latErr := strconv.ParseFloat(lats[0], 64)
+			lng, lngErr := strconv.ParseFloat(lngs[0], 64)
+			if latErr == nil && lngErr == nil {
+				f.hasCoord, f.lat, f.lng = true, lat, lng
+			}
+		}
+	case *pb.EntitySubGraph_EntityIds:
+		for _, id := range entity.GetEntityIds().GetIds() {
+			addID(id.GetProp(), id.GetVal())
+		}
+	default:
+		return nil, status.Errorf(
+			codes.InvalidArgument, "Entity %s has unexpected graph representation %T", sourceID, t)
+	}
+	return f, nil
+}
+
+// overlaps checks if two sets have a common element.
+func overlaps(a, b map[string]struct{}) bool {
+	for k := range a {
+		if _, ok := b[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeName transliterates a name and collapses its punctuation and
+// spaces.
+func normalizeName(name string) string {
+	return strings.Join(strings.FieldsFunc(util.Transliterate(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
+
+// jaroWinkler gets the Jaro-Winkler similarity of two strings, between 0 and
+// 1. It favors strings with a common prefix, which suits names.
+func jaroWinkler(a, b string) float64 {
+	s1, s2 := []rune(a), []rune(b)
+	if len(s1) == 0 || len(s2) == 0 {
+		if len(s1) == len(s2) {
+			return 1
+		}
+		return 0
+	}
+	window := len(s1)
+	if len(s2) > window {
+		window = len(s2)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matched1 := make([]bool, len(s1))
+	matched2 := make([]bool, len(s2))
+	matches := 0
+	for i := range s1 {
+		lo, hi := i-window, i+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(s2) {
+			hi = len(s2)
+		}
+		for j := lo; j < hi; j++ {
+			if !matched2[j] && s1[i] == s2[j] {
+				matched1[i], matched2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions := 0
+	j := 0
+	for i := range s1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if s1[i] != s2[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(s1)) + m/float64(len(s2)) + (m-float64(transpositions/2))/m) / 3
+	prefix := 0
+	for prefix < 4 && prefix < len(s1) && prefix < len(s2) && s1[prefix] == s2[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// nameSimilarity gets the best similarity among the names of two entities.
+func nameSimilarity(a, b []string) float64 {
+	best := 0.0
+	for _, x := range a {
+		for _, y := range b {
+			if s := nameScore(normalizeName(x), normalizeName(y)); s > best {
+				best = s
+			}
+		}
+	}
+	return best
+}
+
+// distanceKm gets the great-circle distance between two coordinates.
+func distanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLng := (lng2 - lng1) * rad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func clamp(x, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
+
+// isPartOf checks if entity a is contained in entity b.
+func isPartOf(a, b *entityFeatures) bool {
+	for _, vals := range b.ids {
+		if overlaps(a.parents, vals) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareFeatures gets the probability that two entities are the same.
+func compareFeatures(a, b *entityFeatures) float64 {
+	logOdds := priorLogOdds
+	for _, prop := range rankedIDProps {
+		va, okA := a.ids[prop]
+		vb, okB := b.ids[prop]
+		if !okA || !okB {
+			continue
+		}
+		match, mismatch := idMatchLLR, idMismatchLLR
+		if prop == "dcid" {
+			match, mismatch = dcidMatchLLR, dcidMismatchLLR
+		}
+		if overlaps(va, vb) {
+			logOdds += match
+		} else {
+			logOdds += mismatch
+		}
+	}
+	if len(a.names) > 0 && len(b.names) > 0 {
+		// Same names give about +2.4, and unrelated names down to -3.
+		logOdds += clamp(16*(nameSimilarity(a.names, b.names)-0.85), -3, 2.5)
+	}
+	if len(a.types) > 0 && len(b.types) > 0 {
+		if overlaps(a.types, b.types) {
+			logOdds += typeMatchLLR
+		} else {
+			logOdds += typeMismatchLLR
+		}
+	}
+	if overlaps(a.parents, b.parents) {
+		logOdds += parentMatchLLR
+	}
+	if isPartOf(a, b) || isPartOf(b, a) {
+		logOdds += containmentLLR
+	}
+	if a.hasCoord && b.hasCoord {
+		// +2 for the same point, 0 at 9km, -2 at 99km, down to -3.
+		d := distanceKm(a.lat, a.lng, b.lat, b.lng)
+		logOdds += clamp(2-2*math.Log10(1+d), -3, 2)
+	}
+	return 1 / (1 + math.Exp(-logOdds))
+}
+
+// CompareEntities implements API for ReconServer.CompareEntities.
+func CompareEntities(
+	ctx context.Context, in *pb.CompareEntitiesRequest,
+) (
+	*pb.CompareEntitiesResponse, error) {
+	res := &pb.CompareEntitiesResponse{}
+	for i, pair := range in.GetEntityPairs() {
+		one, two := pair.GetEntityOne(), pair.GetEntityTwo()
+		if one == nil || two == nil {
+			return nil, status.Errorf(
+				codes.InvalidArgument, "Entity pair %d should have two entities", i)
+		}
+		a, err := getEntityFeatures(one)
+		if err != nil {
+			return nil, err
+		}
+		b, err := getEntityFeatures(two)
+		if err != nil {
+			return nil, err
+		}
+		res.Comparisons = append(res.Comparisons, &pb.CompareEntitiesResponse_Comparison{
+			SourceIds:   []string{one.GetSourceId(), two.GetSourceId()},
+			Probability: compareFeatures(a, b),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		want float64
+	}{
+		{"martha", "marhta", 0.9611},
+		{"dixon", "dicksonx", 0.8133},
+		{"mountain view", "mountain view", 1},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+	} {
+		if got := jaroWinkler(c.a, c.b); math.Abs(got-c.want) > 1e-4 {
+			t.Errorf("jaroWinkler(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// place builds the sub-graph of a place with the given property values.
+func place(sourceID string, pvs map[string][]string) *pb.EntitySubGraph {
+	node := &pb.McfGraph_PropertyValues{Pvs: map[string]*pb.McfGraph_Values{}}
+	for prop, vals := range pvs {
+		values := &pb.McfGraph_Values{}
+		for _, v := range vals {
+			values.TypedValues = append(values.TypedValues, &pb.McfGraph_TypedValue{Value: proto.String(v)})
+		}
+		node.Pvs[prop] = values
+	}
+	return &pb.EntitySubGraph{
+		SourceId: sourceID,
+		GraphRepresentation: &pb.EntitySubGraph_SubGraph{
+			SubGraph: &pb.McfGraph{Nodes: map[string]*pb.McfGraph_PropertyValues{sourceID: node}},
+		},
+	}
+}
+
+func ids(sourceID string, propVals ...string) *pb.EntitySubGraph {
+	entityIds := &pb.EntityIds{}
+	for i := 0; i+1 < len(propVals); i += 2 {
+		entityIds.Ids = append(entityIds.Ids, &pb.IdWithProperty{Prop: propVals[i], Val: propVals[i+1]})
+	}
+	return &pb.EntitySubGraph{
+		SourceId:            sourceID,
+		GraphRepresentation: &pb.EntitySubGraph_EntityIds{EntityIds: entityIds},
+	}
+}
+
+func TestCompareEntities(t *testing.T) {
+	mountainView := place("mv1", map[string][]string{
+		"name":             {`"Mountain View"`},
+		"typeOf":           {"City"},
+		"containedInPlace": {"dcid:geoId/06085"},
+		"latitude":         {"37.3861"},
+		"longitude":        {"-122.0839"},
+	})
+	for _, c := range []struct {
+		desc     string
+		one, two *pb.EntitySubGraph
+		min, max float64
+	}{
+		{
+			"same dcid",
+			ids("a", "dcid", "geoId/0649670"),
+			ids("b", "dcid", "geoId/0649670", "wikidataId", "Q486860"),
+			0.99, 1,
+		},
+		{
+			"different ID of the same property",
+			ids("a", "geoId", "0649670"),
+			ids("b", "geoId", "0650258"),
+			0, 0.01,
+		},
+		{
+			"no common evidence",
+			ids("a", "geoId", "0649670"),
+			ids("b", "wikidataId", "Q486860"),
+			0.1, 0.15,
+		},
+		{
+			"same name, type, parent and location",
+			mountainView,
+			place("mv2", map[string][]string{
+				"name":             {`"Mountain View, California"`, `"Mountain View"`},
+				"typeOf":           {"City"},
+				"containedInPlace": {"geoId/06085"},
+				"latitude":         {"37.39"},
+				"longitude":        {"-122.08"},
+			}),
+			0.95, 1,
+		},
+		{
+			"same name far away",
+			mountainView,
+			place("mv3", map[string][]string{
+				"name":      {`"Mountain View"`},
+				"typeOf":    {"City"},
+				"latitude":  {"35.0"},
+				"longitude": {"-92.1"},
+			}),
+			0, 0.2,
+		},
+		{
+			"similar name of another type",
+			mountainView,
+			place("mvs", map[string][]string{
+				"name":   {`"Mountain View School"`},
+				"typeOf": {"School"},
+			}),
+			0, 0.05,
+		},
+		{
+			"contained in the other",
+			mountainView,
+			place("scc", map[string][]string{
+				"dcid": {"geoId/06085"},
+				"name": {`"Santa Clara County"`},
+			}),
+			0, 0.001,
+		},
+	} {
+		resp, err := CompareEntities(context.Background(), &pb.CompareEntitiesRequest{
+			EntityPairs: []*pb.EntityPair{{EntityOne: c.one, EntityTwo: c.two}},
+		})
+		if err != nil {
+			t.Errorf("CompareEntities(%s) = %s", c.desc, err)
+			continue
+		}
+		got := resp.GetComparisons()[0]
+		if got.GetSourceIds()[0] != c.one.GetSourceId() || got.GetSourceIds()[1] != c.two.GetSourceId() {
+			t.Errorf("CompareEntities(%s) got source IDs %v", c.desc, got.GetSourceIds())
+		}
+		if p := got.GetProbability(); p < c.min || p > c.max {
+			t.Errorf("CompareEntities(%s) = %v, want in [%v, %v]", c.desc, p, c.min, c.max)
+		}
+	}
+}
+
+func TestCompareEntitiesError(t *testing.T) {
+	for _, in := range []*pb.CompareEntitiesRequest{
+		{EntityPairs: []*pb.EntityPair{{EntityOne: ids("a", "dcid", "geoId/06")}}},
+		{EntityPairs: []*pb.EntityPair{{
+			EntityOne: ids("a", "dcid", "geoId/06"),
+			EntityTwo: &pb.EntitySubGraph{
+				SourceId: "b",
+				GraphRepresentation: &pb.EntitySubGraph_SubGraph{
+					SubGraph: &pb.McfGraph{Nodes: map[string]*pb.McfGraph_PropertyValues{}},
+				},
+			},
+		}}},
+	} {
+		if _, err := CompareEntities(context.Background(), in); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CompareEntities(%v) = %v, want InvalidArgument", in, err)
+		}
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 
 	cbt "cloud.google.com/go/bigtable"
@@ -33,7 +35,34 @@ const (
 	geoJSONPredicate string  = "geoJsonCoordinates"
 )
 
-// TODO(Spaceenter): Also add place types to the results.
+// Sizes of place types, from small to large. Places of other types are
+// ranked by the places that contain them.
+var placeTypeRank = map[string]float64{
+	"CensusBlockGroup":            1,
+	"CensusTract":                 2,
+	"CensusZipCodeTabulationArea": 3,
+	"AdministrativeArea5":         3,
+	"AdministrativeArea4":         4,
+	"City":                        5,
+	"Town":                        5,
+	"Village":                     5,
+	"Borough":                     5,
+	"CensusCountyDivision":        5,
+	"AdministrativeArea3":         5,
+	"ElementarySchoolDistrict":    5,
+	"HighSchoolDistrict":          5,
+	"UnifiedSchoolDistrict":       5,
+	"County":                      6,
+	"AdministrativeArea2":         6,
+	"EurostatNUTS3":               6,
+	"CongressionalDistrict":       6,
+	"EurostatNUTS2":               7,
+	"State":                       8,
+	"AdministrativeArea1":         8,
+	"EurostatNUTS1":               8,
+	"Country":                     9,
+	"Continent":                   10,
+}
 
 // ResolveCoordinates implements API for ReconServer.ResolveCoordinates.
 func ResolveCoordinates(
@@ -52,15 +81,115 @@ func ResolveCoordinates(
 	}
 
 	// Read coordinate recon cache.
-	reconRowList := cbt.RowList{}
-	for key := range coordinateLookupKeys {
-		reconRowList = append(reconRowList,
+	reconDataMap, err := readCoordinateRecons(ctx, store, coordinateLookupKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read place metadata cache for the names, types and ancestors of the places.
+	// The types and ancestors are needed to filter and order the places, so they
+	// are read even for the requests without place types, in one batch for all
+	// the coordinates.
+	candidatePlaces := map[string]struct{}{}
+	for _, recon := range reconDataMap {
+		for _, place := range recon.GetPlaces() {
+			candidatePlaces[place.GetDcid()] = struct{}{}
+		}
+	}
+	placeEntries, err := readPlaceEntries(ctx, store, candidatePlaces)
+	if err != nil {
+		return nil, err
+	}
+	wantedTypes := map[string]struct{}{}
+	for _, t := range in.GetPlaceTypes() {
+		wantedTypes[t] = struct{}{}
+	}
+	isWanted := func(place string) bool {
+		if len(wantedTypes) == 0 {
+			return true
+		}
+		entry, ok := placeEntries[place]
+		return ok && hasAny(entry.Types, wantedTypes)
+	}
+
+	// Collect places that don't fully cover the tiles that the coordinates are in.
+	questionablePlaces := map[string]struct{}{}
+	for _, recon := range reconDataMap {
+		for _, place := range recon.GetPlaces() {
+			if !place.GetFull() && isWanted(place.GetDcid()) {
+				questionablePlaces[place.GetDcid()] = struct{}{}
+			}
+		}
+	}
+
+	// Read place GeoJson cache.
+	geoJSONMap, err := readGeoJSONs(ctx, store, questionablePlaces)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assemble response.
+	res := &pb.ResolveCoordinatesResponse{}
+	for _, co := range in.GetCoordinates() {
+		nKey := normCoordinateMap[coordinateKey(co)]
+
+		recon, ok := reconDataMap[nKey]
+		if !ok {
+			continue
+		}
+
+		placeCoordinates := &pb.ResolveCoordinatesResponse_PlaceCoordinate{
+			Latitude:  co.GetLatitude(),
+			Longitude: co.GetLongitude(),
+		}
+		places := []string{}
+		for _, place := range recon.GetPlaces() {
+			if !isWanted(place.GetDcid()) {
+				continue
+			}
+			if place.GetFull() {
+				places = append(places, place.GetDcid())
+			} else { // Not fully cover the tile.
+				geoJSON, ok := geoJSONMap[place.GetDcid()]
+				if !ok {
+					continue
+				}
+				contained, err := isContainedIn(geoJSON,
+					co.GetLatitude(), co.GetLongitude())
+				if err != nil {
+					return res, err
+				}
+				if contained {
+					places = append(places, place.GetDcid())
+				}
+			}
+		}
+		for _, place := range sortPlaces(places, placeEntries, nil) {
+			placeCoordinates.PlaceDcids = append(placeCoordinates.PlaceDcids, place.GetDcid())
+			placeCoordinates.Places = append(placeCoordinates.Places, place)
+		}
+
+		res.PlaceCoordinates = append(res.PlaceCoordinates, placeCoordinates)
+	}
+
+	return res, nil
+}
+
+// readCoordinateRecons reads the coordinate recon cache of grid tiles, keyed by
+// their normalized coordinate keys.
+func readCoordinateRecons(
+	ctx context.Context, store *store.Store, keys map[string]struct{}) (
+	map[string]*pb.CoordinateRecon, error,
+) {
+	rowList := cbt.RowList{}
+	for key := range keys {
+		rowList = append(rowList,
 			fmt.Sprintf("%s%s", bigtable.BtCoordinateReconPrefix, key))
 	}
-	reconDataMap, _, err := bigtable.Read(
+	dataMap, _, err := bigtable.Read(
 		ctx,
 		store.BtGroup,
-		reconRowList,
+		rowList,
 		func(dcid string, jsonRaw []byte) (interface{}, error) {
 			var recon pb.CoordinateRecon
 			if err := protojson.Unmarshal(jsonRaw, &recon); err != nil {
@@ -77,27 +206,27 @@ func ResolveCoordinates(
 	if err != nil {
 		return nil, err
 	}
-
-	// Collect places that don't fully cover the tiles that the coordinates are in.
-	questionablePlaces := map[string]struct{}{}
-	for _, recon := range reconDataMap {
-		for _, place := range recon.(*pb.CoordinateRecon).GetPlaces() {
-			if !place.GetFull() {
-				questionablePlaces[place.GetDcid()] = struct{}{}
-			}
-		}
+	result := map[string]*pb.CoordinateRecon{}
+	for key, recon := range dataMap {
+		result[key] = recon.(*pb.CoordinateRecon)
 	}
+	return result, nil
+}
 
-	// Read place GeoJson cache.
-	geoJSONRowList := cbt.RowList{}
-	for place := range questionablePlaces {
-		geoJSONRowList = append(geoJSONRowList,
+// readGeoJSONs reads the GeoJSON boundaries of places.
+func readGeoJSONs(
+	ctx context.Context, store *store.Store, places map[string]struct{}) (
+	map[string]string, error,
+) {
+	rowList := cbt.RowList{}
+	for place := range places {
+		rowList = append(rowList,
 			fmt.Sprintf("%s%s^%s", bigtable.BtOutPropValPrefix, place, geoJSONPredicate))
 	}
-	geoJSONDataMap, _, err := bigtable.Read(
+	dataMap, _, err := bigtable.Read(
 		ctx,
 		store.BtGroup,
-		geoJSONRowList,
+		rowList,
 		func(dcid string, jsonRaw []byte) (interface{}, error) {
 			var info pb.EntityInfoCollection
 			if err := protojson.Unmarshal(jsonRaw, &info); err != nil {
@@ -115,55 +244,159 @@ func ResolveCoordinates(
 	if err != nil {
 		return nil, err
 	}
-	geoJSONMap := map[string]string{}
-	for place, info := range geoJSONDataMap {
+	result := map[string]string{}
+	for place, info := range dataMap {
 		// A place should only have a single geoJsonCooridnates out arc.
 		typedInfo := info.(*pb.EntityInfoCollection)
 		if typedInfo.GetTotalCount() != 1 {
 			continue
 		}
-		geoJSONMap[place] = typedInfo.GetEntities()[0].GetValue()
+		result[place] = typedInfo.GetEntities()[0].GetValue()
 	}
+	return result, nil
+}
 
-	// Assemble response.
-	res := &pb.ResolveCoordinatesResponse{}
-	for _, co := range in.GetCoordinates() {
-		nKey := normCoordinateMap[coordinateKey(co)]
+// readPlaceEntries reads the names, types and ancestors of places from the
+// place metadata cache.
+func readPlaceEntries(
+	ctx context.Context, store *store.Store, places map[string]struct{}) (
+	map[string]*NameEntry, error,
+) {
+	result := map[string]*NameEntry{}
+	if len(places) == 0 {
+		return result, nil
+	}
+	placeList := []string{}
+	for place := range places {
+		placeList = append(placeList, place)
+	}
+	// Place metadata are from base geo imports. Only trust the base cache.
+	dataMap, _, err := bigtable.Read(
+		ctx,
+		store.BtGroup,
+		bigtable.BuildPlaceMetaDataKey(placeList),
+		func(dcid string, jsonRaw []byte) (interface{}, error) {
+			var data pb.PlaceMetadataCache
+			if err := json.Unmarshal(jsonRaw, &data); err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		nil,
+		false, /* readBranch */
+	)
+	if err != nil {
+		return nil, err
+	}
+	for place, data := range dataMap {
+		if entry := placeNameEntry(place, data.(*pb.PlaceMetadataCache)); entry != nil {
+			result[place] = entry
+		}
+	}
+	return result, nil
+}
 
-		recon, ok := reconDataMap[nKey]
+// sortPlaces orders the places containing a coordinate from the smallest to
+// the largest, with the places without metadata last.
+//
+// When the areas of all the places with metadata are known, places are ordered
+// by area. Otherwise they are ordered by the rank of their types and then by
+// the number of the other places that contain them. A place of an unknown type
+// is ranked between the known places that it contains and the known places
+// that contain it.
+func sortPlaces(
+	places []string,
+	entries map[string]*NameEntry,
+	areas map[string]float64,
+) []*pb.ResolveCoordinatesResponse_Place {
+	inSet := map[string]struct{}{}
+	for _, place := range places {
+		inSet[place] = struct{}{}
+	}
+	typeRank := func(place string) (float64, bool) {
+		if entry, ok := entries[place]; ok {
+			for _, t := range entry.Types {
+				if rank, ok := placeTypeRank[t]; ok {
+					return rank, true
+				}
+			}
+		}
+		return 0, false
+	}
+	// Ancestors of each place in the set.
+	ancestors := map[string][]string{}
+	byArea := true
+	for _, place := range places {
+		entry, ok := entries[place]
 		if !ok {
 			continue
 		}
-
-		placeCoordinates := &pb.ResolveCoordinatesResponse_PlaceCoordinate{
-			Latitude:  co.GetLatitude(),
-			Longitude: co.GetLongitude(),
+		if _, ok := areas[place]; !ok {
+			byArea = false
 		}
-		for _, place := range recon.(*pb.CoordinateRecon).GetPlaces() {
-			if place.GetFull() {
-				placeCoordinates.PlaceDcids = append(placeCoordinates.PlaceDcids,
-					place.GetDcid())
-			} else { // Not fully cover the tile.
-				geoJSON, ok := geoJSONMap[place.GetDcid()]
-				if !ok {
-					continue
-				}
-				contained, err := isContainedIn(geoJSON,
-					co.GetLatitude(), co.GetLongitude())
-				if err != nil {
-					return res, err
-				}
-				if contained {
-					placeCoordinates.PlaceDcids = append(placeCoordinates.PlaceDcids,
-						place.GetDcid())
+		for _, a := range entry.Ancestors {
+			if _, ok := inSet[a]; ok {
+				ancestors[place] = append(ancestors[place], a)
+			}
+		}
+	}
+	maxRank := 0.0
+	for _, r := range placeTypeRank {
+		maxRank = math.Max(maxRank, r)
+	}
+	rank := map[string]float64{}
+	for _, place := range places {
+		if r, ok := typeRank(place); ok {
+			rank[place] = r
+			continue
+		}
+		// Known places that contain the place, and that it contains.
+		lo, hi := 0.0, maxRank+1
+		for _, a := range ancestors[place] {
+			if r, ok := typeRank(a); ok {
+				hi = math.Min(hi, r)
+			}
+		}
+		for _, other := range places {
+			for _, a := range ancestors[other] {
+				if r, ok := typeRank(other); ok && a == place {
+					lo = math.Max(lo, r)
 				}
 			}
 		}
-
-		res.PlaceCoordinates = append(res.PlaceCoordinates, placeCoordinates)
+		rank[place] = (lo + hi) / 2
 	}
-
-	return res, nil
+	sorted := append([]string{}, places...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, pj := sorted[i], sorted[j]
+		_, oki := entries[pi]
+		_, okj := entries[pj]
+		if oki != okj {
+			return oki
+		}
+		if byArea && areas[pi] != areas[pj] {
+			return areas[pi] < areas[pj]
+		}
+		if rank[pi] != rank[pj] {
+			return rank[pi] < rank[pj]
+		}
+		if len(ancestors[pi]) != len(ancestors[pj]) {
+			return len(ancestors[pi]) > len(ancestors[pj])
+		}
+		return pi < pj
+	})
+	result := []*pb.ResolveCoordinatesResponse_Place{}
+	for _, place := range sorted {
+		p := &pb.ResolveCoordinatesResponse_Place{Dcid: place}
+		if entry, ok := entries[place]; ok {
+			p.Name = entry.Names[0]
+			if len(entry.Types) > 0 {
+				p.Type = entry.Types[0]
+			}
+		}
+		result = append(result, p)
+	}
+	return result
 }
 
 func coordinateKey(c *pb.ResolveCoordinatesRequest_Coordinate) string {
@@ -19,6 +19,8 @@ import (
 	"path"
 	"runtime"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestIsContainedIn(t *testing.T) {
@@ -66,3 +68,66 @@ func TestIsContainedIn(t *testing.T) {
 		}
 	}
 }
+
+func TestSortPlaces(t *testing.T) {
+	entries := map[string]*NameEntry{
+		"country/USA": {Names: []string{"United States"}, Types: []string{"Country"}},
+		"geoId/06": {
+			Names: []string{"California"}, Types: []string{"State"},
+			Ancestors: []string{"country/USA"},
+		},
+		"geoId/06085": {
+			Names: []string{"Santa Clara County"}, Types: []string{"County"},
+			Ancestors: []string{"geoId/06", "country/USA"},
+		},
+		"geoId/0649670": {
+			Names: []string{"Mountain View"}, Types: []string{"City"},
+			Ancestors: []string{"geoId/06085", "geoId/06", "country/USA"},
+		},
+		"zip/94043": {
+			Names: []string{"94043"}, Types: []string{"CensusZipCodeTabulationArea"},
+			Ancestors: []string{"geoId/06", "country/USA"},
+		},
+		"ipcc_50/37.25_-122.25_USA": {
+			Names: []string{"ipcc_50/37.25_-122.25_USA"}, Types: []string{"IPCCPlace_50"},
+			Ancestors: []string{"country/USA"},
+		},
+	}
+	got := []string{}
+	for _, p := range sortPlaces([]string{
+		"zip/94043", "ipcc_50/37.25_-122.25_USA", "geoId/0649670", "geoId/06085",
+		"geoId/06", "country/USA", "geoId/unknown",
+	}, entries, nil) {
+		got = append(got, p.GetDcid()+" "+p.GetType()+" "+p.GetName())
+	}
+	// The place of an unknown type is between the known places that it contains,
+	// none, and the country that contains it.
+	want := []string{
+		"zip/94043 CensusZipCodeTabulationArea 94043",
+		"ipcc_50/37.25_-122.25_USA IPCCPlace_50 ipcc_50/37.25_-122.25_USA",
+		"geoId/0649670 City Mountain View",
+		"geoId/06085 County Santa Clara County",
+		"geoId/06 State California",
+		"country/USA Country United States",
+		"geoId/unknown  ",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("sortPlaces() got diff %+v", diff)
+	}
+
+	// Places are ordered by area when it is known.
+	got = []string{}
+	for _, p := range sortPlaces([]string{
+		"geoId/06", "ipcc_50/37.25_-122.25_USA", "geoId/0649670", "geoId/unknown",
+	}, entries, map[string]float64{
+		"geoId/06":                  1e-2,
+		"ipcc_50/37.25_-122.25_USA": 6e-5,
+		"geoId/0649670":             8e-7,
+	}) {
+		got = append(got, p.GetDcid())
+	}
+	want = []string{"geoId/0649670", "ipcc_50/37.25_-122.25_USA", "geoId/06", "geoId/unknown"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("sortPlaces() by area got diff %+v", diff)
+	}
+}
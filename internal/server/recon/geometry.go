@@ -0,0 +1,243 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/golang/geo/s2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Maximum number of grid tiles a shape can span, about 10 by 10 degrees.
+	maxGeometryTiles = 2500
+	// Number of cells that cover a shape before they are subdivided to compute
+	// overlap areas.
+	overlapCoveringCells = 16
+	// Number of cells of the final level in the smaller polygon of an overlap.
+	// The cells along the boundaries are subdivided down to this level, which
+	// bounds the overlap error relative to the smaller polygon.
+	overlapFinalCells = 4096
+	// Level of the smallest S2 cells.
+	maxCellLevel = 30
+)
+
+// parseShape gets the polygon of the shape of a ResolveGeometry request.
+func parseShape(in *pb.ResolveGeometryRequest) (*s2.Polygon, error) {
+	switch shape := in.GetShape().(type) {
+	case *pb.ResolveGeometryRequest_GeoJson:
+		polygon, err := parseGeoJSON(shape.GeoJson)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid GeoJSON: %s", err)
+		}
+		return polygon, nil
+	case *pb.ResolveGeometryRequest_BoundingBox:
+		b := shape.BoundingBox
+		if b.GetSouth() < -90 || b.GetNorth() > 90 || b.GetSouth() >= b.GetNorth() {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"Invalid bounding box latitudes: %v to %v", b.GetSouth(), b.GetNorth())
+		}
+		if b.GetWest() < -180 || b.GetEast() > 180 || b.GetWest() >= b.GetEast() {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"Invalid bounding box longitudes: %v to %v", b.GetWest(), b.GetEast())
+		}
+		loop := s2.LoopFromPoints([]s2.Point{
+			s2.PointFromLatLng(s2.LatLngFromDegrees(b.GetSouth(), b.GetWest())),
+			s2.PointFromLatLng(s2.LatLngFromDegrees(b.GetSouth(), b.GetEast())),
+			s2.PointFromLatLng(s2.LatLngFromDegrees(b.GetNorth(), b.GetEast())),
+			s2.PointFromLatLng(s2.LatLngFromDegrees(b.GetNorth(), b.GetWest())),
+		})
+		return s2.PolygonFromLoops([]*s2.Loop{loop}), nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required argument: shape")
+	}
+}
+
+// intersectionArea gets the area of the intersection of two polygons, in
+// steradians. The cells of a covering of the smaller polygon are subdivided
+// until they are in or out of both polygons, or are small enough to be
+// decided by their centers.
+func intersectionArea(a, b *s2.Polygon) float64 {
+	if !a.RectBound().Intersects(b.RectBound()) {
+		return 0
+	}
+	if b.Area() < a.Area() {
+		a, b = b, a
+	}
+	finalLevel := s2.AvgAreaMetric.MinLevel(a.Area() / overlapFinalCells)
+	if finalLevel > maxCellLevel {
+		finalLevel = maxCellLevel
+	}
+	var cellArea func(id s2.CellID) float64
+	cellArea = func(id s2.CellID) float64 {
+		cell := s2.CellFromCellID(id)
+		if !a.IntersectsCell(cell) || !b.IntersectsCell(cell) {
+			return 0
+		}
+		if a.ContainsCell(cell) && b.ContainsCell(cell) {
+			return cell.ExactArea()
+		}
+		if id.Level() >= finalLevel {
+			center := cell.Center()
+			if a.ContainsPoint(center) && b.ContainsPoint(center) {
+				return cell.ExactArea()
+			}
+			return 0
+		}
+		area := 0.0
+		for _, child := range id.Children() {
+			area += cellArea(child)
+		}
+		return area
+	}
+	coverer := &s2.RegionCoverer{MaxLevel: maxCellLevel, MaxCells: overlapCoveringCells}
+	area := 0.0
+	for _, id := range coverer.Covering(a) {
+		area += cellArea(id)
+	}
+	return area
+}
+
+// overlapFractions gets the fraction of a place in a shape, and the fraction
+// of the shape in the place.
+func overlapFractions(shape, place *s2.Polygon) (float64, float64) {
+	area := intersectionArea(shape, place)
+	fraction := func(total float64) float64 {
+		if total <= 0 {
+			return 0
+		}
+		return math.Min(1, area/total)
+	}
+	return fraction(place.Area()), fraction(shape.Area())
+}
+
+// geometryPlace is a candidate place of a shape.
+type geometryPlace struct {
+	entry   *NameEntry
+	polygon *s2.Polygon
+}
+
+// overlappingPlaces gets the places that overlap a shape, from the largest
+// overlap fraction.
+func overlappingPlaces(shape *s2.Polygon, places []*geometryPlace) []*pb.ResolveGeometryResponse_Place {
+	result := []*pb.ResolveGeometryResponse_Place{}
+	for _, p := range places {
+		if !shape.Intersects(p.polygon) {
+			continue
+		}
+		overlap, shapeFraction := overlapFractions(shape, p.polygon)
+		if overlap == 0 {
+			continue
+		}
+		result = append(result, &pb.ResolveGeometryResponse_Place{
+			Dcid:            p.entry.Dcid,
+			Name:            p.entry.Names[0],
+			OverlapFraction: overlap,
+			ShapeFraction:   shapeFraction,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GetOverlapFraction() != result[j].GetOverlapFraction() {
+			return result[i].GetOverlapFraction() > result[j].GetOverlapFraction()
+		}
+		return result[i].GetDcid() < result[j].GetDcid()
+	})
+	return result
+}
+
+// tileKeys gets the normalized coordinate keys of the grid tiles that a
+// rectangle spans.
+func tileKeys(rect s2.Rect) (map[string]struct{}, error) {
+	if rect.Lng.IsInverted() {
+		return nil, status.Errorf(codes.InvalidArgument, "Shapes across the antimeridian are not supported")
+	}
+	latLo := int((rect.Lo().Lat.Degrees() + 90.0) / gridSize)
+	latHi := int((rect.Hi().Lat.Degrees() + 90.0) / gridSize)
+	lngLo := int((rect.Lo().Lng.Degrees() + 180.0) / gridSize)
+	lngHi := int((rect.Hi().Lng.Degrees() + 180.0) / gridSize)
+	if n := (latHi - latLo + 1) * (lngHi - lngLo + 1); n > maxGeometryTiles {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Shape spans %d grid tiles, more than %d", n, maxGeometryTiles)
+	}
+	result := map[string]struct{}{}
+	for i := latLo; i <= latHi; i++ {
+		for j := lngLo; j <= lngHi; j++ {
+			result[fmt.Sprintf("%.1f^%.1f",
+				float64(i)*gridSize-90, float64(j)*gridSize-180)] = struct{}{}
+		}
+	}
+	return result, nil
+}
+
+// ResolveGeometry implements API for ReconServer.ResolveGeometry.
+//
+// The candidate places are those of the grid tiles that the shape spans. Their
+// overlaps are computed from their GeoJSON boundaries.
+func ResolveGeometry(
+	ctx context.Context, in *pb.ResolveGeometryRequest, store *store.Store) (
+	*pb.ResolveGeometryResponse, error,
+) {
+	if in.GetPlaceType() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required argument: place_type")
+	}
+	shape, err := parseShape(in)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := tileKeys(shape.RectBound())
+	if err != nil {
+		return nil, err
+	}
+	recons, err := readCoordinateRecons(ctx, store, keys)
+	if err != nil {
+		return nil, err
+	}
+	candidatePlaces := map[string]struct{}{}
+	for _, recon := range recons {
+		for _, place := range recon.GetPlaces() {
+			candidatePlaces[place.GetDcid()] = struct{}{}
+		}
+	}
+	placeEntries, err := readPlaceEntries(ctx, store, candidatePlaces)
+	if err != nil {
+		return nil, err
+	}
+	wantedPlaces := map[string]struct{}{}
+	for place, entry := range placeEntries {
+		if hasAny(entry.Types, map[string]struct{}{in.GetPlaceType(): {}}) {
+			wantedPlaces[place] = struct{}{}
+		}
+	}
+	geoJSONMap, err := readGeoJSONs(ctx, store, wantedPlaces)
+	if err != nil {
+		return nil, err
+	}
+	places := []*geometryPlace{}
+	for place, geoJSON := range geoJSONMap {
+		polygon, err := parseGeoJSON(geoJSON)
+		if err != nil {
+			return nil, err
+		}
+		places = append(places, &geometryPlace{entry: placeEntries[place], polygon: polygon})
+	}
+	return &pb.ResolveGeometryResponse{Places: overlappingPlaces(shape, places)}, nil
+}
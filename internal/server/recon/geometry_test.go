@@ -0,0 +1,152 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/golang/geo/s2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// box gets the GeoJSON polygon of a latitude and longitude box.
+func box(south, west, north, east float64) string {
+	return fmt.Sprintf(`{"type": "Polygon", "coordinates": [[[%[2]v, %[1]v], [%[4]v, %[1]v], `+
+		`[%[4]v, %[3]v], [%[2]v, %[3]v], [%[2]v, %[1]v]]]}`, south, west, north, east)
+}
+
+func TestOverlappingPlaces(t *testing.T) {
+	places := []*geometryPlace{}
+	for _, p := range []struct {
+		dcid, geoJSON string
+	}{
+		{"west", box(10, 10, 11, 11)},
+		{"east", box(10, 11, 11, 12)},
+		{"far", box(20, 20, 21, 21)},
+	} {
+		polygon, err := parseGeoJSON(p.geoJSON)
+		if err != nil {
+			t.Fatalf("parseGeoJSON(%s) = %s", p.dcid, err)
+		}
+		places = append(places, &geometryPlace{
+			entry:   &NameEntry{Dcid: p.dcid, Names: []string{p.dcid}},
+			polygon: polygon,
+		})
+	}
+	for _, c := range []struct {
+		in   *pb.ResolveGeometryRequest
+		want map[string][2]float64
+	}{
+		{
+			// The west place and half of the east place.
+			&pb.ResolveGeometryRequest{Shape: &pb.ResolveGeometryRequest_BoundingBox{
+				BoundingBox: &pb.ResolveGeometryRequest_LatLngRect{
+					South: 10, West: 10, North: 11, East: 11.5}}},
+			map[string][2]float64{"west": {1, 2.0 / 3}, "east": {0.5, 1.0 / 3}},
+		},
+		{
+			// A quarter of the east place.
+			&pb.ResolveGeometryRequest{Shape: &pb.ResolveGeometryRequest_GeoJson{
+				GeoJson: box(10, 11, 10.5, 11.5)}},
+			map[string][2]float64{"east": {0.25, 1}},
+		},
+	} {
+		shape, err := parseShape(c.in)
+		if err != nil {
+			t.Fatalf("parseShape(%v) = %s", c.in, err)
+		}
+		got := overlappingPlaces(shape, places)
+		if len(got) != len(c.want) {
+			t.Errorf("overlappingPlaces(%v) = %v, want %d places", c.in, got, len(c.want))
+			continue
+		}
+		for i, p := range got {
+			want, ok := c.want[p.GetDcid()]
+			if !ok {
+				t.Errorf("overlappingPlaces(%v) got unexpected place %s", c.in, p.GetDcid())
+				continue
+			}
+			// Sub-divided cells are decided by their centers near the boundaries.
+			if math.Abs(p.GetOverlapFraction()-want[0]) > 0.01 ||
+				math.Abs(p.GetShapeFraction()-want[1]) > 0.01 {
+				t.Errorf("overlappingPlaces(%v) got %s fractions %v and %v, want %v",
+					c.in, p.GetDcid(), p.GetOverlapFraction(), p.GetShapeFraction(), want)
+			}
+			if i > 0 && p.GetOverlapFraction() > got[i-1].GetOverlapFraction() {
+				t.Errorf("overlappingPlaces(%v) is not ordered by overlap", c.in)
+			}
+		}
+	}
+}
+
+func TestIntersectionArea(t *testing.T) {
+	large, err := parseGeoJSON(box(0, 0, 20, 20))
+	if err != nil {
+		t.Fatalf("parseGeoJSON() = %s", err)
+	}
+	// A small place, about 1km wide, across the boundary of a large shape.
+	small, err := parseGeoJSON(box(10, 19.995, 10.01, 20.005))
+	if err != nil {
+		t.Fatalf("parseGeoJSON() = %s", err)
+	}
+	for _, c := range []struct{ a, b *s2.Polygon }{{large, small}, {small, large}} {
+		got := intersectionArea(c.a, c.b) / small.Area()
+		if math.Abs(got-0.5) > 0.01 {
+			t.Errorf("intersectionArea() got fraction %v of the small place, want 0.5", got)
+		}
+	}
+}
+
+func TestResolveGeometryError(t *testing.T) {
+	for _, in := range []*pb.ResolveGeometryRequest{
+		{PlaceType: "County"},
+		{Shape: &pb.ResolveGeometryRequest_GeoJson{GeoJson: box(10, 10, 11, 11)}},
+		{PlaceType: "County", Shape: &pb.ResolveGeometryRequest_GeoJson{GeoJson: `{"type": "Point"}`}},
+		{PlaceType: "County", Shape: &pb.ResolveGeometryRequest_BoundingBox{
+			BoundingBox: &pb.ResolveGeometryRequest_LatLngRect{South: 11, West: 10, North: 10, East: 11}}},
+		{PlaceType: "County", Shape: &pb.ResolveGeometryRequest_BoundingBox{
+			BoundingBox: &pb.ResolveGeometryRequest_LatLngRect{South: 10, West: 10, North: 11, East: 190}}},
+		// Too large.
+		{PlaceType: "Country", Shape: &pb.ResolveGeometryRequest_BoundingBox{
+			BoundingBox: &pb.ResolveGeometryRequest_LatLngRect{South: -60, West: -120, North: 60, East: 120}}},
+	} {
+		// Requests are checked before any Bigtable read.
+		if _, err := ResolveGeometry(context.Background(), in, nil); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ResolveGeometry(%v) = %v, want InvalidArgument", in, err)
+		}
+	}
+}
+
+func TestTileKeys(t *testing.T) {
+	rect := s2.RectFromLatLng(s2.LatLngFromDegrees(37.42, -122.08)).
+		AddPoint(s2.LatLngFromDegrees(37.65, -121.95))
+	got, err := tileKeys(rect)
+	if err != nil {
+		t.Fatalf("tileKeys() = %s", err)
+	}
+	for _, key := range []string{"37.4^-122.2", "37.6^-122.2", "37.4^-122.0", "37.6^-122.0"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("tileKeys() = %v, want %s", got, key)
+		}
+	}
+	if len(got) != 4 {
+		t.Errorf("tileKeys() got %d keys, want 4", len(got))
+	}
+}
@@ -41,9 +41,47 @@ func TestCompareEntities(t *testing.T) {
 		req        *pb.CompareEntitiesRequest
 		goldenFile string
 	}{
-		// TODO(spaceenter): Add real test case.
 		{
-			&pb.CompareEntitiesRequest{},
+			&pb.CompareEntitiesRequest{
+				EntityPairs: []*pb.EntityPair{
+					{
+						EntityOne: &pb.EntitySubGraph{
+							SourceId: "newId/SantaClaraCounty",
+							GraphRepresentation: &pb.EntitySubGraph_EntityIds{
+								EntityIds: &pb.EntityIds{
+									Ids: []*pb.IdWithProperty{{Prop: "dcid", Val: "geoId/06085"}},
+								},
+							},
+						},
+						EntityTwo: &pb.EntitySubGraph{
+							SourceId: "newId/SantaClara",
+							GraphRepresentation: &pb.EntitySubGraph_EntityIds{
+								EntityIds: &pb.EntityIds{
+									Ids: []*pb.IdWithProperty{{Prop: "dcid", Val: "geoId/06085"}},
+								},
+							},
+						},
+					},
+					{
+						EntityOne: &pb.EntitySubGraph{
+							SourceId: "newId/SantaClaraCounty",
+							GraphRepresentation: &pb.EntitySubGraph_EntityIds{
+								EntityIds: &pb.EntityIds{
+									Ids: []*pb.IdWithProperty{{Prop: "geoId", Val: "06085"}},
+								},
+							},
+						},
+						EntityTwo: &pb.EntitySubGraph{
+							SourceId: "newId/SanMateoCounty",
+							GraphRepresentation: &pb.EntitySubGraph_EntityIds{
+								EntityIds: &pb.EntityIds{
+									Ids: []*pb.IdWithProperty{{Prop: "geoId", Val: "06081"}},
+								},
+							},
+						},
+					},
+				},
+			},
 			"result.json",
 		},
 	} {
@@ -25,6 +25,8 @@ import (
 	"github.com/datacommonsorg/mixer/internal/store/bigtable"
 
 	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -95,3 +97,99 @@ func ResolveIds(
 
 	return res, nil
 }
+
+// Maximum number of ID mappings CrosswalkIds reads, which is the number of IDs
+// times the number of output properties.
+const maxCrosswalkMappings = 10000
+
+// CrosswalkIds implements API for ReconServer.CrosswalkIds.
+func CrosswalkIds(
+	ctx context.Context, in *pb.CrosswalkIdsRequest, store *store.Store,
+) (
+	*pb.CrosswalkIdsResponse, error) {
+	ids, outProps := in.GetIds(), in.GetOutProps()
+	if len(ids) == 0 || len(outProps) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required arguments: ids, out_props")
+	}
+	if n := len(ids) * len(outProps); n > maxCrosswalkMappings {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Too many ID mappings: %d IDs by %d properties is more than %d",
+			len(ids), len(outProps), maxCrosswalkMappings)
+	}
+	for _, id := range ids {
+		if id.GetProp() == "" || id.GetVal() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid ID: %v", id)
+		}
+	}
+
+	// Read cache data. The tokens are the row keys without the prefix.
+	keys := map[string]struct{}{}
+	rowList := cbt.RowList{}
+	for _, id := range ids {
+		for _, outProp := range outProps {
+			if outProp == id.GetProp() {
+				continue
+			}
+			key := fmt.Sprintf("%s^%s^%s", id.GetProp(), id.GetVal(), outProp)
+			if _, ok := keys[key]; ok {
+				continue
+			}
+			keys[key] = struct{}{}
+			rowList = append(rowList, bigtable.BtReconIDMapPrefix+key)
+		}
+	}
+	dataMap := map[string]interface{}{}
+	if len(rowList) > 0 {
+		var err error
+		dataMap, _, err = bigtable.Read(
+			ctx, store.BtGroup, rowList,
+			func(dcid string, jsonRaw []byte) (interface{}, error) {
+				var reconEntities pb.ReconEntities
+				if err := protojson.Unmarshal(jsonRaw, &reconEntities); err != nil {
+					return nil, err
+				}
+				return &reconEntities, nil
+			},
+			func(rowKey string) (string, error) {
+				return strings.TrimPrefix(rowKey, bigtable.BtReconIDMapPrefix), nil
+			},
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Assemble result.
+	res := &pb.CrosswalkIdsResponse{}
+	for _, id := range ids {
+		entity := &pb.CrosswalkIdsResponse_Entity{InId: id}
+		for _, outProp := range outProps {
+			outIds := &pb.CrosswalkIdsResponse_OutIds{Prop: outProp}
+			if outProp == id.GetProp() {
+				outIds.Vals = []string{id.GetVal()}
+			} else {
+				key := fmt.Sprintf("%s^%s^%s", id.GetProp(), id.GetVal(), outProp)
+				vals := map[string]struct{}{}
+				if reconEntities, ok := dataMap[key].(*pb.ReconEntities); ok {
+					for _, reconEntity := range reconEntities.GetEntities() {
+						for _, reconID := range reconEntity.GetIds() {
+							if reconID.GetProp() == outProp {
+								vals[reconID.GetVal()] = struct{}{}
+							}
+						}
+					}
+				}
+				for v := range vals {
+					outIds.Vals = append(outIds.Vals, v)
+				}
+				// Sort to make the result deterministic.
+				sort.Strings(outIds.Vals)
+			}
+			outIds.Ambiguous = len(outIds.Vals) > 1
+			entity.OutIds = append(entity.OutIds, outIds)
+		}
+		res.Entities = append(res.Entities, entity)
+	}
+	return res, nil
+}
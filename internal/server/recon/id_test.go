@@ -0,0 +1,108 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestCrosswalkIds(t *testing.T) {
+	ctx := context.Background()
+	data := map[string]string{}
+	for key, value := range map[string]string{
+		"geoId^06^wikidataId": `{"entities": [{"ids": [{"prop": "wikidataId", "val": "Q99"}]}]}`,
+		"geoId^06^dcid":       `{"entities": [{"ids": [{"prop": "dcid", "val": "geoId/06"}]}]}`,
+		"isoCode^US-CA^dcid":  `{"entities": [{"ids": [{"prop": "dcid", "val": "geoId/06"}]}]}`,
+		"nutsCode^FR1^wikidataId": `{"entities": [` +
+			`{"ids": [{"prop": "wikidataId", "val": "Q13917"}]}, ` +
+			`{"ids": [{"prop": "wikidataId", "val": "Q90"}]}]}`,
+	} {
+		tableValue, err := util.ZipAndEncode([]byte(value))
+		if err != nil {
+			t.Fatalf("util.ZipAndEncode(%s) = %s", value, err)
+		}
+		data[bigtable.BtReconIDMapPrefix+key] = tableValue
+	}
+	btTable, err := bigtable.SetupBigtable(ctx, data)
+	if err != nil {
+		t.Fatalf("SetupBigtable() = %s", err)
+	}
+	s := store.NewStore(nil, nil, btTable, nil)
+
+	got, err := CrosswalkIds(ctx, &pb.CrosswalkIdsRequest{
+		Ids: []*pb.IdWithProperty{
+			{Prop: "geoId", Val: "06"},
+			{Prop: "isoCode", Val: "US-CA"},
+			{Prop: "nutsCode", Val: "FR1"},
+		},
+		OutProps: []string{"dcid", "wikidataId", "geoId"},
+	}, s)
+	if err != nil {
+		t.Fatalf("CrosswalkIds() = %s", err)
+	}
+	want := []*pb.CrosswalkIdsResponse_Entity{
+		{
+			InId: &pb.IdWithProperty{Prop: "geoId", Val: "06"},
+			OutIds: []*pb.CrosswalkIdsResponse_OutIds{
+				{Prop: "dcid", Vals: []string{"geoId/06"}},
+				{Prop: "wikidataId", Vals: []string{"Q99"}},
+				{Prop: "geoId", Vals: []string{"06"}},
+			},
+		},
+		{
+			InId: &pb.IdWithProperty{Prop: "isoCode", Val: "US-CA"},
+			OutIds: []*pb.CrosswalkIdsResponse_OutIds{
+				{Prop: "dcid", Vals: []string{"geoId/06"}},
+				{Prop: "wikidataId"},
+				{Prop: "geoId"},
+			},
+		},
+		{
+			InId: &pb.IdWithProperty{Prop: "nutsCode", Val: "FR1"},
+			OutIds: []*pb.CrosswalkIdsResponse_OutIds{
+				{Prop: "dcid"},
+				{Prop: "wikidataId", Vals: []string{"Q13917", "Q90"}, Ambiguous: true},
+				{Prop: "geoId"},
+			},
+		},
+	}
+	if diff := cmp.Diff(got.GetEntities(), want, protocmp.Transform()); diff != "" {
+		t.Errorf("CrosswalkIds() got diff %+v", diff)
+	}
+
+	for _, in := range []*pb.CrosswalkIdsRequest{
+		{OutProps: []string{"dcid"}},
+		{Ids: []*pb.IdWithProperty{{Prop: "geoId", Val: "06"}}},
+		{Ids: []*pb.IdWithProperty{{Prop: "geoId"}}, OutProps: []string{"dcid"}},
+		{
+			Ids:      make([]*pb.IdWithProperty, maxCrosswalkMappings),
+			OutProps: []string{"dcid", "wikidataId"},
+		},
+	} {
+		if _, err := CrosswalkIds(ctx, in, s); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CrosswalkIds(%d IDs) = %v, want InvalidArgument", len(in.GetIds()), err)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strings"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Minimum name score of a candidate.
+	minNameScore = 0.75
+	// Temperature of the softmax that shares the probability among the
+	// candidates. The lower it is, the more the best candidates get.
+	nameScoreTemperature = 0.05
+	// Minimum similarity of two tokens that are matched with a typo.
+	minTokenScore = 0.85
+	// Minimum length of the tokens that are matched with a typo.
+	minTypoTokenLen = 4
+)
+
+// tokenSetSimilarity gets the similarity of two token sets, regardless of the
+// order and repetition of the tokens. Each token of a set is matched with at
+// most one token of the other, and tokens with a typo count as a partial
+// match, so the similarity is between 0 and 1.
+func tokenSetSimilarity(a, b []string) float64 {
+	a, b = dedupe(a), dedupe(b)
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	used := make([]bool, len(b))
+	matched := 0.0
+	for _, x := range a {
+		best, bestIndex := 0.0, -1
+		for i, y := range b {
+			if used[i] {
+				continue
+			}
+			s := jaroWinkler(x, y)
+			if s < minTokenScore {
+				continue
+			}
+			if s > best {
+				best, bestIndex = s, i
+			}
+		}
+		if bestIndex >= 0 {
+			used[bestIndex] = true
+			matched += best
+		}
+	}
+	return 2 * matched / float64(len(a)+len(b))
+}
+
+// dedupe removes the repeated values of a list, keeping their first order.
+func dedupe(list []string) []string {
+	seen := map[string]struct{}{}
+	result := []string{}
+	for _, v := range list {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// nameScore gets the similarity of two normalized names, between 0 and 1. The
+// tokens of the names are compared with their Jaro-Winkler similarity.
+func nameScore(a, b string) float64 {
+	return tokenSetSimilarity(strings.Fields(a), strings.Fields(b))
+}
+
+// deletionVariants gets the variants of a token with one deleted character,
+// which two tokens with a typo have in common.
+func deletionVariants(token string) []string {
+	r := []rune(token)
+	if len(r) < minTypoTokenLen {
+		return nil
+	}
+	result := []string{}
+	for i := range r {
+		result = append(result, string(r[:i])+string(r[i+1:]))
+	}
+	return result
+}
+
+// NameEntry is an entity of the name index.
+type NameEntry struct {
+	Dcid  string
+	Names []string
+	Types []string
+	// Places the entity is contained in, directly or not.
+	Ancestors []string
+}
+
+// NameMatch is an entity that matches a name.
+type NameMatch struct {
+	Dcid        string
+	Probability float64
+}
+
+type indexedName struct {
+	entry *NameEntry
+	name  string
+}
+
+// NameIndex indexes entities by the tokens of their normalized names. Tokens
+// are also indexed by their deletion variants, to find names with a typo.
+type NameIndex struct {
+	names []*indexedName
+	// Token -> indexes of the names that have it.
+	tokens map[string][]int
+	// Deletion variant -> tokens that have it.
+	variants map[string][]string
+}
+
+// NewNameIndex builds a name index of entities.
+func NewNameIndex(entries []*NameEntry) *NameIndex {
+	idx := &NameIndex{tokens: map[string][]int{}, variants: map[string][]string{}}
+	for _, e := range entries {
+		for _, name := range e.Names {
+			n := normalizeName(name)
+			if n == "" {
+				continue
+			}
+			idx.names = append(idx.names, &indexedName{entry: e, name: n})
+			for _, token := range strings.Fields(n) {
+				if _, ok := idx.tokens[token]; !ok {
+					for _, v := range deletionVariants(token) {
+						idx.variants[v] = append(idx.variants[v], token)
+					}
+				}
+				idx.tokens[token] = append(idx.tokens[token], len(idx.names)-1)
+			}
+		}
+	}
+	return idx
+}
+
+// candidates gets the indexes of the names that share a token with the query
+// tokens, allowing a typo.
+func (idx *NameIndex) candidates(tokens []string) map[int]struct{} {
+	similar := map[string]struct{}{}
+	for _, t := range tokens {
+		similar[t] = struct{}{}
+		variants := deletionVariants(t)
+		for _, v := range append(variants, t) {
+			for _, s := range idx.variants[v] {
+				similar[s] = struct{}{}
+			}
+		}
+		// Tokens with one more character than the query token.
+		for _, v := range variants {
+			if _, ok := idx.tokens[v]; ok {
+				similar[v] = struct{}{}
+			}
+		}
+	}
+	result := map[int]struct{}{}
+	for t := range similar {
+		for _, i := range idx.tokens[t] {
+			result[i] = struct{}{}
+		}
+	}
+	return result
+}
+
+// hasAny checks if a list has any of the wanted values. It is true when no
+// value is wanted.
+func hasAny(list []string, wanted map[string]struct{}) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if _, ok := wanted[v]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Search finds the entities of the given types and in the given places with a
+// name similar to the query name.
+//
+// The probability of the best match is its name score. It is shared with the
+// other matches of close scores, so the probabilities add up to at most the
+// best score.
+func (idx *NameIndex) Search(
+	name string, types, ancestors map[string]struct{}, limit int) []*NameMatch {
+	query := normalizeName(name)
+	scores := map[*NameEntry]float64{}
+	for i := range idx.candidates(strings.Fields(query)) {
+		n := idx.names[i]
+		if !hasAny(n.entry.Types, types) || !hasAny(n.entry.Ancestors, ancestors) {
+			continue
+		}
+		if s := nameScore(query, n.name); s >= minNameScore && s > scores[n.entry] {
+			scores[n.entry] = s
+		}
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+	entries := []*NameEntry{}
+	for e := range scores {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		si, sj := scores[entries[i]], scores[entries[j]]
+		if si != sj {
+			return si > sj
+		}
+		return entries[i].Dcid < entries[j].Dcid
+	})
+	best := scores[entries[0]]
+	total := 0.0
+	for _, e := range entries {
+		total += math.Exp((scores[e] - best) / nameScoreTemperature)
+	}
+	result := []*NameMatch{}
+	for _, e := range entries {
+		if limit > 0 && len(result) == limit {
+			break
+		}
+		result = append(result, &NameMatch{
+			Dcid:        e.Dcid,
+			Probability: best * math.Exp((scores[e]-best)/nameScoreTemperature) / total,
+		})
+	}
+	return result
+}
+
+// LoadNameIndex builds the name index of the places in the place metadata
+// cache of the base Bigtable.
+func LoadNameIndex(ctx context.Context, store *store.Store) (*NameIndex, error) {
+	baseBt := store.BtGroup.BaseBt()
+	if baseBt == nil {
+		return nil, status.Errorf(codes.NotFound, "Bigtable instance is not specified")
+	}
+	entries := []*NameEntry{}
+	err := bigtable.ReadAllPlacesMetadata(ctx, baseBt,
+		func(place string, data *pb.PlaceMetadataCache) error {
+			if entry := placeNameEntry(place, data); entry != nil {
+				entries = append(entries, entry)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Built name index of %d places", len(entries))
+	return NewNameIndex(entries), nil
+}
+
+// placeNameEntry gets the name entry of a place from its metadata, which has
+// the place and its ancestors.
+func placeNameEntry(place string, data *pb.PlaceMetadataCache) *NameEntry {
+	infos := map[string]*pb.PlaceMetadataCache_PlaceInfo{}
+	for _, info := range data.GetPlaces() {
+		infos[info.GetDcid()] = info
+	}
+	self, ok := infos[place]
+	if !ok || self.GetName() == "" {
+		return nil
+	}
+	entry := &NameEntry{Dcid: place, Names: []string{self.GetName()}}
+	if self.GetType() != "" {
+		entry.Types = []string{self.GetType()}
+	}
+	visited := map[string]struct{}{place: {}}
+	queue := append([]string{}, self.GetParents()...)
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[curr]; ok {
+			continue
+		}
+		visited[curr] = struct{}{}
+		entry.Ancestors = append(entry.Ancestors, curr)
+		queue = append(queue, infos[curr].GetParents()...)
+	}
+	return entry
+}
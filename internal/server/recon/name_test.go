@@ -0,0 +1,173 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func testNameIndex() *NameIndex {
+	return NewNameIndex([]*NameEntry{
+		{
+			Dcid: "geoId/0649670", Names: []string{"Mountain View"}, Types: []string{"City"},
+			Ancestors: []string{"geoId/06085", "geoId/06", "country/USA"},
+		},
+		{
+			Dcid: "geoId/0502270", Names: []string{"Mountain View"}, Types: []string{"City"},
+			Ancestors: []string{"geoId/05", "country/USA"},
+		},
+		{
+			Dcid: "geoId/06085", Names: []string{"Santa Clara County"}, Types: []string{"County"},
+			Ancestors: []string{"geoId/06", "country/USA"},
+		},
+		{
+			Dcid: "geoId/0669084", Names: []string{"Santa Clara"}, Types: []string{"City"},
+			Ancestors: []string{"geoId/06085", "geoId/06", "country/USA"},
+		},
+		{
+			Dcid: "wikidataId/Q1490", Names: []string{"Düsseldorf"}, Types: []string{"City"},
+			Ancestors: []string{"country/DEU"},
+		},
+	})
+}
+
+func TestNameIndexSearch(t *testing.T) {
+	idx := testNameIndex()
+	set := func(vals ...string) map[string]struct{} {
+		result := map[string]struct{}{}
+		for _, v := range vals {
+			result[v] = struct{}{}
+		}
+		return result
+	}
+	for _, c := range []struct {
+		name      string
+		types     map[string]struct{}
+		ancestors map[string]struct{}
+		want      []string
+	}{
+		{"Mountain View", nil, set("geoId/06"), []string{"geoId/0649670"}},
+		{"mountain-view", nil, nil, []string{"geoId/0502270", "geoId/0649670"}},
+		// Typo.
+		{"Moutain View", nil, set("geoId/06"), []string{"geoId/0649670"}},
+		// Transliteration.
+		{"Dusseldorf", nil, nil, []string{"wikidataId/Q1490"}},
+		// Token order.
+		{"County of Santa Clara", set("County"), nil, []string{"geoId/06085"}},
+		{"Santa Clara", set("City"), nil, []string{"geoId/0669084"}},
+		{"Santa Clara", set("State"), nil, []string{}},
+		{"Sunnyvale", nil, nil, []string{}},
+	} {
+		got := []string{}
+		total := 0.0
+		for _, m := range idx.Search(c.name, c.types, c.ancestors, 0) {
+			got = append(got, m.Dcid)
+			total += m.Probability
+		}
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("Search(%q) got diff %+v", c.name, diff)
+		}
+		if total > 1+1e-9 {
+			t.Errorf("Search(%q) got total probability %v > 1", c.name, total)
+		}
+	}
+}
+
+func TestTokenSetSimilarity(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		want float64
+	}{
+		{"mountain view", "mountain view", 1},
+		// Repeated tokens are matched once.
+		{"new york new york", "new york", 1},
+		{"santa clara", "santa clara county", 0.8},
+		{"county of santa clara", "santa clara county", 6.0 / 7},
+		{"sunnyvale", "santa clara", 0},
+	} {
+		got := nameScore(c.a, c.b)
+		if math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("nameScore(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestNameIndexProbability(t *testing.T) {
+	idx := testNameIndex()
+	got := idx.Search("Santa Clara", nil, nil, 0)
+	if len(got) != 2 || got[0].Dcid != "geoId/0669084" {
+		t.Fatalf("Search(Santa Clara) = %v, want the city first", got)
+	}
+	// The exact match gets most of the probability.
+	if got[0].Probability < 0.9 || got[1].Probability > 0.1 {
+		t.Errorf("Search(Santa Clara) got probabilities %v and %v",
+			got[0].Probability, got[1].Probability)
+	}
+	// Repeated words of the query don't push the probability above 1.
+	got = NewNameIndex([]*NameEntry{
+		{Dcid: "geoId/3651000", Names: []string{"New York"}, Types: []string{"City"}},
+	}).Search("New York, New York", nil, nil, 0)
+	if len(got) != 1 || got[0].Probability != 1 {
+		t.Errorf("Search(New York, New York) = %v, want one match of probability 1", got)
+	}
+	// Two exact matches share it.
+	got = idx.Search("Mountain View", nil, nil, 0)
+	if len(got) != 2 || got[0].Probability != 0.5 || got[1].Probability != 0.5 {
+		t.Errorf("Search(Mountain View) = %v, want two matches of probability 0.5", got)
+	}
+}
+
+func TestResolveEntitiesByName(t *testing.T) {
+	in := &pb.ResolveEntitiesRequest{
+		Entities: []*pb.EntitySubGraph{
+			place("mv", map[string][]string{
+				"name":             {`"Mountain View"`},
+				"typeOf":           {"City"},
+				"containedInPlace": {"l:ca"},
+			}),
+			place("unknown", map[string][]string{"name": {`"Sunnyvale"`}}),
+		},
+	}
+	// The parent is a local node of the sub-graph.
+	in.Entities[0].GetSubGraph().Nodes["ca"] = &pb.McfGraph_PropertyValues{
+		Pvs: map[string]*pb.McfGraph_Values{
+			"dcid": {TypedValues: []*pb.McfGraph_TypedValue{{Value: proto.String("geoId/06")}}},
+		},
+	}
+	got, err := ResolveEntities(context.Background(), in, &store.Store{}, testNameIndex())
+	if err != nil {
+		t.Fatalf("ResolveEntities() = %s", err)
+	}
+	want := []*pb.ResolveEntitiesResponse_ResolvedEntity{
+		{SourceId: "unknown", ResolvedIds: []*pb.ResolveEntitiesResponse_ResolvedId{}},
+		{
+			SourceId: "mv",
+			ResolvedIds: []*pb.ResolveEntitiesResponse_ResolvedId{
+				{Ids: []*pb.IdWithProperty{{Prop: "dcid", Val: "geoId/0649670"}}, Probability: 1},
+			},
+		},
+	}
+	if diff := cmp.Diff(got.GetResolvedEntities(), want, protocmp.Transform()); diff != "" {
+		t.Errorf("ResolveEntities() got diff %+v", diff)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Maximum number of places FindNearbyPlaces returns.
+	maxNearbyPlaces = 1000
+	// Maximum search radius of FindNearbyPlaces.
+	maxNearbyRadiusKm = 1000.0
+)
+
+// FindNearbyPlaces implements API for ReconServer.FindNearbyPlaces.
+func FindNearbyPlaces(
+	ctx context.Context, in *pb.FindNearbyPlacesRequest, store *store.Store) (
+	*pb.FindNearbyPlacesResponse, error,
+) {
+	if store.Centroids == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Place centroid index is not loaded")
+	}
+	lat, lng := in.GetLatitude(), in.GetLongitude()
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid coordinate: %v, %v", lat, lng)
+	}
+	if in.GetRadiusKm() < 0 || in.GetLimit() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "radius_km and limit should not be negative")
+	}
+	if in.GetRadiusKm() == 0 && in.GetLimit() == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required argument: radius_km or limit")
+	}
+	if in.GetRadiusKm() > maxNearbyRadiusKm {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"radius_km should be at most %v, got %v", maxNearbyRadiusKm, in.GetRadiusKm())
+	}
+	if in.GetLimit() > maxNearbyPlaces {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"limit should be at most %d, got %d", maxNearbyPlaces, in.GetLimit())
+	}
+	limit := int(in.GetLimit())
+	if limit == 0 {
+		limit = maxNearbyPlaces
+	}
+	places, truncated := store.Centroids.Search(lat, lng, in.GetRadiusKm(), limit, in.GetPlaceType())
+	res := &pb.FindNearbyPlacesResponse{Truncated: truncated}
+	for _, p := range places {
+		res.Places = append(res.Places, &pb.FindNearbyPlacesResponse_Place{
+			Dcid:       p.Dcid,
+			Name:       p.Name,
+			Types:      p.Types,
+			Latitude:   p.Latitude,
+			Longitude:  p.Longitude,
+			DistanceKm: p.DistanceKm,
+		})
+	}
+	return res, nil
+}
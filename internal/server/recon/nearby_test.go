@@ -0,0 +1,62 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/geo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFindNearbyPlaces(t *testing.T) {
+	ctx := context.Background()
+	s := &store.Store{Centroids: geo.NewCentroidIndex([]*geo.Place{
+		{Dcid: "geoId/0649670", Name: "Mountain View", Types: []string{"City"}, Latitude: 37.3861, Longitude: -122.0839},
+		{Dcid: "geoId/0677000", Name: "Sunnyvale", Types: []string{"City"}, Latitude: 37.3688, Longitude: -122.0363},
+		{Dcid: "ws/KNUQ", Name: "Moffett Field", Types: []string{"WeatherStation"}, Latitude: 37.4161, Longitude: -122.0491},
+	})}
+	got, err := FindNearbyPlaces(ctx, &pb.FindNearbyPlacesRequest{
+		Latitude: 37.3861, Longitude: -122.0839, RadiusKm: 10, PlaceType: "City",
+	}, s)
+	if err != nil {
+		t.Fatalf("FindNearbyPlaces() = %s", err)
+	}
+	if len(got.GetPlaces()) != 2 || got.GetPlaces()[0].GetDcid() != "geoId/0649670" ||
+		got.GetPlaces()[0].GetDistanceKm() != 0 || got.GetPlaces()[1].GetName() != "Sunnyvale" {
+		t.Errorf("FindNearbyPlaces() = %v, want Mountain View and Sunnyvale", got)
+	}
+
+	for _, c := range []struct {
+		in    *pb.FindNearbyPlacesRequest
+		store *store.Store
+		want  codes.Code
+	}{
+		{&pb.FindNearbyPlacesRequest{Limit: 5}, &store.Store{}, codes.FailedPrecondition},
+		{&pb.FindNearbyPlacesRequest{Latitude: 37}, s, codes.InvalidArgument},
+		{&pb.FindNearbyPlacesRequest{Latitude: 91, Limit: 5}, s, codes.InvalidArgument},
+		{&pb.FindNearbyPlacesRequest{RadiusKm: -1}, s, codes.InvalidArgument},
+		{&pb.FindNearbyPlacesRequest{RadiusKm: 5000}, s, codes.InvalidArgument},
+		{&pb.FindNearbyPlacesRequest{Limit: maxNearbyPlaces + 1}, s, codes.InvalidArgument},
+	} {
+		if _, err := FindNearbyPlaces(ctx, c.in, c.store); status.Code(err) != c.want {
+			t.Errorf("FindNearbyPlaces(%v) = %v, want %s", c.in, err, c.want)
+		}
+	}
+}
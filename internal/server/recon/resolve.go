@@ -29,6 +29,9 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// Maximum number of entities an entity is resolved to by name.
+const maxNameMatches = 5
+
 var (
 	// This is a preferred list.
 	// The props ranked higher are preferred over those ranked lower for resolving.
@@ -58,14 +61,28 @@ func getPropVal(node *pb.McfGraph_PropertyValues, prop string) string {
 	return typedValues[0].GetValue()
 }
 
+// nameQuery is the name of an entity to resolve, with the types and places
+// its candidates should have.
+type nameQuery struct {
+	names     []string
+	types     map[string]struct{}
+	ancestors map[string]struct{}
+}
+
 // ResolveEntities implements API for ReconServer.ResolveEntities.
+//
+// Entities are resolved by their IDs. Entities of a sub-graph that have no
+// resolved ID are resolved by their name, type and containing places with the
+// name index, if there is one.
 func ResolveEntities(
 	ctx context.Context, in *pb.ResolveEntitiesRequest, store *store.Store,
+	nameIndex *NameIndex,
 ) (
 	*pb.ResolveEntitiesResponse, error) {
 	rowList := cbt.RowList{}
 	idKeyToSourceIDs := map[string][]string{}
 	sourceIDs := map[string]struct{}{}
+	nameQueries := map[string]*nameQuery{}
 
 	// Collect to-be-resolved IDs to rowList and idKeyToSourceID.
 	for _, entity := range in.GetEntities() {
@@ -88,6 +105,9 @@ func ResolveEntities(
 				rowList = append(rowList, fmt.Sprintf("%s%s", bigtable.BtReconIDMapPrefix, idKey))
 				idKeyToSourceIDs[idKey] = append(idKeyToSourceIDs[idKey], sourceID)
 			}
+			if q := getNameQuery(entity.GetSubGraph(), node); q != nil {
+				nameQueries[sourceID] = q
+			}
 		case *pb.EntitySubGraph_EntityIds:
 			idStore := map[string]string{} // Map: ID prop -> ID val.
 			for _, id := range entity.GetEntityIds().GetIds() {
@@ -109,22 +129,26 @@ func ResolveEntities(
 		sourceIDs[sourceID] = struct{}{}
 	}
 
-	// Read ReconIdMap cache.
-	dataMap, _, err := bigtable.Read(ctx, store.BtGroup, rowList,
-		func(dcid string, jsonRaw []byte) (interface{}, error) {
-			var reconEntities pb.ReconEntities
-			err := protojson.Unmarshal(jsonRaw, &reconEntities)
-			if err != nil {
-				return nil, err
-			}
-			return &reconEntities, nil
-		},
-		func(rowKey string) (string, error) {
-			return strings.TrimPrefix(rowKey, bigtable.BtReconIDMapPrefix), nil
-		},
-		false)
-	if err != nil {
-		return nil, err
+	// Read ReconIdMap cache, unless no entity has an ID.
+	dataMap := map[string]interface{}{}
+	if len(rowList) > 0 {
+		var err error
+		dataMap, _, err = bigtable.Read(ctx, store.BtGroup, rowList,
+			func(dcid string, jsonRaw []byte) (interface{}, error) {
+				var reconEntities pb.ReconEntities
+				err := protojson.Unmarshal(jsonRaw, &reconEntities)
+				if err != nil {
+					return nil, err
+				}
+				return &reconEntities, nil
+			},
+			func(rowKey string) (string, error) {
+				return strings.TrimPrefix(rowKey, bigtable.BtReconIDMapPrefix), nil
+			},
+			false)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Source ID -> ID Prop -> ReconEntities.
@@ -195,15 +219,18 @@ func ResolveEntities(
 		res.ResolvedEntities = append(res.ResolvedEntities, resolvedEntity)
 	}
 
-	// Add entities that are not resolved as empty result.
+	// Resolve the other entities by name, or add them as empty result.
 	for sourceID := range sourceIDs {
 		if _, ok := reconEntityStore[sourceID]; ok { // Resolved.
 			continue
 		}
-		res.ResolvedEntities = append(res.ResolvedEntities,
-			&pb.ResolveEntitiesResponse_ResolvedEntity{
-				SourceId: sourceID,
-			})
+		resolvedEntity := &pb.ResolveEntitiesResponse_ResolvedEntity{
+			SourceId: sourceID,
+		}
+		if q, ok := nameQueries[sourceID]; ok && nameIndex != nil {
+			resolvedEntity.ResolvedIds = resolveName(nameIndex, q)
+		}
+		res.ResolvedEntities = append(res.ResolvedEntities, resolvedEntity)
 	}
 
 	// Sort to make the result deterministic.
@@ -213,3 +240,55 @@ func ResolveEntities(
 
 	return res, nil
 }
+
+// getNameQuery gets the name query of an entity node. It is nil if the entity
+// has no name.
+func getNameQuery(graph *pb.McfGraph, node *pb.McfGraph_PropertyValues) *nameQuery {
+	q := &nameQuery{
+		types:     map[string]struct{}{},
+		ancestors: map[string]struct{}{},
+	}
+	for _, prop := range nameProps {
+		q.names = append(q.names, getPropVals(node, prop)...)
+	}
+	if len(q.names) == 0 {
+		return nil
+	}
+	for _, v := range getPropVals(node, "typeOf") {
+		q.types[refValue(graph, v)] = struct{}{}
+	}
+	for _, v := range getPropVals(node, "containedInPlace") {
+		q.ancestors[refValue(graph, v)] = struct{}{}
+	}
+	return q
+}
+
+// resolveName resolves an entity by its names. Each entity found gets the best
+// probability among its names.
+func resolveName(nameIndex *NameIndex, q *nameQuery) []*pb.ResolveEntitiesResponse_ResolvedId {
+	probabilities := map[string]float64{}
+	for _, name := range q.names {
+		for _, m := range nameIndex.Search(name, q.types, q.ancestors, maxNameMatches) {
+			if m.Probability > probabilities[m.Dcid] {
+				probabilities[m.Dcid] = m.Probability
+			}
+		}
+	}
+	result := []*pb.ResolveEntitiesResponse_ResolvedId{}
+	for dcid, p := range probabilities {
+		result = append(result, &pb.ResolveEntitiesResponse_ResolvedId{
+			Ids:         []*pb.IdWithProperty{{Prop: "dcid", Val: dcid}},
+			Probability: p,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GetProbability() != result[j].GetProbability() {
+			return result[i].GetProbability() > result[j].GetProbability()
+		}
+		return result[i].GetIds()[0].GetVal() < result[j].GetIds()[0].GetVal()
+	})
+	if len(result) > maxNameMatches {
+		result = result[:maxNameMatches]
+	}
+	return result
+}
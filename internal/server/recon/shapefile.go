@@ -0,0 +1,195 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/golang/geo/s2"
+)
+
+// Shapefile (.shp) layout, from the ESRI Shapefile Technical Description.
+const (
+	shpFileCode   = 9994
+	shpHeaderSize = 100
+	// Shape types.
+	shpNull     = 0
+	shpPolygon  = 5
+	shpPolygonZ = 15
+	shpPolygonM = 25
+)
+
+// dBASE (.dbf) field names have at most 10 characters.
+const dbfMaxFieldName = 10
+
+// readShapefile reads the polygons of a shapefile, in longitude and latitude
+// degrees. Null shapes are read as nil, so that the polygons line up with the
+// records of the attribute table.
+func readShapefile(b []byte) ([]*s2.Polygon, error) {
+	if len(b) < shpHeaderSize || binary.BigEndian.Uint32(b[0:4]) != shpFileCode {
+		return nil, fmt.Errorf("not a shapefile")
+	}
+	// The file length is in 16-bit words.
+	if size := int(binary.BigEndian.Uint32(b[24:28])) * 2; size < len(b) {
+		b = b[:size]
+	}
+	polygons := []*s2.Polygon{}
+	for offset := shpHeaderSize; offset < len(b); {
+		if offset+8 > len(b) {
+			return nil, fmt.Errorf("truncated record %d", len(polygons)+1)
+		}
+		start := offset + 8
+		end := start + int(binary.BigEndian.Uint32(b[offset+4:offset+8]))*2
+		if end > len(b) || end < start+4 {
+			return nil, fmt.Errorf("truncated record %d", len(polygons)+1)
+		}
+		polygon, err := readShapeRecord(b[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %s", len(polygons)+1, err)
+		}
+		polygons = append(polygons, polygon)
+		offset = end
+	}
+	return polygons, nil
+}
+
+// readShapeRecord reads the polygon of a shapefile record. The measures of
+// PolygonM and the heights of PolygonZ are ignored.
+func readShapeRecord(rec []byte) (*s2.Polygon, error) {
+	le := binary.LittleEndian
+	switch shapeType := le.Uint32(rec[0:4]); shapeType {
+	case shpNull:
+		return nil, nil
+	case shpPolygon, shpPolygonZ, shpPolygonM:
+	default:
+		return nil, fmt.Errorf("shape type %d is not a polygon", shapeType)
+	}
+	// The bounding box takes the 32 bytes after the shape type.
+	if len(rec) < 44 {
+		return nil, fmt.Errorf("truncated polygon")
+	}
+	numParts := int(le.Uint32(rec[36:40]))
+	numPoints := int(le.Uint32(rec[40:44]))
+	pointsStart := 44 + 4*numParts
+	if numParts == 0 || pointsStart+16*numPoints > len(rec) {
+		return nil, fmt.Errorf("truncated polygon")
+	}
+	loops := []*s2.Loop{}
+	for i := 0; i < numParts; i++ {
+		start := int(le.Uint32(rec[44+4*i:]))
+		end := numPoints
+		if i+1 < numParts {
+			end = int(le.Uint32(rec[48+4*i:]))
+		}
+		if start > end || end > numPoints || end-start < 4 {
+			return nil, fmt.Errorf("ring %d requires >= 4 points", i)
+		}
+		// Like in GeoJSON, the last point is the same as the first point, and
+		// is skipped. The shells of shapefiles are clockwise and their holes
+		// counter-clockwise, which is the opposite of S2, so the points are read
+		// backwards.
+		points := []s2.Point{}
+		for j := end - 2; j >= start; j-- {
+			p := rec[pointsStart+16*j:]
+			lng := math.Float64frombits(le.Uint64(p[0:8]))
+			lat := math.Float64frombits(le.Uint64(p[8:16]))
+			points = append(points, s2.PointFromLatLng(s2.LatLngFromDegrees(lat, lng)))
+		}
+		loops = append(loops, s2.LoopFromPoints(points))
+	}
+	return s2.PolygonFromOrientedLoops(loops), nil
+}
+
+// readDBF reads the records of a dBASE table, as the trimmed values of their
+// fields by name. Deleted records are read as nil.
+func readDBF(b []byte) ([]map[string]string, error) {
+	if len(b) < 32 {
+		return nil, fmt.Errorf("not a dBASE file")
+	}
+	le := binary.LittleEndian
+	numRecords := int(le.Uint32(b[4:8]))
+	headerSize := int(le.Uint16(b[8:10]))
+	recordSize := int(le.Uint16(b[10:12]))
+	if headerSize > len(b) {
+		return nil, fmt.Errorf("truncated header")
+	}
+	type field struct {
+		name string
+		size int
+	}
+	fields := []field{}
+	// Records start with a deletion flag.
+	width := 1
+	// Field descriptors take 32 bytes each, and end with 0x0D.
+	for offset := 32; offset+32 <= headerSize && b[offset] != 0x0D; offset += 32 {
+		name := string(b[offset : offset+11])
+		if i := strings.IndexByte(name, 0); i >= 0 {
+			name = name[:i]
+		}
+		size := int(b[offset+16])
+		fields = append(fields, field{name: name, size: size})
+		width += size
+	}
+	if width > recordSize {
+		return nil, fmt.Errorf("fields of %d bytes do not fit in records of %d bytes", width, recordSize)
+	}
+	if headerSize+numRecords*recordSize > len(b) {
+		return nil, fmt.Errorf("truncated records")
+	}
+	records := make([]map[string]string, numRecords)
+	for i := range records {
+		rec := b[headerSize+i*recordSize:]
+		if rec[0] == '*' {
+			continue
+		}
+		values := map[string]string{}
+		pos := 1
+		for _, f := range fields {
+			values[f.name] = strings.TrimSpace(string(rec[pos : pos+f.size]))
+			pos += f.size
+		}
+		records[i] = values
+	}
+	return records, nil
+}
+
+// dbfValue gets the value of a property in a dBASE record. Field names are
+// matched regardless of case, and the property is truncated like field names,
+// e.g. "containedI" for containedInPlace.
+func dbfValue(record map[string]string, prop string) string {
+	if len(prop) > dbfMaxFieldName {
+		prop = prop[:dbfMaxFieldName]
+	}
+	for name, v := range record {
+		if strings.EqualFold(name, prop) {
+			return v
+		}
+	}
+	return ""
+}
+
+// dbfList splits a comma-separated dBASE value into a list.
+func dbfList(v string) []string {
+	result := []string{}
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -44,12 +44,43 @@ type Metadata struct {
 	Bq               string
 	BtProject        string
 	BranchBtInstance string
+	// Maximum number of bytes a BigQuery query can process. 0 if there is no
+	// limit.
+	BqMaxBytes int64
+	// Local directory or GCS folder ("gs://bucket/folder") of the schema
+	// mapping files, and path of table_types.json, to reload the mappings from.
+	SchemaPath     string
+	TableTypesPath string
+	// Generation of the schema mapping, increased each time it is reloaded.
+	MappingGeneration int64
 }
 
+// Fields of a stat var (group) that are searched.
+const (
+	NameField = iota
+	DescriptionField
+	SynonymField
+	NumSearchFields
+)
+
 // SearchIndex holds the index for searching stat var (group).
 type SearchIndex struct {
 	RootTrieNode *TrieNode
 	Ranking      map[string]*RankingInfo
+	// Docs holds the terms of each sv/svg to score search results.
+	Docs map[string]*SearchDoc
+	// DocFreq is the number of sv/svg that have each term in any field.
+	DocFreq map[string]int
+	// TotalLength is the number of terms in each field of all the sv/svg.
+	TotalLength [NumSearchFields]int
+}
+
+// SearchDoc holds the terms of a stat var (group).
+type SearchDoc struct {
+	// TermFreq is the number of occurrences of each term in each field.
+	TermFreq [NumSearchFields]map[string]int
+	// Length is the number of terms in each field.
+	Length [NumSearchFields]int
 }
 
 // TrieNode represents a node in the sv hierarchy search Trie.
@@ -69,22 +100,51 @@ type RankingInfo struct {
 	RankingName string
 }
 
-// Update search index, given a stat var (group) node ID and string.
+// Tokenize splits a string into lower case search terms.
+func Tokenize(s string) []string {
+	return strings.Fields(strings.ReplaceAll(strings.ToLower(s), ",", " "))
+}
+
+// Update search index, given a stat var (group) node ID, its display name and
+// descriptions. Terms of the name, the descriptions and their synonyms are
+// indexed.
 func (index *SearchIndex) Update(
-	nodeID string, nodeString string, displayName string, isSvg bool, synonymMap map[string][]string) {
-	processedTokenString := strings.ToLower(nodeString)
-	processedTokenString = strings.ReplaceAll(processedTokenString, ",", " ")
-	tokenList := strings.Fields(processedTokenString)
-	// Create a set of tokens from the tokens in tokenList and their synonyms
-	tokens := map[string]struct{}{}
-	for _, token := range tokenList {
-		tokens[token] = struct{}{}
-		if synonymList, ok := synonymMap[token]; ok {
-			for _, synonym := range synonymList {
-				tokens[synonym] = struct{}{}
+	nodeID string, displayName string, descriptions []string, isSvg bool, synonymMap map[string][]string) {
+	doc := &SearchDoc{}
+	for f := range doc.TermFreq {
+		doc.TermFreq[f] = map[string]int{}
+	}
+	addTerms := func(field int, terms []string) {
+		for _, term := range terms {
+			doc.TermFreq[field][term]++
+			doc.Length[field]++
+			for _, synonym := range synonymMap[term] {
+				doc.TermFreq[SynonymField][synonym]++
+				doc.Length[SynonymField]++
 			}
 		}
 	}
+	addTerms(NameField, Tokenize(displayName))
+	seenDescriptions := map[string]struct{}{}
+	for _, description := range descriptions {
+		if _, ok := seenDescriptions[description]; ok {
+			continue
+		}
+		seenDescriptions[description] = struct{}{}
+		addTerms(DescriptionField, Tokenize(description))
+	}
+	// Create a set of the terms of all the fields
+	tokens := map[string]struct{}{}
+	for f := range doc.TermFreq {
+		for token := range doc.TermFreq[f] {
+			tokens[token] = struct{}{}
+		}
+		index.TotalLength[f] += doc.Length[f]
+	}
+	for token := range tokens {
+		index.DocFreq[token]++
+	}
+	index.Docs[nodeID] = doc
 	approxNumPv := len(strings.Split(nodeID, "_"))
 	if approxNumPv == 1 {
 		// when approxNumPv is 1, most likely a non human curated PV
@@ -0,0 +1,256 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"context"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/server/node"
+	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	subClassOf     = "subClassOf"
+	subPropertyOf  = "subPropertyOf"
+	domainIncludes = "domainIncludes"
+	rangeIncludes  = "rangeIncludes"
+	description    = "description"
+
+	// maxAncestorDepth bounds the walk up the type hierarchy, in case the
+	// schema has a cycle.
+	maxAncestorDepth = 20
+)
+
+// GetTypeSchema implements API for Mixer.GetTypeSchema.
+func GetTypeSchema(
+	ctx context.Context,
+	in *pb.GetTypeSchemaRequest,
+	store *store.Store,
+	metadata *resource.Metadata,
+) (*pb.GetTypeSchemaResponse, error) {
+	types := in.GetTypes()
+	if len(types) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required argument: types")
+	}
+	if !util.CheckValidDCIDs(types) {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid types")
+	}
+
+	parents, err := node.GetPropertyValuesHelper(ctx, store, types, subClassOf, true)
+	if err != nil {
+		return nil, err
+	}
+	children, err := node.GetPropertyValuesHelper(ctx, store, types, subClassOf, false)
+	if err != nil {
+		return nil, err
+	}
+	descriptions, err := node.GetPropertyValuesHelper(ctx, store, types, description, true)
+	if err != nil {
+		return nil, err
+	}
+	ancestors, err := getAncestors(ctx, store, types, parents)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the properties of all the types and their ancestors in one batch.
+	allTypes := []string{}
+	seen := map[string]struct{}{}
+	for _, t := range types {
+		for _, a := range append([]string{t}, ancestors[t]...) {
+			if _, ok := seen[a]; !ok {
+				seen[a] = struct{}{}
+				allTypes = append(allTypes, a)
+			}
+		}
+	}
+	typeProps, err := node.GetPropertyValuesHelper(ctx, store, allTypes, domainIncludes, false)
+	if err != nil {
+		return nil, err
+	}
+	allProps := []string{}
+	seen = map[string]struct{}{}
+	for _, t := range allTypes {
+		for _, p := range dcids(typeProps[t]) {
+			if _, ok := seen[p]; !ok {
+				seen[p] = struct{}{}
+				allProps = append(allProps, p)
+			}
+		}
+	}
+	ranges := map[string][]*model.Node{}
+	if len(allProps) > 0 {
+		ranges, err = node.GetPropertyValuesHelper(ctx, store, allProps, rangeIncludes, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &pb.GetTypeSchemaResponse{Types: map[string]*pb.TypeSchema{}}
+	for _, t := range types {
+		schema := &pb.TypeSchema{
+			Dcid:        t,
+			Description: firstValue(descriptions[t]),
+			Parents:     dcids(parents[t]),
+			Ancestors:   ancestors[t],
+			Children:    dcids(children[t]),
+		}
+		if metadata != nil {
+			schema.TableType = metadata.SubTypeMap[t]
+		}
+		// Properties declared on the type come first, then the ones of the
+		// closest ancestors.
+		added := map[string]struct{}{}
+		for _, declaredBy := range append([]string{t}, ancestors[t]...) {
+			for _, p := range dcids(typeProps[declaredBy]) {
+				if _, ok := added[p]; ok {
+					continue
+				}
+				added[p] = struct{}{}
+				schema.Properties = append(schema.Properties, &pb.TypeProperty{
+					Property:      p,
+					RangeIncludes: dcids(ranges[p]),
+					DeclaredBy:    declaredBy,
+				})
+			}
+		}
+		result.Types[t] = schema
+	}
+	return result, nil
+}
+
+// GetPropertySchema implements API for Mixer.GetPropertySchema.
+func GetPropertySchema(
+	ctx context.Context,
+	in *pb.GetPropertySchemaRequest,
+	store *store.Store,
+) (*pb.GetPropertySchemaResponse, error) {
+	props := in.GetProperties()
+	if len(props) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing required argument: properties")
+	}
+	if !util.CheckValidDCIDs(props) {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid properties")
+	}
+
+	values := map[string]map[string][]*model.Node{}
+	for _, p := range []string{description, domainIncludes, rangeIncludes, subPropertyOf} {
+		resp, err := node.GetPropertyValuesHelper(ctx, store, props, p, true)
+		if err != nil {
+			return nil, err
+		}
+		values[p] = resp
+	}
+	result := &pb.GetPropertySchemaResponse{Properties: map[string]*pb.PropertySchema{}}
+	for _, p := range props {
+		result.Properties[p] = &pb.PropertySchema{
+			Dcid:           p,
+			Description:    firstValue(values[description][p]),
+			DomainIncludes: dcids(values[domainIncludes][p]),
+			RangeIncludes:  dcids(values[rangeIncludes][p]),
+			SubPropertyOf:  dcids(values[subPropertyOf][p]),
+		}
+	}
+	return result, nil
+}
+
+// getAncestors walks up the type hierarchy of each type, one level per batch
+// read. The ancestors of a type are ordered from the closest.
+func getAncestors(
+	ctx context.Context,
+	store *store.Store,
+	types []string,
+	parents map[string][]*model.Node,
+) (map[string][]string, error) {
+	// Parents of all the types reached so far.
+	allParents := map[string][]string{}
+	for _, t := range types {
+		allParents[t] = dcids(parents[t])
+	}
+	toRead := []string{}
+	for _, t := range types {
+		for _, p := range allParents[t] {
+			if _, ok := allParents[p]; !ok {
+				toRead = append(toRead, p)
+				// Mark as pending so it is only read once.
+				allParents[p] = nil
+			}
+		}
+	}
+	for depth := 0; depth < maxAncestorDepth && len(toRead) > 0; depth++ {
+		resp, err := node.GetPropertyValuesHelper(ctx, store, toRead, subClassOf, true)
+		if err != nil {
+			return nil, err
+		}
+		next := []string{}
+		for _, t := range toRead {
+			allParents[t] = dcids(resp[t])
+		}
+		for _, t := range toRead {
+			for _, p := range allParents[t] {
+				if _, ok := allParents[p]; !ok {
+					next = append(next, p)
+					allParents[p] = nil
+				}
+			}
+		}
+		toRead = next
+	}
+
+	result := map[string][]string{}
+	for _, t := range types {
+		// Breadth first, so closer ancestors come first.
+		ancestors := []string{}
+		visited := map[string]struct{}{t: {}}
+		queue := append([]string{}, allParents[t]...)
+		for len(queue) > 0 {
+			a := queue[0]
+			queue = queue[1:]
+			if _, ok := visited[a]; ok {
+				continue
+			}
+			visited[a] = struct{}{}
+			ancestors = append(ancestors, a)
+			queue = append(queue, allParents[a]...)
+		}
+		result[t] = ancestors
+	}
+	return result, nil
+}
+
+func dcids(nodes []*model.Node) []string {
+	result := []string{}
+	for _, n := range nodes {
+		if n.Dcid != "" {
+			result = append(result, n.Dcid)
+		}
+	}
+	return result
+}
+
+func firstValue(nodes []*model.Node) string {
+	for _, n := range nodes {
+		if n.Value != "" {
+			return n.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func setupStore(ctx context.Context, t *testing.T) *store.Store {
+	data := map[string]string{}
+	for key, value := range map[string]string{
+		bigtable.BtOutPropValPrefix + "County^subClassOf": `{"entities": [
+			{"dcid": "AdministrativeArea2"}]}`,
+		bigtable.BtOutPropValPrefix + "AdministrativeArea2^subClassOf": `{"entities": [
+			{"dcid": "AdministrativeArea"}]}`,
+		bigtable.BtOutPropValPrefix + "AdministrativeArea^subClassOf": `{"entities": [
+			{"dcid": "Place"}]}`,
+		bigtable.BtInPropValPrefix + "Place^subClassOf": `{"entities": [
+			{"dcid": "AdministrativeArea"}, {"dcid": "GeoRegion"}]}`,
+		bigtable.BtOutPropValPrefix + "County^description": `{"entities": [
+			{"value": "A county."}]}`,
+		bigtable.BtInPropValPrefix + "County^domainIncludes": `{"entities": [
+			{"dcid": "fipsCode"}]}`,
+		bigtable.BtInPropValPrefix + "Place^domainIncludes": `{"entities": [
+			{"dcid": "containedInPlace"}, {"dcid": "fipsCode"}]}`,
+		bigtable.BtOutPropValPrefix + "containedInPlace^domainIncludes": `{"entities": [
+			{"dcid": "Place"}]}`,
+		bigtable.BtOutPropValPrefix + "containedInPlace^rangeIncludes": `{"entities": [
+			{"dcid": "Place"}]}`,
+		bigtable.BtOutPropValPrefix + "fipsCode^rangeIncludes": `{"entities": [
+			{"dcid": "Text"}]}`,
+		bigtable.BtOutPropValPrefix + "containedInPlace^description": `{"entities": [
+			{"value": "Places that encompass this place."}]}`,
+	} {
+		tableValue, err := util.ZipAndEncode([]byte(value))
+		if err != nil {
+			t.Fatalf("util.ZipAndEncode(%s) = %v", value, err)
+		}
+		data[key] = tableValue
+	}
+	btTable, err := bigtable.SetupBigtable(ctx, data)
+	if err != nil {
+		t.Fatalf("SetupBigtable(...) = %v", err)
+	}
+	return store.NewStore(nil, nil, btTable, nil)
+}
+
+func TestGetTypeSchema(t *testing.T) {
+	ctx := context.Background()
+	s := setupStore(ctx, t)
+	metadata := &resource.Metadata{SubTypeMap: map[string]string{"County": "Place"}}
+
+	got, err := GetTypeSchema(
+		ctx, &pb.GetTypeSchemaRequest{Types: []string{"County", "Place"}}, s, metadata)
+	if err != nil {
+		t.Fatalf("GetTypeSchema() = %v", err)
+	}
+	want := &pb.GetTypeSchemaResponse{
+		Types: map[string]*pb.TypeSchema{
+			"County": {
+				Dcid:        "County",
+				Description: "A county.",
+				Parents:     []string{"AdministrativeArea2"},
+				Ancestors:   []string{"AdministrativeArea2", "AdministrativeArea", "Place"},
+				Children:    []string{},
+				Properties: []*pb.TypeProperty{
+					{Property: "fipsCode", RangeIncludes: []string{"Text"}, DeclaredBy: "County"},
+					{Property: "containedInPlace", RangeIncludes: []string{"Place"}, DeclaredBy: "Place"},
+				},
+				TableType: "Place",
+			},
+			"Place": {
+				Dcid:      "Place",
+				Parents:   []string{},
+				Ancestors: []string{},
+				Children:  []string{"AdministrativeArea", "GeoRegion"},
+				Properties: []*pb.TypeProperty{
+					{Property: "containedInPlace", RangeIncludes: []string{"Place"}, DeclaredBy: "Place"},
+					{Property: "fipsCode", RangeIncludes: []string{"Text"}, DeclaredBy: "Place"},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("GetTypeSchema() got diff %+v", diff)
+	}
+}
+
+func TestGetPropertySchema(t *testing.T) {
+	ctx := context.Background()
+	s := setupStore(ctx, t)
+
+	got, err := GetPropertySchema(
+		ctx, &pb.GetPropertySchemaRequest{Properties: []string{"containedInPlace"}}, s)
+	if err != nil {
+		t.Fatalf("GetPropertySchema() = %v", err)
+	}
+	want := &pb.GetPropertySchemaResponse{
+		Properties: map[string]*pb.PropertySchema{
+			"containedInPlace": {
+				Dcid:           "containedInPlace",
+				Description:    "Places that encompass this place.",
+				DomainIncludes: []string{"Place"},
+				RangeIncludes:  []string{"Place"},
+				SubPropertyOf:  []string{},
+			},
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("GetPropertySchema() got diff %+v", diff)
+	}
+}
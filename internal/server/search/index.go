@@ -0,0 +1,388 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"container/heap"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/util"
+)
+
+const (
+	// Maximum number of entities returned from the index.
+	maxIndexResults = 1000
+	// Type of the entities without types.
+	defaultType = "Thing"
+)
+
+// Types of the entities that are not returned unless a request sets the types.
+var defaultExcludedTypes = []string{"CensusTract", "PowerPlant", "PowerPlantUnit", "BiologicalSpecimen"}
+
+// Entity is an entity to search by its names.
+type Entity struct {
+	Dcid           string
+	Name           string
+	AlternateNames []string
+	Types          []string
+	// Popularity ranks the entities that match a query equally, from the
+	// highest.
+	Popularity float64
+}
+
+// Index is an inverted index of the words of entity names. Entities are
+// numbered from the most popular, so the postings of each word are in rank
+// order.
+type Index struct {
+	entities []*Entity
+	// entityWords are the words of the names of each entity.
+	entityWords [][]string
+	postings    map[string][]int
+	// words are the sorted keys of postings, to find the words of a prefix.
+	words []string
+}
+
+// NewIndex builds the index of entities.
+func NewIndex(entities []*Entity) *Index {
+	sorted := append([]*Entity{}, entities...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Popularity != sorted[j].Popularity {
+			return sorted[i].Popularity > sorted[j].Popularity
+		}
+		return sorted[i].Dcid < sorted[j].Dcid
+	})
+	idx := &Index{
+		entities:    sorted,
+		entityWords: make([][]string, len(sorted)),
+		postings:    map[string][]int{},
+	}
+	for i, e := range sorted {
+		seen := map[string]struct{}{}
+		for _, name := range append([]string{e.Name}, e.AlternateNames...) {
+			for _, word := range tokenize(name) {
+				if _, ok := seen[word]; ok {
+					continue
+				}
+				seen[word] = struct{}{}
+				idx.postings[word] = append(idx.postings[word], i)
+				idx.entityWords[i] = append(idx.entityWords[i], word)
+			}
+		}
+	}
+	for word := range idx.postings {
+		idx.words = append(idx.words, word)
+	}
+	sort.Strings(idx.words)
+	return idx
+}
+
+// Len gets the number of entities in the index.
+func (idx *Index) Len() int {
+	return len(idx.entities)
+}
+
+// splitWords lowercases a string and splits it into words of letters and
+// digits.
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// tokenize splits a string into words with their letters folded to ASCII when
+// possible, like "São Tomé" to "sao" and "tome".
+func tokenize(s string) []string {
+	return splitWords(util.Transliterate(s))
+}
+
+// intersect gets the entities of two postings.
+func intersect(a, b []int) []int {
+	result := []int{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// postingsHeap holds the remaining entities of postings, by their next entity.
+type postingsHeap [][]int
+
+func (h postingsHeap) Len() int            { return len(h) }
+func (h postingsHeap) Less(i, j int) bool  { return h[i][0] < h[j][0] }
+func (h postingsHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *postingsHeap) Push(x interface{}) { *h = append(*h, x.([]int)) }
+func (h *postingsHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// each gets a function that returns the entities of a posting in rank order,
+// until it returns false.
+func each(posting []int) func() (int, bool) {
+	return func() (int, bool) {
+		if len(posting) == 0 {
+			return 0, false
+		}
+		i := posting[0]
+		posting = posting[1:]
+		return i, true
+	}
+}
+
+// merge gets a function that returns the entities of postings in rank order,
+// until it returns false. Entities can be returned more than once.
+func merge(postings [][]int) func() (int, bool) {
+	h := postingsHeap{}
+	for _, p := range postings {
+		if len(p) > 0 {
+			h = append(h, p)
+		}
+	}
+	heap.Init(&h)
+	return func() (int, bool) {
+		if h.Len() == 0 {
+			return 0, false
+		}
+		i := h[0][0]
+		if len(h[0]) == 1 {
+			heap.Pop(&h)
+		} else {
+			h[0] = h[0][1:]
+			heap.Fix(&h, 0)
+		}
+		return i, true
+	}
+}
+
+// typeFilter gets a function that returns the first type of an entity that a
+// request wants, or "" if there is none.
+func typeFilter(in *pb.SearchRequest) func(types []string) string {
+	include := map[string]struct{}{}
+	for _, t := range in.GetTypes() {
+		include[t] = struct{}{}
+	}
+	excludeTypes := in.GetExcludeTypes()
+	if len(include) == 0 && len(excludeTypes) == 0 {
+		excludeTypes = defaultExcludedTypes
+	}
+	exclude := map[string]struct{}{}
+	for _, t := range excludeTypes {
+		exclude[t] = struct{}{}
+	}
+	return func(types []string) string {
+		if len(types) == 0 {
+			types = []string{defaultType}
+		}
+		for _, t := range types {
+			if _, ok := include[t]; len(include) > 0 && !ok {
+				continue
+			}
+			if _, ok := exclude[t]; ok {
+				continue
+			}
+			return t
+		}
+		return ""
+	}
+}
+
+// Search finds the entities with names that have all the words of a query.
+// The last word can be the prefix of a name word, to autocomplete a query.
+//
+// Entities with all the words are ranked first, then those that complete the
+// last word. Each of them is ranked by popularity. Entities are grouped in a
+// section of their first wanted type, and sections are ordered by their best
+// entity.
+func (idx *Index) Search(in *pb.SearchRequest) *pb.SearchResponse {
+	out := &pb.SearchResponse{}
+	tokens := tokenize(in.GetQuery())
+	if len(tokens) == 0 {
+		return out
+	}
+	limit := int(in.GetMaxResults())
+	if limit <= 0 || limit > maxIndexResults {
+		limit = maxIndexResults
+	}
+	wantedType := typeFilter(in)
+
+	// Entities with all the words but the last.
+	var base []int
+	if words := tokens[:len(tokens)-1]; len(words) > 0 {
+		sort.Slice(words, func(i, j int) bool {
+			return len(idx.postings[words[i]]) < len(idx.postings[words[j]])
+		})
+		base = idx.postings[words[0]]
+		for _, w := range words[1:] {
+			base = intersect(base, idx.postings[w])
+		}
+		if len(base) == 0 {
+			return out
+		}
+	}
+	last := tokens[len(tokens)-1]
+	// hasCompletion checks whether an entity has a word that completes the last
+	// word of the query.
+	hasCompletion := func(i int) bool {
+		for _, w := range idx.entityWords[i] {
+			if w != last && strings.HasPrefix(w, last) {
+				return true
+			}
+		}
+		return false
+	}
+
+	sections := map[string]*pb.SearchResultSection{}
+	seen := map[int]struct{}{}
+	count := 0
+	add := func(next func() (int, bool)) {
+		for count < limit {
+			i, ok := next()
+			if !ok {
+				return
+			}
+			if _, ok := seen[i]; ok {
+				continue
+			}
+			seen[i] = struct{}{}
+			e := idx.entities[i]
+			typeName := wantedType(e.Types)
+			if typeName == "" {
+				continue
+			}
+			section, ok := sections[typeName]
+			if !ok {
+				section = &pb.SearchResultSection{TypeName: typeName}
+				sections[typeName] = section
+				out.Section = append(out.Section, section)
+			}
+			section.Entity = append(section.Entity, &pb.SearchEntityResult{Dcid: e.Dcid, Name: e.Name})
+			count++
+		}
+	}
+	if base == nil {
+		// The completions of the last word are merged from their postings.
+		completions := [][]int{}
+		for i := sort.SearchStrings(idx.words, last); i < len(idx.words) && strings.HasPrefix(idx.words[i], last); i++ {
+			if idx.words[i] != last {
+				completions = append(completions, idx.postings[idx.words[i]])
+			}
+		}
+		add(each(idx.postings[last]))
+		add(merge(completions))
+		return out
+	}
+	// The entities with the other words are fewer than those of the completions
+	// of a short last word, so they are checked for the completions instead.
+	add(each(intersect(base, idx.postings[last])))
+	add(func() (int, bool) {
+		for len(base) > 0 {
+			i := base[0]
+			base = base[1:]
+			if hasCompletion(i) {
+				return i, true
+			}
+		}
+		return 0, false
+	})
+	return out
+}
+
+// LoadIndexCSV loads the entities of a CSV file with a header of columns
+// "dcid", "name", "typeOf", "alternateName" and "popularity". Only "dcid" and
+// "name" are required. Entities of several types have them separated by
+// commas, and alternate names are separated by semicolons.
+func LoadIndexCSV(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	reader := csv.NewReader(f)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header of %s: %s", path, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"dcid", "name"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %s in %s", name, path)
+		}
+	}
+	get := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	split := func(s, sep string) []string {
+		result := []string{}
+		for _, v := range strings.Split(s, sep) {
+			if v = strings.TrimSpace(v); v != "" {
+				result = append(result, v)
+			}
+		}
+		return result
+	}
+	entities := []*Entity{}
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		e := &Entity{
+			Dcid:           get(row, "dcid"),
+			Name:           get(row, "name"),
+			Types:          split(get(row, "typeOf"), ","),
+			AlternateNames: split(get(row, "alternateName"), ";"),
+		}
+		if e.Dcid == "" || e.Name == "" {
+			return nil, fmt.Errorf("invalid entity at line %d of %s", line, path)
+		}
+		if p := get(row, "popularity"); p != "" {
+			if e.Popularity, err = strconv.ParseFloat(p, 64); err != nil {
+				return nil, fmt.Errorf("invalid popularity at line %d of %s", line, path)
+			}
+		}
+		entities = append(entities, e)
+	}
+	log.Printf("Built search index of %d entities", len(entities))
+	return NewIndex(entities), nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func section(typeName string, entities ...string) *pb.SearchResultSection {
+	result := &pb.SearchResultSection{TypeName: typeName}
+	for i := 0; i < len(entities); i += 2 {
+		result.Entity = append(result.Entity,
+			&pb.SearchEntityResult{Dcid: entities[i], Name: entities[i+1]})
+	}
+	return result
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := NewIndex([]*Entity{
+		{Dcid: "geoId/06", Name: "California", AlternateNames: []string{"CA", "Calif."},
+			Types: []string{"State"}, Popularity: 100},
+		{Dcid: "geoId/0644000", Name: "Los Angeles", Types: []string{"City"}, Popularity: 90},
+		{Dcid: "geoId/06037", Name: "Los Angeles County", Types: []string{"County"}, Popularity: 80},
+		{Dcid: "geoId/06037101110", Name: "Census Tract 1011.10, Los Angeles County",
+			Types: []string{"CensusTract"}, Popularity: 1},
+		{Dcid: "geoId/0667000", Name: "San Francisco", Types: []string{"City"}, Popularity: 85},
+		{Dcid: "geoId/0668252", Name: "San Mateo", Types: []string{"City"}, Popularity: 50},
+		{Dcid: "geoId/4967440", Name: "Sandy", Types: []string{"City"}, Popularity: 99},
+		{Dcid: "geoId/0609710", Name: "Calexico", Types: []string{"City"}, Popularity: 5},
+		{Dcid: "country/STP", Name: "São Tomé and Príncipe", Types: []string{"Country"}, Popularity: 10},
+	})
+	if idx.Len() != 9 {
+		t.Errorf("Len() = %d, want 9", idx.Len())
+	}
+	for _, c := range []struct {
+		in   *pb.SearchRequest
+		want []*pb.SearchResultSection
+	}{
+		{
+			&pb.SearchRequest{Query: "Los Angeles"},
+			[]*pb.SearchResultSection{
+				section("City", "geoId/0644000", "Los Angeles"),
+				section("County", "geoId/06037", "Los Angeles County"),
+			},
+		},
+		{
+			&pb.SearchRequest{Query: "los angeles", Types: []string{"CensusTract"}},
+			[]*pb.SearchResultSection{
+				section("CensusTract", "geoId/06037101110", "Census Tract 1011.10, Los Angeles County"),
+			},
+		},
+		{
+			&pb.SearchRequest{Query: "los angeles", ExcludeTypes: []string{"City"}},
+			[]*pb.SearchResultSection{
+				section("County", "geoId/06037", "Los Angeles County"),
+				section("CensusTract", "geoId/06037101110", "Census Tract 1011.10, Los Angeles County"),
+			},
+		},
+		{
+			// Entities with the word are ranked above the completions.
+			&pb.SearchRequest{Query: "san"},
+			[]*pb.SearchResultSection{
+				section("City",
+					"geoId/0667000", "San Francisco",
+					"geoId/0668252", "San Mateo",
+					"geoId/4967440", "Sandy"),
+			},
+		},
+		{
+			&pb.SearchRequest{Query: "san", MaxResults: 1},
+			[]*pb.SearchResultSection{
+				section("City", "geoId/0667000", "San Francisco"),
+			},
+		},
+		{
+			// Alternate names.
+			&pb.SearchRequest{Query: "ca"},
+			[]*pb.SearchResultSection{
+				section("State", "geoId/06", "California"),
+				section("City", "geoId/0609710", "Calexico"),
+			},
+		},
+		{
+			&pb.SearchRequest{Query: "sao tome"},
+			[]*pb.SearchResultSection{
+				section("Country", "country/STP", "São Tomé and Príncipe"),
+			},
+		},
+		{
+			// The last word is completed among the entities with the other words.
+			&pb.SearchRequest{Query: "los angeles co"},
+			[]*pb.SearchResultSection{
+				section("County", "geoId/06037", "Los Angeles County"),
+			},
+		},
+		{
+			&pb.SearchRequest{Query: "san fr"},
+			[]*pb.SearchResultSection{
+				section("City", "geoId/0667000", "San Francisco"),
+			},
+		},
+		{
+			&pb.SearchRequest{Query: "los fran"},
+			nil,
+		},
+		{
+			// Regular expression characters are not words.
+			&pb.SearchRequest{Query: ".*("},
+			nil,
+		},
+	} {
+		got := idx.Search(c.in)
+		if diff := cmp.Diff(got.GetSection(), c.want, protocmp.Transform()); diff != "" {
+			t.Errorf("Search(%v) got diff %v", c.in, diff)
+		}
+	}
+}
+
+func TestLoadIndexCSV(t *testing.T) {
+	dir := t.TempDir()
+	for _, c := range []struct {
+		content string
+		wantErr bool
+	}{
+		{
+			"dcid,name,typeOf,alternateName,popularity\n" +
+				"geoId/06,California,\"State,AdministrativeArea1\",CA;Calif.,100\n" +
+				"geoId/0609710,Calexico,City,,\n",
+			false,
+		},
+		{"dcid,typeOf\ngeoId/06,State\n", true},
+		{"dcid,name\ngeoId/06,\n", true},
+		{"dcid,name,popularity\ngeoId/06,California,high\n", true},
+	} {
+		file := path.Join(dir, "entities.csv")
+		if err := ioutil.WriteFile(file, []byte(c.content), 0644); err != nil {
+			t.Fatalf("WriteFile() = %s", err)
+		}
+		idx, err := LoadIndexCSV(file)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("LoadIndexCSV(%s) = nil, want error", c.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("LoadIndexCSV(%s) = %s", c.content, err)
+		}
+		got := idx.Search(&pb.SearchRequest{Query: "ca"})
+		want := []*pb.SearchResultSection{
+			section("State", "geoId/06", "California"),
+			section("City", "geoId/0609710", "Calexico"),
+		}
+		if diff := cmp.Diff(got.GetSection(), want, protocmp.Transform()); diff != "" {
+			t.Errorf("Search() got diff %v", diff)
+		}
+	}
+}
@@ -17,7 +17,7 @@ package search
 import (
 	"context"
 	"fmt"
-	"strings"
+	"regexp"
 
 	"cloud.google.com/go/bigquery"
 
@@ -26,7 +26,8 @@ import (
 	"google.golang.org/api/iterator"
 )
 
-// Search implements API for Mixer.Search.
+// Search implements API for Mixer.Search, with a BigQuery query. It is used
+// when there is no search index.
 func Search(
 	ctx context.Context,
 	in *pb.SearchRequest,
@@ -34,20 +35,37 @@ func Search(
 	tableName string,
 ) (*pb.SearchResponse, error) {
 	result := map[string]*pb.SearchResultSection{}
-	tokens := strings.Split(strings.ToLower(in.GetQuery()), " ")
-	qStr := fmt.Sprintf(
-		"SELECT id, type, extended_name FROM `%s`.Instance "+
-			"WHERE type != \"CensusTract\" and type != \"PowerPlant\""+
-			" and type != \"PowerPlantUnit\""+
-			" and type != \"BiologicalSpecimen\"", tableName)
-	for _, token := range tokens {
-		qStr += fmt.Sprintf(
-			` AND REGEXP_CONTAINS(LOWER(extended_name), r"\b%s\b")`, token)
+	// Names in BigQuery are not folded to ASCII.
+	tokens := splitWords(in.GetQuery())
+	if len(tokens) == 0 {
+		return &pb.SearchResponse{}, nil
+	}
+	qStr := fmt.Sprintf("SELECT id, type, extended_name FROM `%s`.Instance WHERE TRUE", tableName)
+	params := []bigquery.QueryParameter{}
+	if len(in.GetTypes()) > 0 {
+		qStr += " AND type IN UNNEST(@types)"
+		params = append(params, bigquery.QueryParameter{Name: "types", Value: in.GetTypes()})
+	}
+	excludeTypes := in.GetExcludeTypes()
+	if len(in.GetTypes()) == 0 && len(excludeTypes) == 0 {
+		excludeTypes = defaultExcludedTypes
+	}
+	if len(excludeTypes) > 0 {
+		qStr += " AND type NOT IN UNNEST(@exclude_types)"
+		params = append(params, bigquery.QueryParameter{Name: "exclude_types", Value: excludeTypes})
+	}
+	for i, token := range tokens {
+		qStr += fmt.Sprintf(" AND REGEXP_CONTAINS(LOWER(extended_name), @token%d)", i)
+		params = append(params, bigquery.QueryParameter{
+			Name:  fmt.Sprintf("token%d", i),
+			Value: `\b` + regexp.QuoteMeta(token) + `\b`,
+		})
 	}
 	if in.GetMaxResults() > 0 {
 		qStr += fmt.Sprintf(" LIMIT %d", in.GetMaxResults())
 	}
 	q := bqClient.Query(qStr)
+	q.Parameters = params
 	it, err := q.Read(ctx)
 	if err != nil {
 		return nil, err
@@ -18,23 +18,29 @@ import (
 	"context"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"path"
-	"path/filepath"
 	"runtime"
-	"strings"
+	"sync"
+	"time"
 
 	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/bigtable"
 	pubsub "cloud.google.com/go/pubsub"
 	"cloud.google.com/go/storage"
-	"github.com/datacommonsorg/mixer/internal/parser/mcf"
 	dcpubsub "github.com/datacommonsorg/mixer/internal/pubsub"
+	"github.com/datacommonsorg/mixer/internal/server/graphql"
+	"github.com/datacommonsorg/mixer/internal/server/recon"
 	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/server/search"
 	"github.com/datacommonsorg/mixer/internal/server/statvar"
 	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/geo"
 	"github.com/datacommonsorg/mixer/internal/store/memdb"
-	"github.com/datacommonsorg/mixer/internal/translator/solver"
+	"github.com/datacommonsorg/mixer/internal/store/sqldb"
 	"github.com/datacommonsorg/mixer/internal/translator/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Server holds resources for a mixer server
@@ -42,11 +48,55 @@ type Server struct {
 	store    *store.Store
 	metadata *resource.Metadata
 	cache    *resource.Cache
+	// Guards the metadata, which is swapped when the schema mapping is
+	// reloaded, and the mapping status.
+	metadataLock  sync.RWMutex
+	mappingStatus MappingStatus
+	// Index of entity names for recon. Nil if entities are only resolved by ID.
+	nameIndex *recon.NameIndex
+	// Index of place boundaries for recon. Nil if coordinates are resolved with
+	// Bigtable.
+	boundaryIndex *recon.BoundaryIndex
+	// Index of entity names for Search. Nil if Search queries BigQuery.
+	searchIndex *search.Index
+}
+
+// GraphQLHandler creates an HTTP handler serving GraphQL queries on the store
+// of the server.
+func (s *Server) GraphQLHandler() http.Handler {
+	return graphql.NewHandler(s.store)
+}
+
+// SetSQLDb sets the SQL database that serves Sparql queries, in place of
+// BigQuery.
+func (s *Server) SetSQLDb(db sqldb.Executor) {
+	s.store.SQLDb = db
+}
+
+// SetQueryCache sets the cache of recent Sparql query results.
+func (s *Server) SetQueryCache(c *sqldb.Cache) {
+	s.store.QueryCache = c
+}
+
+// SetCentroidIndex sets the index of place centroids to find nearby places.
+func (s *Server) SetCentroidIndex(idx *geo.CentroidIndex) {
+	s.store.Centroids = idx
+}
+
+// LoadSearchIndex builds the index of the entity names of a CSV file to serve
+// Search without BigQuery.
+func (s *Server) LoadSearchIndex(path string) error {
+	searchIndex, err := search.LoadIndexCSV(path)
+	if err != nil {
+		return err
+	}
+	s.searchIndex = searchIndex
+	return nil
 }
 
 func (s *Server) updateBranchTable(ctx context.Context, branchTableName string) {
 	branchTable, err := NewBtTable(
-		ctx, s.metadata.BtProject, s.metadata.BranchBtInstance, branchTableName)
+		ctx, s.getMetadata().BtProject, s.getMetadata().BranchBtInstance, branchTableName)
 	if err != nil {
 		log.Printf("Failed to udpate branch cache Bigtable client: %v", err)
 		return
@@ -73,32 +123,17 @@ func ReadBranchTableName(
 	return string(folder), nil
 }
 
-// NewMetadata initialize the metadata for translator.
+// NewMetadata initialize the metadata for translator. The schema mapping
+// files are read from a local directory or a GCS folder ("gs://bucket/folder").
 func NewMetadata(
 	bqDataset, storeProject, branchInstance, schemaPath string) (*resource.Metadata, error) {
 	_, filename, _, _ := runtime.Caller(0)
-	subTypeMap, err := solver.GetSubTypeMap(
-		path.Join(path.Dir(filename), "../translator/table_types.json"))
-	if err != nil {
-		return nil, err
-	}
-	files, err := ioutil.ReadDir(schemaPath)
-	if err != nil {
-		return nil, err
-	}
-	mappings := []*types.Mapping{}
-	for _, f := range files {
-		if strings.HasSuffix(f.Name(), ".mcf") {
-			mappingStr, err := ioutil.ReadFile(filepath.Join(schemaPath, f.Name()))
-			if err != nil {
-				return nil, err
-			}
-			mapping, err := mcf.ParseMapping(string(mappingStr), bqDataset)
-			if err != nil {
-				return nil, err
-			}
-			mappings = append(mappings, mapping...)
-		}
+	tableTypesPath := path.Join(path.Dir(filename), "../translator/table_types.json")
+	mappings, subTypeMap, errs := loadMappings(
+		context.Background(), bqDataset, schemaPath, tableTypesPath)
+	if len(errs) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid schema mapping: %s %s", errs[0].File, errs[0].Message)
 	}
 	outArcInfo := map[string]map[string][]types.OutArcInfo{}
 	inArcInfo := map[string][]types.InArcInfo{}
@@ -110,6 +145,8 @@ func NewMetadata(
 			Bq:               bqDataset,
 			BtProject:        storeProject,
 			BranchBtInstance: branchInstance,
+			SchemaPath:       schemaPath,
+			TableTypesPath:   tableTypesPath,
 		},
 		nil
 }
@@ -170,11 +207,38 @@ func NewMixerServer(
 	cache *resource.Cache,
 	memDb *memdb.MemDb,
 ) *Server {
-	return &Server{
+	s := &Server{
 		store:    store.NewStore(bqClient, memDb, baseTable, branchTable),
 		metadata: metadata,
 		cache:    cache,
 	}
+	if metadata != nil {
+		now := time.Now()
+		s.mappingStatus = MappingStatus{
+			LoadTime: now, NumMappings: len(metadata.Mappings), CheckTime: now}
+	}
+	return s
+}
+
+// LoadNameIndex builds the index of place names to resolve entities by name.
+func (s *Server) LoadNameIndex(ctx context.Context) error {
+	nameIndex, err := recon.LoadNameIndex(ctx, s.store)
+	if err != nil {
+		return err
+	}
+	s.nameIndex = nameIndex
+	return nil
+}
+
+// LoadBoundaryIndex builds the index of the place boundaries of a directory of
+// GeoJSON files to resolve coordinates locally.
+func (s *Server) LoadBoundaryIndex(dir string) error {
+	boundaryIndex, err := recon.LoadBoundaryIndex(dir)
+	if err != nil {
+		return err
+	}
+	s.boundaryIndex = boundaryIndex
+	return nil
 }
 
 // NewReconServer creates a new recon server instance.
@@ -130,6 +130,8 @@ func BuildStatVarSearchIndex(
 	searchIndex := &resource.SearchIndex{
 		RootTrieNode: &resource.TrieNode{},
 		Ranking:      map[string]*resource.RankingInfo{},
+		Docs:         map[string]*resource.SearchDoc{},
+		DocFreq:      map[string]int{},
 	}
 	ignoredSVG := map[string]string{}
 	// Exclude svg and sv under miscellaneous from the search index
@@ -147,15 +149,14 @@ func BuildStatVarSearchIndex(
 		if _, ok := ignoredSVG[svgID]; ok {
 			continue
 		}
-		tokenString := svgData.AbsoluteName
-		searchIndex.Update(svgID, tokenString, tokenString, true /* isSvg */, synonymMap)
+		searchIndex.Update(svgID, svgData.AbsoluteName, nil, true /* isSvg */, synonymMap)
 		for _, svData := range svgData.ChildStatVars {
 			if _, ok := seenSV[svData.Id]; ok {
 				continue
 			}
 			seenSV[svData.Id] = struct{}{}
-			svTokenString := svData.SearchName
-			searchIndex.Update(svData.Id, svTokenString, svData.DisplayName, false /* isSvg */, synonymMap)
+			descriptions := append([]string{svData.SearchName}, svData.SearchNames...)
+			searchIndex.Update(svData.Id, svData.DisplayName, descriptions, false /* isSvg */, synonymMap)
 		}
 	}
 	return searchIndex
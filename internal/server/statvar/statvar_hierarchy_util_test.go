@@ -131,6 +131,19 @@ func TestBuildSearchIndex(t *testing.T) {
 		SvgIds: nil,
 		SvIds:  nil,
 	}
+	tokenSV := resource.TrieNode{
+		ChildrenNodes: map[rune]*resource.TrieNode{
+			'1': {SvIds: map[string]struct{}{"sv_1_1": {}}},
+			'2': {SvIds: map[string]struct{}{"sv_1_2": {}}},
+			'3': {SvIds: map[string]struct{}{"sv_3": {}}},
+			'4': {SvIds: map[string]struct{}{"sv3": {}}},
+		},
+	}
+	tokenS := resource.TrieNode{
+		ChildrenNodes: map[rune]*resource.TrieNode{
+			'v': &tokenSV,
+		},
+	}
 	tokenA := resource.TrieNode{
 		ChildrenNodes: map[rune]*resource.TrieNode{
 			'b': &tokenB2,
@@ -185,6 +198,7 @@ func TestBuildSearchIndex(t *testing.T) {
 						'a': &tokenA,
 						'z': &tokenZ,
 						'b': &tokenB1,
+						's': &tokenS,
 					},
 					SvgIds: nil,
 					SvIds:  nil,
@@ -215,6 +229,61 @@ func TestBuildSearchIndex(t *testing.T) {
 						RankingName: "sv4",
 					},
 				},
+				Docs: map[string]*resource.SearchDoc{
+					"group_1": {
+						TermFreq: [resource.NumSearchFields]map[string]int{
+							{"ab1": 1, "zdx": 1},
+							{},
+							{},
+						},
+						Length: [resource.NumSearchFields]int{2, 0, 0},
+					},
+					"sv_1_1": {
+						TermFreq: [resource.NumSearchFields]map[string]int{
+							{"sv1": 1},
+							{"ab1": 1, "ac3": 1},
+							{},
+						},
+						Length: [resource.NumSearchFields]int{1, 2, 0},
+					},
+					"sv_1_2": {
+						TermFreq: [resource.NumSearchFields]map[string]int{
+							{"sv2": 1},
+							{"ac3": 1, "bd": 1},
+							{},
+						},
+						Length: [resource.NumSearchFields]int{1, 2, 0},
+					},
+					"group_3_1": {
+						TermFreq: [resource.NumSearchFields]map[string]int{
+							{"zdx": 1, "bd": 1},
+							{},
+							{},
+						},
+						Length: [resource.NumSearchFields]int{2, 0, 0},
+					},
+					"sv_3": {
+						TermFreq: [resource.NumSearchFields]map[string]int{
+							{"sv3": 1},
+							{"zdx": 1},
+							{},
+						},
+						Length: [resource.NumSearchFields]int{1, 1, 0},
+					},
+					"sv3": {
+						TermFreq: [resource.NumSearchFields]map[string]int{
+							{"sv4": 1},
+							{"bd": 1},
+							{},
+						},
+						Length: [resource.NumSearchFields]int{1, 1, 0},
+					},
+				},
+				DocFreq: map[string]int{
+					"ab1": 2, "ac3": 2, "bd": 3, "zdx": 3,
+					"sv1": 1, "sv2": 1, "sv3": 1, "sv4": 1,
+				},
+				TotalLength: [resource.NumSearchFields]int{8, 6, 0},
 			},
 		},
 	} {
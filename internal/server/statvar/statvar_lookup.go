@@ -0,0 +1,247 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statvar
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/server/model"
+	"github.com/datacommonsorg/mixer/internal/server/node"
+	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Maximum number of stat vars of the hierarchy to check for superset matches
+	// of a lookup.
+	maxLookupCandidates  = 10000
+	constraintProperties = "constraintProperties"
+)
+
+// svgConstraints gets the population type and the constraint properties of an
+// auto-generated stat var group, like "Person" and {"age", "gender"} for
+// "dc/g/Person_Age-Years16Onwards_Gender". Constraint values are not parsed, as
+// they are not always dcids in the group IDs.
+func svgConstraints(svg string) (string, map[string]struct{}, bool) {
+	if !strings.HasPrefix(svg, autoGenSvgIDPrefix) {
+		return "", nil, false
+	}
+	pieces := strings.Split(strings.TrimPrefix(svg, autoGenSvgIDPrefix), svgDelimiter)
+	props := map[string]struct{}{}
+	for _, piece := range pieces[1:] {
+		prop := strings.SplitN(piece, "-", 2)[0]
+		if prop == "" {
+			return "", nil, false
+		}
+		props[strings.ToLower(prop[:1])+prop[1:]] = struct{}{}
+	}
+	return pieces[0], props, true
+}
+
+// lookupCandidates gets the stat vars of the auto-generated stat var groups of
+// a population type that have the constraint properties, with their display
+// names.
+//
+// Without constraints, only the group of the population type itself, like
+// "dc/g/Person", is used. Otherwise the groups with exactly the constraint
+// properties are used, then those with more properties, from the fewest other
+// properties, as long as there are at most maxLookupCandidates stat vars.
+func lookupCandidates(
+	populationType string,
+	constraints map[string]string,
+	svgInfo map[string]*pb.StatVarGroupNode,
+) map[string]string {
+	// Groups keyed by their number of constraint properties not requested.
+	groups := map[int][]*pb.StatVarGroupNode{}
+	for svg, svgData := range svgInfo {
+		svgPopulationType, svgProps, ok := svgConstraints(svg)
+		if !ok || (populationType != "" && svgPopulationType != populationType) {
+			continue
+		}
+		if len(constraints) == 0 && len(svgProps) > 0 {
+			continue
+		}
+		hasProps := true
+		for prop := range constraints {
+			if _, ok := svgProps[prop]; !ok {
+				hasProps = false
+				break
+			}
+		}
+		if !hasProps {
+			continue
+		}
+		numOtherProps := len(svgProps) - len(constraints)
+		groups[numOtherProps] = append(groups[numOtherProps], svgData)
+	}
+	numOtherProps := []int{}
+	for n := range groups {
+		numOtherProps = append(numOtherProps, n)
+	}
+	sort.Ints(numOtherProps)
+
+	result := map[string]string{}
+	for _, n := range numOtherProps {
+		svs := map[string]string{}
+		for _, svgData := range groups[n] {
+			for _, sv := range svgData.ChildStatVars {
+				if _, ok := result[sv.Id]; !ok {
+					svs[sv.Id] = sv.DisplayName
+				}
+			}
+		}
+		if n > 0 && len(result)+len(svs) > maxLookupCandidates {
+			break
+		}
+		for sv, name := range svs {
+			result[sv] = name
+		}
+	}
+	return result
+}
+
+// nodeValue gets the dcid of a reference node, or the value of a literal.
+func nodeValue(n *model.Node) string {
+	if n.Dcid != "" {
+		return n.Dcid
+	}
+	return n.Value
+}
+
+// hasValue checks whether a stat var has a value of a property, or any value
+// if it is empty.
+func hasValue(values map[string][]*model.Node, sv, value string) bool {
+	nodes := values[sv]
+	if value == "" {
+		return len(nodes) > 0
+	}
+	for _, n := range nodes {
+		if nodeValue(n) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupStatVar implements API for Mixer.LookupStatVar.
+//
+// The candidate stat vars are those of the auto-generated stat var groups that
+// have the population type and the constraint properties, see lookupCandidates.
+// Their definitions are then read to match the request, so superset matches
+// with many other constraint properties may be left out.
+func LookupStatVar(
+	ctx context.Context,
+	in *pb.LookupStatVarRequest,
+	store *store.Store,
+	cache *resource.Cache,
+) (*pb.LookupStatVarResponse, error) {
+	if in.GetPopulationType() == "" && len(in.GetConstraints()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Missing required argument: population_type or constraints")
+	}
+	constraints := map[string]string{}
+	for prop, value := range in.GetConstraints() {
+		if prop == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "Empty constraint property")
+		}
+		constraints[prop] = strings.TrimPrefix(value, "dcid:")
+	}
+	candidates := lookupCandidates(in.GetPopulationType(), constraints, cache.SvgInfo)
+
+	// Filter the candidates by each property, reading only the remaining ones.
+	svs := []string{}
+	for sv := range candidates {
+		svs = append(svs, sv)
+	}
+	filter := func(prop, value string) error {
+		if len(svs) == 0 {
+			return nil
+		}
+		values, err := node.GetPropertyValuesHelper(ctx, store, svs, prop, true)
+		if err != nil {
+			return err
+		}
+		kept := []string{}
+		for _, sv := range svs {
+			if hasValue(values, sv, value) {
+				kept = append(kept, sv)
+			}
+		}
+		svs = kept
+		return nil
+	}
+	for prop, value := range map[string]string{
+		"populationType":   in.GetPopulationType(),
+		"measuredProperty": in.GetMeasuredProperty(),
+		"statType":         in.GetStatType(),
+	} {
+		if value == "" {
+			continue
+		}
+		if err := filter(prop, value); err != nil {
+			return nil, err
+		}
+	}
+	for prop, value := range constraints {
+		if err := filter(prop, value); err != nil {
+			return nil, err
+		}
+	}
+	svProps := map[string][]*model.Node{}
+	if len(svs) > 0 {
+		var err error
+		svProps, err = node.GetPropertyValuesHelper(ctx, store, svs, constraintProperties, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &pb.LookupStatVarResponse{
+		ExactMatches:    []*pb.EntityInfo{},
+		SupersetMatches: []*pb.EntityInfo{},
+	}
+	// Number of constraint properties of each stat var that are not requested.
+	numOtherProps := map[string]int{}
+	for _, sv := range svs {
+		for _, n := range svProps[sv] {
+			if _, ok := constraints[nodeValue(n)]; !ok {
+				numOtherProps[sv]++
+			}
+		}
+		info := &pb.EntityInfo{Dcid: sv, Name: candidates[sv]}
+		if numOtherProps[sv] == 0 {
+			result.ExactMatches = append(result.ExactMatches, info)
+		} else {
+			result.SupersetMatches = append(result.SupersetMatches, info)
+		}
+	}
+	sort.Slice(result.ExactMatches, func(i, j int) bool {
+		return result.ExactMatches[i].Dcid < result.ExactMatches[j].Dcid
+	})
+	sort.Slice(result.SupersetMatches, func(i, j int) bool {
+		ni := numOtherProps[result.SupersetMatches[i].Dcid]
+		nj := numOtherProps[result.SupersetMatches[j].Dcid]
+		if ni != nj {
+			return ni < nj
+		}
+		return result.SupersetMatches[i].Dcid < result.SupersetMatches[j].Dcid
+	})
+	return result, nil
+}
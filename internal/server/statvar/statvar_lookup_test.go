@@ -0,0 +1,223 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statvar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestSvgConstraints(t *testing.T) {
+	for _, c := range []struct {
+		svg            string
+		populationType string
+		props          map[string]struct{}
+		ok             bool
+	}{
+		{"dc/g/Person", "Person", map[string]struct{}{}, true},
+		{
+			"dc/g/Person_Age-Years16Onwards_EmploymentStatus",
+			"Person",
+			map[string]struct{}{"age": {}, "employmentStatus": {}},
+			true,
+		},
+		{"dc/g/Person_-Female", "", nil, false},
+		{"Custom_Group", "", nil, false},
+	} {
+		populationType, props, ok := svgConstraints(c.svg)
+		if populationType != c.populationType || ok != c.ok {
+			t.Errorf("svgConstraints(%s) = %s, %v, want %s, %v",
+				c.svg, populationType, ok, c.populationType, c.ok)
+		}
+		if diff := cmp.Diff(props, c.props); diff != "" {
+			t.Errorf("svgConstraints(%s) got diff %v", c.svg, diff)
+		}
+	}
+}
+
+func TestLookupStatVar(t *testing.T) {
+	ctx := context.Background()
+	data := map[string]string{}
+	for sv, pvs := range map[string]map[string][]string{
+		"Count_Person": {
+			"populationType":   {"Person"},
+			"measuredProperty": {"count"},
+			"statType":         {"measuredValue"},
+		},
+		"Count_Person_Female": {
+			"populationType":       {"Person"},
+			"measuredProperty":     {"count"},
+			"statType":             {"measuredValue"},
+			"constraintProperties": {"gender"},
+			"gender":               {"Female"},
+		},
+		"Count_Person_Male": {
+			"populationType":       {"Person"},
+			"measuredProperty":     {"count"},
+			"statType":             {"measuredValue"},
+			"constraintProperties": {"gender"},
+			"gender":               {"Male"},
+		},
+		"Count_Person_25To34Years_Female": {
+			"populationType":       {"Person"},
+			"measuredProperty":     {"count"},
+			"statType":             {"measuredValue"},
+			"constraintProperties": {"age", "gender"},
+			"age":                  {"Years25To34"},
+			"gender":               {"Female"},
+		},
+		"Median_Age_Person_Female": {
+			"populationType":       {"Person"},
+			"measuredProperty":     {"age"},
+			"statType":             {"medianValue"},
+			"constraintProperties": {"gender"},
+			"gender":               {"Female"},
+		},
+	} {
+		for prop, values := range pvs {
+			entities := []string{}
+			for _, v := range values {
+				entities = append(entities, fmt.Sprintf(`{"dcid": %q}`, v))
+			}
+			value := fmt.Sprintf(`{"entities": [%s]}`, strings.Join(entities, ", "))
+			tableValue, err := util.ZipAndEncode([]byte(value))
+			if err != nil {
+				t.Fatalf("util.ZipAndEncode(%s) = %s", value, err)
+			}
+			data[fmt.Sprintf("%s%s^%s", bigtable.BtOutPropValPrefix, sv, prop)] = tableValue
+		}
+	}
+	btTable, err := bigtable.SetupBigtable(ctx, data)
+	if err != nil {
+		t.Fatalf("SetupBigtable() = %s", err)
+	}
+	s := store.NewStore(nil, nil, btTable, nil)
+	cache := &resource.Cache{
+		SvgInfo: map[string]*pb.StatVarGroupNode{
+			"dc/g/Demographics": {
+				ChildStatVars: []*pb.StatVarGroupNode_ChildSV{
+					{Id: "Count_Person", DisplayName: "Population"},
+				},
+			},
+			"dc/g/Person": {
+				ChildStatVars: []*pb.StatVarGroupNode_ChildSV{
+					{Id: "Count_Person", DisplayName: "Population"},
+				},
+			},
+			"dc/g/Person_Gender": {
+				ChildStatVars: []*pb.StatVarGroupNode_ChildSV{
+					{Id: "Count_Person_Female", DisplayName: "Female Population"},
+					{Id: "Count_Person_Male", DisplayName: "Male Population"},
+				},
+			},
+			"dc/g/Person_Gender-Female": {
+				ChildStatVars: []*pb.StatVarGroupNode_ChildSV{
+					{Id: "Count_Person_Female", DisplayName: "Female Population"},
+					{Id: "Median_Age_Person_Female", DisplayName: "Median Age of Female Population"},
+				},
+			},
+			"dc/g/Person_Age_Gender": {
+				ChildStatVars: []*pb.StatVarGroupNode_ChildSV{
+					{Id: "Count_Person_25To34Years_Female", DisplayName: "Female Population Aged 25 To 34"},
+				},
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		in   *pb.LookupStatVarRequest
+		want *pb.LookupStatVarResponse
+	}{
+		{
+			&pb.LookupStatVarRequest{
+				PopulationType:   "Person",
+				MeasuredProperty: "count",
+			},
+			&pb.LookupStatVarResponse{
+				ExactMatches: []*pb.EntityInfo{
+					{Dcid: "Count_Person", Name: "Population"},
+				},
+				SupersetMatches: []*pb.EntityInfo{},
+			},
+		},
+		{
+			&pb.LookupStatVarRequest{
+				PopulationType:   "Person",
+				MeasuredProperty: "count",
+				Constraints:      map[string]string{"gender": "dcid:Female"},
+			},
+			&pb.LookupStatVarResponse{
+				ExactMatches: []*pb.EntityInfo{
+					{Dcid: "Count_Person_Female", Name: "Female Population"},
+				},
+				SupersetMatches: []*pb.EntityInfo{
+					{Dcid: "Count_Person_25To34Years_Female", Name: "Female Population Aged 25 To 34"},
+				},
+			},
+		},
+		{
+			&pb.LookupStatVarRequest{
+				PopulationType: "Person",
+				StatType:       "measuredValue",
+				Constraints:    map[string]string{"gender": ""},
+			},
+			&pb.LookupStatVarResponse{
+				ExactMatches: []*pb.EntityInfo{
+					{Dcid: "Count_Person_Female", Name: "Female Population"},
+					{Dcid: "Count_Person_Male", Name: "Male Population"},
+				},
+				SupersetMatches: []*pb.EntityInfo{
+					{Dcid: "Count_Person_25To34Years_Female", Name: "Female Population Aged 25 To 34"},
+				},
+			},
+		},
+		{
+			&pb.LookupStatVarRequest{
+				Constraints: map[string]string{"age": "Years25To34", "gender": "Female"},
+			},
+			&pb.LookupStatVarResponse{
+				ExactMatches: []*pb.EntityInfo{
+					{Dcid: "Count_Person_25To34Years_Female", Name: "Female Population Aged 25 To 34"},
+				},
+				SupersetMatches: []*pb.EntityInfo{},
+			},
+		},
+	} {
+		got, err := LookupStatVar(ctx, c.in, s, cache)
+		if err != nil {
+			t.Fatalf("LookupStatVar(%v) = %s", c.in, err)
+		}
+		if diff := cmp.Diff(got, c.want, protocmp.Transform()); diff != "" {
+			t.Errorf("LookupStatVar(%v) got diff %v", c.in, diff)
+		}
+	}
+
+	_, err = LookupStatVar(ctx, &pb.LookupStatVarRequest{MeasuredProperty: "count"}, s, cache)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("LookupStatVar() without population type = %v, want InvalidArgument", err)
+	}
+}
@@ -16,19 +16,72 @@ package statvar
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math"
 	"sort"
-	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	pb "github.com/datacommonsorg/mixer/internal/proto"
 	"github.com/datacommonsorg/mixer/internal/server/resource"
 	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	maxFilteredIds = 3000 // Twice of maxResult to give buffer for place filter.
 	maxResult      = 1000
+	// BM25 term frequency saturation and length normalization.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// Weight of a term that a query token is a prefix of, relative to a term
+	// that is the token.
+	prefixMatchWeight = 0.7
+	// Weight of a term for each edit from a query token.
+	editMatchWeight = 0.5
 )
 
+// Weight of the terms in each field of a stat var (group).
+var fieldWeights = [resource.NumSearchFields]float64{
+	resource.NameField:        3,
+	resource.DescriptionField: 1,
+	resource.SynonymField:     0.5,
+}
+
+// searchCursor holds the state encoded in a page token.
+type searchCursor struct {
+	// Query and Blocklist are those of the request, and Places is the hash of
+	// the places of the request. They are used to reject tokens that are
+	// replayed against a different request.
+	Query     string `json:"q"`
+	Places    string `json:"p,omitempty"`
+	Blocklist bool   `json:"b,omitempty"`
+	Offset    int    `json:"o"`
+}
+
+func encodeSearchPageToken(cursor *searchCursor) (string, error) {
+	jsonRaw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(jsonRaw), nil
+}
+
+func decodeSearchPageToken(token string) (*searchCursor, error) {
+	jsonRaw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+	}
+	cursor := &searchCursor{}
+	if err := json.Unmarshal(jsonRaw, cursor); err != nil || cursor.Offset < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+	}
+	return cursor, nil
+}
+
 // SearchStatVar implements API for Mixer.SearchStatVar.
 func SearchStatVar(
 	ctx context.Context, in *pb.SearchStatVarRequest, store *store.Store,
@@ -39,6 +92,30 @@ func SearchStatVar(
 	query := in.GetQuery()
 	places := in.GetPlaces()
 	enableBlocklist := in.GetEnableBlocklist()
+	pageSize := int(in.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid page_size: %d", pageSize)
+	}
+	if pageSize == 0 || pageSize > maxResult {
+		pageSize = maxResult
+	}
+	next := &searchCursor{Query: query, Blocklist: enableBlocklist}
+	if len(places) > 0 {
+		next.Places = util.HashStrings(places)
+	}
+	offset := 0
+	if token := in.GetPageToken(); token != "" {
+		cursor, err := decodeSearchPageToken(token)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Query != next.Query ||
+			cursor.Places != next.Places ||
+			cursor.Blocklist != next.Blocklist {
+			return nil, status.Errorf(codes.InvalidArgument, "Page token does not match the request")
+		}
+		offset = cursor.Offset
+	}
 
 	result := &pb.SearchStatVarResponse{
 		StatVars:      []*pb.EntityInfo{},
@@ -47,13 +124,12 @@ func SearchStatVar(
 	if query == "" {
 		return result, nil
 	}
-	tokens := strings.Fields(
-		strings.Replace(strings.ToLower(query), ",", " ", -1))
+	tokens := resource.Tokenize(query)
 	searchIndex := cache.SvgSearchIndex
 	if enableBlocklist {
 		searchIndex = cache.BlocklistedSvgSearchIndex
 	}
-	svList, svgList := searchTokens(tokens, searchIndex)
+	svList, svgList, matches := searchTokens(tokens, searchIndex)
 
 	// Filter the stat var and stat var group by places.
 	if len(places) > 0 {
@@ -78,18 +154,38 @@ func SearchStatVar(
 		svList = filter(svList, statVarCount, len(places))
 		svgList = filter(svgList, statVarCount, len(places))
 	}
-	// TODO(shifucun): return the total number of result for client to consume.
-	if len(svList) > maxResult {
-		svList = svList[0:maxResult]
+	result.TotalStatVars = int32(len(svList))
+	result.TotalStatVarGroups = int32(len(svgList))
+	result.StatVars = page(svList, offset, pageSize)
+	result.StatVarGroups = page(svgList, offset, pageSize)
+	if offset+pageSize < len(svList) || offset+pageSize < len(svgList) {
+		next.Offset = offset + pageSize
+		token, err := encodeSearchPageToken(next)
+		if err != nil {
+			return nil, err
+		}
+		result.NextPageToken = token
 	}
-	if len(svgList) > maxResult {
-		svgList = svgList[0:maxResult]
+	result.Highlights = map[string]*pb.SearchStatVarResponse_Highlight{}
+	for _, item := range append(result.StatVars, result.StatVarGroups...) {
+		if spans := highlight(item.Name, matches[item.Dcid]); len(spans) > 0 {
+			result.Highlights[item.Dcid] = &pb.SearchStatVarResponse_Highlight{Spans: spans}
+		}
 	}
-	result.StatVars = svList
-	result.StatVarGroups = svgList
 	return result, nil
 }
 
+// page gets the nodes of a page of the given size starting at offset.
+func page(nodes []*pb.EntityInfo, offset, size int) []*pb.EntityInfo {
+	if offset >= len(nodes) {
+		return []*pb.EntityInfo{}
+	}
+	if offset+size < len(nodes) {
+		return nodes[offset : offset+size]
+	}
+	return nodes[offset:]
+}
+
 func filter(
 	nodes []*pb.EntityInfo,
 	countMap map[string]map[string]int32,
@@ -103,99 +199,224 @@ func filter(
 	return result
 }
 
-func searchTokens(
-	tokens []string, index *resource.SearchIndex) ([]*pb.EntityInfo, []*pb.EntityInfo) {
-	svCount := map[string]int{}
-	svgCount := map[string]int{}
+// termMatch is an indexed term that matches a query token.
+type termMatch struct {
+	node *resource.TrieNode
+	// weight is the weight of the term relative to the token.
+	weight float64
+	// length is the number of leading characters of the term that match the
+	// token.
+	length int
+}
 
-	// Get all matching sv and svg from the trie for each token
-	for _, token := range tokens {
-		currNode := index.RootTrieNode
-		// Traverse the Trie following the order of the characters in the token
-		// until we either reach the end of the token or we reach a node that
-		// doesn't have the next character as a child node.
-		// eg. Trie: Root - a
-		//                 / \
-		// 				       b     c
-		//
-		//			If token is "ab", currNode will go Root -> a -> b
-		// 			If token is "bc", currNode will go Root -> nil
-		//			If token is "abc", currNode will go Root -> a -> b -> nil
-		for _, c := range token {
-			if _, ok := currNode.ChildrenNodes[c]; !ok {
-				currNode = nil
-				break
+// maxEdits gets the maximum number of edits between a query token and the
+// terms it matches. Short tokens must match exactly.
+func maxEdits(token string) int {
+	switch n := utf8.RuneCountInString(token); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// matchToken gets the terms of a trie that match a query token. A term matches
+// if it is within maxEdits of the token, or if it starts with such a prefix.
+//
+// The trie is walked while the edit distance between the token and the path to
+// a node can be within maxEdits, keeping a row of the Levenshtein table for
+// the path.
+func matchToken(token string, root *resource.TrieNode) map[string]*termMatch {
+	query := []rune(token)
+	k := maxEdits(token)
+	result := map[string]*termMatch{}
+	add := func(term string, node *resource.TrieNode, weight float64, length int) {
+		if len(node.SvIds) == 0 && len(node.SvgIds) == 0 {
+			return
+		}
+		if m, ok := result[term]; ok && m.weight >= weight {
+			return
+		}
+		result[term] = &termMatch{node: node, weight: weight, length: length}
+	}
+	// row[i] is the edit distance between the first i runes of the token and
+	// the path. prefixDist is the smallest distance between the token and a
+	// prefix of the path, which is prefixLen long.
+	var walk func(node *resource.TrieNode, path []rune, row []int, prefixDist, prefixLen int)
+	walk = func(node *resource.TrieNode, path []rune, row []int, prefixDist, prefixLen int) {
+		if d := row[len(query)]; d < prefixDist {
+			prefixDist, prefixLen = d, len(path)
+		}
+		if d := row[len(query)]; d <= k {
+			add(string(path), node, math.Pow(editMatchWeight, float64(d)), len(path))
+		}
+		if prefixDist <= k {
+			add(string(path), node,
+				prefixMatchWeight*math.Pow(editMatchWeight, float64(prefixDist)), prefixLen)
+		}
+		for c, child := range node.ChildrenNodes {
+			next := make([]int, len(row))
+			next[0] = row[0] + 1
+			minDist := next[0]
+			for i := 1; i < len(row); i++ {
+				cost := 1
+				if query[i-1] == c {
+					cost = 0
+				}
+				next[i] = minInt(row[i-1]+cost, minInt(row[i]+1, next[i-1]+1))
+				minDist = minInt(minDist, next[i])
+			}
+			if minDist <= k || prefixDist <= k {
+				walk(child, append(path[:len(path):len(path)], c), next, prefixDist, prefixLen)
 			}
-			currNode = currNode.ChildrenNodes[c]
 		}
-		// The token is not a prefix or word in the Trie.
-		if currNode == nil {
+	}
+	row := make([]int, len(query)+1)
+	for i := range row {
+		row[i] = i
+	}
+	walk(root, nil, row, math.MaxInt32, 0)
+	return result
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// termScore gets the BM25F score of a term for a stat var (group). Fields are
+// weighted by fieldWeights and normalized by their average length.
+func termScore(index *resource.SearchIndex, term string, id string) float64 {
+	doc, ok := index.Docs[id]
+	if !ok {
+		return 0
+	}
+	n := float64(len(index.Docs))
+	df := float64(index.DocFreq[term])
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+	tf := 0.0
+	for f, w := range fieldWeights {
+		if doc.Length[f] == 0 {
 			continue
 		}
-		// Traverse the entire subTrie rooted at the node corresponding to the
-		// last character in the token and add all SvIds and SvgIds seen.
-		nodesToCheck := []resource.TrieNode{*currNode}
-		for len(nodesToCheck) > 0 {
-			node := nodesToCheck[0]
-			nodesToCheck = nodesToCheck[1:]
-			for _, node := range node.ChildrenNodes {
-				nodesToCheck = append(nodesToCheck, *node)
+		avgLength := float64(index.TotalLength[f]) / n
+		tf += w * float64(doc.TermFreq[f][term]) /
+			(1 - bm25B + bm25B*float64(doc.Length[f])/avgLength)
+	}
+	return idf * tf * (bm25K1 + 1) / (bm25K1 + tf)
+}
+
+// searchTokens gets the stat vars and stat var groups that match all the
+// tokens, from the most relevant, and the terms each of them matches.
+func searchTokens(
+	tokens []string, index *resource.SearchIndex) (
+	[]*pb.EntityInfo, []*pb.EntityInfo, map[string]map[string]*termMatch,
+) {
+	svCount := map[string]int{}
+	svgCount := map[string]int{}
+	scores := map[string]float64{}
+	matches := map[string]map[string]*termMatch{}
+
+	for _, token := range tokens {
+		// Score of the best term of the token for each sv and svg.
+		tokenScores := map[string]float64{}
+		svMatched := map[string]struct{}{}
+		svgMatched := map[string]struct{}{}
+		for term, m := range matchToken(token, index.RootTrieNode) {
+			match := func(id string) {
+				if matches[id] == nil {
+					matches[id] = map[string]*termMatch{}
+				}
+				if prev, ok := matches[id][term]; !ok || prev.length < m.length {
+					matches[id][term] = m
+				}
+				score := m.weight * termScore(index, term, id)
+				if prev, ok := tokenScores[id]; !ok || score > prev {
+					tokenScores[id] = score
+				}
 			}
-			for sv := range node.SvIds {
-				svCount[sv]++
+			for sv := range m.node.SvIds {
+				svMatched[sv] = struct{}{}
+				match(sv)
 			}
-			for svg := range node.SvgIds {
-				svgCount[svg]++
+			for svg := range m.node.SvgIds {
+				svgMatched[svg] = struct{}{}
+				match(svg)
 			}
 		}
+		for sv := range svMatched {
+			svCount[sv]++
+		}
+		for svg := range svgMatched {
+			svgCount[svg]++
+		}
+		for id, score := range tokenScores {
+			scores[id] += score
+		}
 	}
 
 	// Only select sv and svg that matches all the tokens
-	svList := []*pb.EntityInfo{}
-	for sv, c := range svCount {
-		if c == len(tokens) {
-			svList = append(svList, &pb.EntityInfo{
-				Dcid: sv,
-				Name: index.Ranking[sv].RankingName,
-			})
-		}
-	}
-
-	// Sort stat vars by number of PV; If two stat vars have same number of PV,
-	// then order by the stat var (group) name.
-	sort.SliceStable(svList, func(i, j int) bool {
-		ranking := index.Ranking
-		ri := ranking[svList[i].Dcid]
-		rj := ranking[svList[j].Dcid]
-		if ri.ApproxNumPv == rj.ApproxNumPv {
-			if ri.RankingName == rj.RankingName {
-				return svList[i].Dcid < svList[j].Dcid
+	selectMatched := func(count map[string]int) []*pb.EntityInfo {
+		result := []*pb.EntityInfo{}
+		for id, c := range count {
+			if c == len(tokens) {
+				result = append(result, &pb.EntityInfo{
+					Dcid: id,
+					Name: index.Ranking[id].RankingName,
+				})
+			}
+		}
+		// Sort by relevance; If two have the same score, then order by number of
+		// PV and then by the stat var (group) name.
+		sort.Slice(result, func(i, j int) bool {
+			si, sj := scores[result[i].Dcid], scores[result[j].Dcid]
+			if si != sj {
+				return si > sj
 			}
-			return ri.RankingName < rj.RankingName
-		}
-		return ri.ApproxNumPv < rj.ApproxNumPv
-	})
-
-	svgList := []*pb.EntityInfo{}
-	for svg, c := range svgCount {
-		if c == len(tokens) {
-			svgList = append(svgList, &pb.EntityInfo{
-				Dcid: svg,
-				Name: index.Ranking[svg].RankingName,
-			})
-		}
-	}
-	sort.SliceStable(svgList, func(i, j int) bool {
-		ranking := index.Ranking
-		ri := ranking[svgList[i].Dcid]
-		rj := ranking[svgList[j].Dcid]
-		if ri.ApproxNumPv == rj.ApproxNumPv {
-			if ri.RankingName == rj.RankingName {
-				return svgList[i].Dcid < svgList[j].Dcid
+			ri := index.Ranking[result[i].Dcid]
+			rj := index.Ranking[result[j].Dcid]
+			if ri.ApproxNumPv == rj.ApproxNumPv {
+				if ri.RankingName == rj.RankingName {
+					return result[i].Dcid < result[j].Dcid
+				}
+				return ri.RankingName < rj.RankingName
 			}
-			return ri.RankingName < rj.RankingName
+			return ri.ApproxNumPv < rj.ApproxNumPv
+		})
+		return result
+	}
+	return selectMatched(svCount), selectMatched(svgCount), matches
+}
+
+// highlight gets the spans of a name that match the terms of a search result.
+// Words are matched as they are tokenized in the index.
+func highlight(name string, matches map[string]*termMatch) []*pb.SearchStatVarResponse_Span {
+	spans := []*pb.SearchStatVarResponse_Span{}
+	runes := []rune(name)
+	isSeparator := func(r rune) bool { return unicode.IsSpace(r) || r == ',' }
+	for start := 0; start < len(runes); {
+		if isSeparator(runes[start]) {
+			start++
+			continue
 		}
-		return ri.ApproxNumPv < rj.ApproxNumPv
-	})
-	return svList, svgList
+		end := start
+		for end < len(runes) && !isSeparator(runes[end]) {
+			end++
+		}
+		word := resource.Tokenize(string(runes[start:end]))
+		if len(word) == 1 {
+			if m, ok := matches[word[0]]; ok {
+				spans = append(spans, &pb.SearchStatVarResponse_Span{
+					Start: int32(start),
+					End:   int32(start + minInt(m.length, end-start)),
+				})
+			}
+		}
+		start = end
+	}
+	return spans
 }
@@ -15,6 +15,7 @@
 package statvar
 
 import (
+	"context"
 	"testing"
 
 	pb "github.com/datacommonsorg/mixer/internal/proto"
@@ -163,7 +164,7 @@ func TestSearchTokens(t *testing.T) {
 			wantSvg: []*pb.EntityInfo{},
 		},
 	} {
-		sv, svg := searchTokens(c.tokens, c.index)
+		sv, svg, _ := searchTokens(c.tokens, c.index)
 		if diff := cmp.Diff(sv, c.wantSv, protocmp.Transform()); diff != "" {
 			t.Errorf("Stat var list got diff %v", diff)
 		}
@@ -172,3 +173,179 @@ func TestSearchTokens(t *testing.T) {
 		}
 	}
 }
+
+func TestSearchStatVar(t *testing.T) {
+	index := BuildStatVarSearchIndex(map[string]*pb.StatVarGroupNode{
+		"dc/g/Demographics": {
+			AbsoluteName: "Demographics",
+			ChildStatVars: []*pb.StatVarGroupNode_ChildSV{
+				{
+					Id:          "Count_Person",
+					SearchName:  "Count of Person",
+					DisplayName: "Total Population",
+				},
+				{
+					Id:          "Count_Person_Female",
+					SearchName:  "Count of Person: Female",
+					DisplayName: "Female Population",
+				},
+				{
+					Id:          "Median_Age_Person_Female",
+					SearchName:  "Median Age of Person: Female",
+					DisplayName: "Median Age of Female Population",
+				},
+				{
+					Id:          "Count_Household",
+					SearchName:  "Count of Household",
+					DisplayName: "Number of Households",
+				},
+			},
+		},
+	}, false)
+	cache := &resource.Cache{SvgSearchIndex: index}
+
+	for _, c := range []struct {
+		query     string
+		pageSize  int32
+		wantSv    []string
+		wantTotal int32
+		wantNext  bool
+	}{
+		{
+			"female population",
+			0,
+			[]string{"Count_Person_Female", "Median_Age_Person_Female"},
+			2,
+			false,
+		},
+		{
+			// Typos.
+			"femal popultion",
+			0,
+			[]string{"Count_Person_Female", "Median_Age_Person_Female"},
+			2,
+			false,
+		},
+		{
+			// A name match ranks above synonyms of "count".
+			"number",
+			0,
+			[]string{"Count_Household", "Count_Person", "Count_Person_Female"},
+			3,
+			false,
+		},
+		{
+			"population",
+			2,
+			[]string{"Count_Person", "Count_Person_Female"},
+			3,
+			true,
+		},
+		{
+			"households",
+			0,
+			[]string{"Count_Household"},
+			1,
+			false,
+		},
+	} {
+		got, err := SearchStatVar(context.Background(),
+			&pb.SearchStatVarRequest{Query: c.query, PageSize: c.pageSize}, nil, cache)
+		if err != nil {
+			t.Fatalf("SearchStatVar(%s) = %s", c.query, err)
+		}
+		gotSv := []string{}
+		for _, sv := range got.GetStatVars() {
+			gotSv = append(gotSv, sv.GetDcid())
+		}
+		if diff := cmp.Diff(gotSv, c.wantSv); diff != "" {
+			t.Errorf("SearchStatVar(%s) got diff %v", c.query, diff)
+		}
+		if got.GetTotalStatVars() != c.wantTotal {
+			t.Errorf("SearchStatVar(%s) got %d stat vars in total, want %d",
+				c.query, got.GetTotalStatVars(), c.wantTotal)
+		}
+		if (got.GetNextPageToken() != "") != c.wantNext {
+			t.Errorf("SearchStatVar(%s) got next page token %q", c.query, got.GetNextPageToken())
+		}
+	}
+
+	// The last page.
+	first, err := SearchStatVar(context.Background(),
+		&pb.SearchStatVarRequest{Query: "population", PageSize: 2}, nil, cache)
+	if err != nil {
+		t.Fatalf("SearchStatVar() = %s", err)
+	}
+	got, err := SearchStatVar(context.Background(), &pb.SearchStatVarRequest{
+		Query: "population", PageSize: 2, PageToken: first.GetNextPageToken()}, nil, cache)
+	if err != nil {
+		t.Fatalf("SearchStatVar() = %s", err)
+	}
+	want := &pb.SearchStatVarResponse{
+		StatVars: []*pb.EntityInfo{
+			{Dcid: "Median_Age_Person_Female", Name: "Median Age of Female Population"},
+		},
+		StatVarGroups: []*pb.EntityInfo{},
+		Highlights: map[string]*pb.SearchStatVarResponse_Highlight{
+			"Median_Age_Person_Female": {
+				Spans: []*pb.SearchStatVarResponse_Span{{Start: 21, End: 31}},
+			},
+		},
+		TotalStatVars: 3,
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("SearchStatVar() got diff %v", diff)
+	}
+
+	// A page token of another request.
+	for _, in := range []*pb.SearchStatVarRequest{
+		{Query: "female", PageToken: first.GetNextPageToken()},
+		{Query: "population", EnableBlocklist: true, PageToken: first.GetNextPageToken()},
+		{Query: "population", Places: []string{"geoId/06"}, PageToken: first.GetNextPageToken()},
+	} {
+		if _, err := SearchStatVar(context.Background(), in, nil, cache); err == nil {
+			t.Errorf("SearchStatVar(%v) with the page token of another request = nil, want error", in)
+		}
+	}
+}
+
+func TestMatchToken(t *testing.T) {
+	index := &resource.SearchIndex{
+		RootTrieNode: &resource.TrieNode{},
+		Ranking:      map[string]*resource.RankingInfo{},
+		Docs:         map[string]*resource.SearchDoc{},
+		DocFreq:      map[string]int{},
+	}
+	index.Update("sv_1", "population households house", nil, false, nil)
+	for _, c := range []struct {
+		token string
+		want  map[string]float64
+	}{
+		{"house", map[string]float64{"house": 1, "households": 0.7}},
+		{"hous", map[string]float64{"house": 0.7, "households": 0.7}},
+		{"hose", map[string]float64{"house": 0.5, "households": 0.35}},
+		{"populaton", map[string]float64{"population": 0.5}},
+		{"pop", map[string]float64{"population": 0.7}},
+		{"pob", map[string]float64{}},
+	} {
+		got := map[string]float64{}
+		for term, m := range matchToken(c.token, index.RootTrieNode) {
+			got[term] = m.weight
+		}
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("matchToken(%s) got diff %v", c.token, diff)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	matches := map[string]*termMatch{
+		"female":  {length: 6},
+		"person:": {length: 3},
+	}
+	got := highlight("Count of Person: Female, Age 5", matches)
+	want := []*pb.SearchStatVarResponse_Span{{Start: 9, End: 12}, {Start: 17, End: 23}}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("highlight() got diff %v", diff)
+	}
+}
@@ -16,17 +16,27 @@ package translator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
 
-	"cloud.google.com/go/bigquery"
 	"github.com/datacommonsorg/mixer/internal/server/resource"
 	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/sqldb"
 	"github.com/datacommonsorg/mixer/internal/translator"
+	"github.com/datacommonsorg/mixer/internal/translator/datalog"
 	"github.com/datacommonsorg/mixer/internal/translator/sparql"
+	"github.com/datacommonsorg/mixer/internal/translator/types"
 
 	pb "github.com/datacommonsorg/mixer/internal/proto"
 
-	"google.golang.org/api/iterator"
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Query implements API for Mixer.Query.
@@ -36,15 +46,24 @@ func Query(
 	metadata *resource.Metadata,
 	store *store.Store,
 ) (*pb.QueryResponse, error) {
-	nodes, queries, opts, err := sparql.ParseQuery(in.GetSparql())
+	db := store.SQLDb
+	if db == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "No database to run Sparql queries")
+	}
+	nodes, queries, opts, err := parseQuery(in)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
+	}
+	opts.Dialect = db.Dialect()
+	opts.Params = map[string][]string{}
+	for name, p := range in.GetParameters() {
+		opts.Params[name] = p.GetValues()
 	}
 
 	translation, err := translator.Translate(
 		metadata.Mappings, nodes, queries, metadata.SubTypeMap, opts)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	var out pb.QueryResponse
@@ -52,49 +71,279 @@ func Query(
 		out.Header = append(out.Header, node.Alias)
 	}
 	out.Rows = []*pb.QueryResponseRow{}
-	n := len(out.Header)
 
-	q := store.BqClient.Query(translation.SQL)
-	it, err := q.Read(ctx)
+	colTypes := columnTypes(ctx, db, translation)
+	var cacheKey string
+	cache := store.QueryCache
+	// Results are cached for the dataset and the schema mapping generation of the
+	// metadata. Requests that still run on older mappings bypass the cache.
+	if cache != nil && (in.GetExplain() || !cache.CheckGeneration(metadata.MappingGeneration)) {
+		cache = nil
+	}
+	if cache != nil {
+		cacheKey, err = sqldb.CacheKey(
+			metadata.MappingGeneration, metadata.Bq, translation.SQL, translation.Params)
+		if err != nil {
+			return nil, err
+		}
+		if rows, ok := cache.Get(cacheKey); ok {
+			out.Rows = toResponseRows(rows, translation, colTypes)
+			out.CacheHit = true
+			return &out, nil
+		}
+	}
+	if in.GetExplain() || metadata.BqMaxBytes > 0 {
+		bytes, err := db.DryRun(ctx, translation.SQL, translation.Params)
+		if err != nil {
+			return nil, err
+		}
+		if in.GetExplain() {
+			out.Explanation = newExplanation(translation, bytes, metadata.BqMaxBytes)
+			return &out, nil
+		}
+		if bytes > metadata.BqMaxBytes {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"Query would process %d bytes, more than the limit of %d bytes",
+				bytes, metadata.BqMaxBytes)
+		}
+	}
+	// The estimate can be off, so the limit is also enforced by the database.
+	rows, err := db.Query(ctx, translation.SQL, translation.Params, metadata.BqMaxBytes)
 	if err != nil {
 		return nil, err
 	}
-	for {
-		responseRow := pb.QueryResponseRow{}
-		var row []bigquery.Value
-		err := it.Next(&row)
-		if err == iterator.Done {
-			break
+	if cache != nil {
+		cache.Put(metadata.MappingGeneration, cacheKey, rows)
+	}
+	out.Rows = toResponseRows(rows, translation, colTypes)
+	return &out, nil
+}
+
+// columnTypes gets the type of the mapping column bound to each node of a
+// translation. The type is empty if the node is not bound to a column, like an
+// aggregate, or if the database does not know the column type; cells then get
+// the type of their values.
+func columnTypes(
+	ctx context.Context, db sqldb.Executor, translation *translator.Translation,
+) []bigquery.FieldType {
+	result := make([]bigquery.FieldType, len(translation.Nodes))
+	for i, node := range translation.Nodes {
+		col, ok := translation.NodeColumn(node)
+		if !ok {
+			continue
 		}
+		colTypes, err := db.ColumnTypes(ctx, col.Table.Path())
 		if err != nil {
-			return nil, err
+			continue
 		}
-		for i, cell := range row {
-			var str string
-			if cell != nil {
-				if x, ok := cell.(int64); ok {
-					str = fmt.Sprintf("%v", x)
-				}
-				if x, ok := cell.(float64); ok {
-					str = fmt.Sprintf("%v", x)
-				}
-				if x, ok := cell.(string); ok {
-					str = x
-				}
-			}
+		result[i] = colTypes[col.Name]
+	}
+	return result
+}
+
+// toResponseRows converts the rows of a query result to response rows, with a
+// cell for each node of the translation, typed after the node column types.
+func toResponseRows(
+	rows [][]interface{},
+	translation *translator.Translation,
+	colTypes []bigquery.FieldType,
+) []*pb.QueryResponseRow {
+	n := len(translation.Nodes)
+	refs := make([]bool, n)
+	for i, node := range translation.Nodes {
+		refs[i] = translation.IsNodeRef(node)
+	}
+	result := []*pb.QueryResponseRow{}
+	for _, row := range rows {
+		responseRow := pb.QueryResponseRow{}
+		for i, value := range row {
 			if i < n {
-				responseRow.Cells = append(
-					responseRow.Cells, &pb.QueryResponseCell{Value: str})
+				responseRow.Cells = append(responseRow.Cells, toCell(value, refs[i], colTypes[i]))
 			} else {
 				// Add provenance to corresponding cells.
 				if idx, ok := translation.Prov[i]; ok {
 					for _, j := range idx {
-						responseRow.Cells[j].ProvenanceId = str
+						responseRow.Cells[j].ProvenanceId = toCell(value, false, "").GetValue()
 					}
 				}
 			}
 		}
-		out.Rows = append(out.Rows, &responseRow)
+		result = append(result, &responseRow)
 	}
-	return &out, nil
+	return result
+}
+
+// toCell converts a database value to a response cell of the type of its
+// column. Without column type, or if the value does not convert to it, the cell
+// gets the type of the value. The values of node references are dcids.
+func toCell(value interface{}, ref bool, typ bigquery.FieldType) *pb.QueryResponseCell {
+	if cell, ok := toTypedCell(value, ref, typ); ok {
+		return cell
+	}
+	cell := &pb.QueryResponseCell{}
+	switch x := value.(type) {
+	case int64:
+		cell.Value = strconv.FormatInt(x, 10)
+		cell.TypedValue = &pb.QueryResponseCell_IntValue{IntValue: x}
+	case float64:
+		cell.Value = fmt.Sprintf("%v", x)
+		cell.TypedValue = &pb.QueryResponseCell_DoubleValue{DoubleValue: x}
+	case *big.Rat:
+		// BigQuery NUMERIC values.
+		f, _ := x.Float64()
+		cell.Value = fmt.Sprintf("%v", f)
+		cell.TypedValue = &pb.QueryResponseCell_DoubleValue{DoubleValue: f}
+	case bool:
+		cell.Value = strconv.FormatBool(x)
+		cell.TypedValue = &pb.QueryResponseCell_BoolValue{BoolValue: x}
+	case time.Time:
+		cell.Value = x.UTC().Format(time.RFC3339Nano)
+		cell.TypedValue = &pb.QueryResponseCell_DateValue{DateValue: cell.Value}
+	case civil.Date:
+		cell.Value = x.String()
+		cell.TypedValue = &pb.QueryResponseCell_DateValue{DateValue: cell.Value}
+	case civil.DateTime:
+		cell.Value = x.String()
+		cell.TypedValue = &pb.QueryResponseCell_DateValue{DateValue: cell.Value}
+	case civil.Time:
+		cell.Value = x.String()
+		cell.TypedValue = &pb.QueryResponseCell_DateValue{DateValue: cell.Value}
+	case string:
+		cell.Value = x
+		if ref {
+			cell.TypedValue = &pb.QueryResponseCell_Dcid{Dcid: x}
+		} else {
+			cell.TypedValue = &pb.QueryResponseCell_StringValue{StringValue: x}
+		}
+	}
+	return cell
+}
+
+// toTypedCell converts a database value to a response cell of a column type.
+// Values are converted between numbers and strings, as SQLite stores numbers of
+// text columns and text of numeric columns.
+func toTypedCell(value interface{}, ref bool, typ bigquery.FieldType) (
+	*pb.QueryResponseCell, bool) {
+	var s string
+	switch x := value.(type) {
+	case string:
+		s = x
+	case int64:
+		s = strconv.FormatInt(x, 10)
+	case float64:
+		s = strconv.FormatFloat(x, 'g', -1, 64)
+	case bool:
+		s = strconv.FormatBool(x)
+	default:
+		return nil, false
+	}
+	cell := &pb.QueryResponseCell{Value: s}
+	switch typ {
+	case bigquery.StringFieldType:
+		if ref {
+			cell.TypedValue = &pb.QueryResponseCell_Dcid{Dcid: s}
+		} else {
+			cell.TypedValue = &pb.QueryResponseCell_StringValue{StringValue: s}
+		}
+	case bigquery.IntegerFieldType:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		cell.TypedValue = &pb.QueryResponseCell_IntValue{IntValue: i}
+	case bigquery.FloatFieldType, bigquery.NumericFieldType, bigquery.BigNumericFieldType:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, false
+		}
+		cell.Value = fmt.Sprintf("%v", f)
+		cell.TypedValue = &pb.QueryResponseCell_DoubleValue{DoubleValue: f}
+	case bigquery.BooleanFieldType:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, false
+		}
+		cell.Value = strconv.FormatBool(b)
+		cell.TypedValue = &pb.QueryResponseCell_BoolValue{BoolValue: b}
+	default:
+		return nil, false
+	}
+	return cell, true
+}
+
+// parseQuery parses the Sparql or datalog query of a request.
+func parseQuery(in *pb.QueryRequest) (
+	[]types.Node, []*types.Query, *types.QueryOptions, error) {
+	if in.GetSparql() != "" && in.GetDatalog() != "" {
+		return nil, nil, nil, status.Errorf(
+			codes.InvalidArgument, "Only one of sparql and datalog should be set")
+	}
+	if in.GetDatalog() != "" {
+		nodes, queries, err := datalog.ParseQuery(in.GetDatalog())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return nodes, queries, &types.QueryOptions{}, nil
+	}
+	return sparql.ParseQuery(in.GetSparql())
+}
+
+// toStatusError converts an error in a query to an InvalidArgument status,
+// with the details of the error. Other errors are returned as they are.
+func toStatusError(err error) error {
+	var queryErr *types.QueryError
+	if !errors.As(err, &queryErr) {
+		return err
+	}
+	s, detailErr := status.New(codes.InvalidArgument, queryErr.Error()).WithDetails(
+		&pb.QueryError{
+			Line:      int32(queryErr.Pos.Line),
+			Column:    int32(queryErr.Pos.Column),
+			Token:     queryErr.Token,
+			Expected:  queryErr.Expected,
+			Statement: queryErr.Statement,
+		})
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, queryErr.Error())
+	}
+	return s.Err()
+}
+
+// newExplanation builds the explanation of a translated query.
+func newExplanation(
+	translation *translator.Translation, bytes, maxBytes int64) *pb.QueryExplanation {
+	result := &pb.QueryExplanation{
+		Sql:                 translation.SQL,
+		TotalBytesProcessed: bytes,
+		MaxBytes:            maxBytes,
+	}
+	// Keep the bindings in the order of the statements in the query.
+	bindings := make([]translator.Binding, len(translation.Bindings))
+	copy(bindings, translation.Bindings)
+	sort.SliceStable(bindings, func(i, j int) bool {
+		pi, pj := bindings[i].Query.Pos, bindings[j].Query.Pos
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		return pi.Column < pj.Column
+	})
+	for _, b := range bindings {
+		result.Bindings = append(result.Bindings, &pb.QueryBinding{
+			Statement: b.Query.String(),
+			Mapping:   b.Mapping.String(),
+		})
+	}
+	cols := []int{}
+	for col := range translation.Prov {
+		cols = append(cols, col)
+	}
+	sort.Ints(cols)
+	for _, col := range cols {
+		pc := &pb.QueryProvenanceColumn{Column: int32(col)}
+		for _, idx := range translation.Prov[col] {
+			pc.NodeColumns = append(pc.NodeColumns, int32(idx))
+		}
+		result.ProvenanceColumns = append(result.ProvenanceColumns, pc)
+	}
+	return result
 }
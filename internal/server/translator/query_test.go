@@ -0,0 +1,433 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"path"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"github.com/datacommonsorg/mixer/internal/parser/mcf"
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/server/resource"
+	"github.com/datacommonsorg/mixer/internal/store"
+	"github.com/datacommonsorg/mixer/internal/store/sqldb"
+	"github.com/datacommonsorg/mixer/internal/translator"
+	"github.com/datacommonsorg/mixer/internal/translator/types"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestNewExplanation(t *testing.T) {
+	placeTable := types.Table{Name: "dc.Place", ID: "0"}
+	entity := types.Entity{ID: "Place", Table: placeTable}
+	nameCol := types.Column{Name: "name", Table: placeTable}
+	idCol := types.Column{Name: "id", Table: placeTable}
+
+	for _, c := range []struct {
+		translation *translator.Translation
+		want        *pb.QueryExplanation
+	}{
+		{
+			&translator.Translation{
+				SQL: "SELECT _dc_Place_0.name AS name FROM `dc.Place` AS _dc_Place_0",
+				Bindings: []translator.Binding{
+					{
+						Query: &types.Query{
+							Pred: "name",
+							Sub:  types.Node{Alias: "?p"},
+							Obj:  types.Node{Alias: "?name"},
+							Pos:  types.Pos{Line: 2, Column: 1},
+						},
+						Mapping: &types.Mapping{Pred: "name", Sub: entity, Obj: nameCol},
+					},
+					{
+						Query: &types.Query{
+							Pred: "typeOf",
+							Sub:  types.Node{Alias: "?p"},
+							Obj:  "Place",
+							Pos:  types.Pos{Line: 1, Column: 3},
+						},
+						Mapping: &types.Mapping{Pred: "dcid", Sub: entity, Obj: idCol},
+					},
+				},
+				Prov: map[int][]int{3: {1}, 2: {0}},
+			},
+			&pb.QueryExplanation{
+				Sql: "SELECT _dc_Place_0.name AS name FROM `dc.Place` AS _dc_Place_0",
+				Bindings: []*pb.QueryBinding{
+					{
+						Statement: "?p typeOf Place",
+						Mapping:   "dc.Place0->Place dcid dc.Place0->id",
+					},
+					{
+						Statement: "?p name ?name",
+						Mapping:   "dc.Place0->Place name dc.Place0->name",
+					},
+				},
+				ProvenanceColumns: []*pb.QueryProvenanceColumn{
+					{Column: 2, NodeColumns: []int32{0}},
+					{Column: 3, NodeColumns: []int32{1}},
+				},
+				TotalBytesProcessed: 100,
+				MaxBytes:            1000,
+			},
+		},
+	} {
+		got := newExplanation(c.translation, 100, 1000)
+		if diff := cmp.Diff(got, c.want, protocmp.Transform()); diff != "" {
+			t.Errorf("newExplanation() got diff %+v", diff)
+		}
+	}
+}
+
+func TestQueryLocal(t *testing.T) {
+	mappings, err := mcf.ParseMapping(`
+Node: E:Place->E1
+typeOf: Place
+dcid: C:Place->id
+name: C:Place->name
+population: C:Place->population
+fips: C:Place->fips
+functionalDeps: dcid
+`, "dc")
+	if err != nil {
+		t.Fatalf("ParseMapping() = %s", err)
+	}
+	dir := t.TempDir()
+	err = ioutil.WriteFile(path.Join(dir, "Place.csv"), []byte(
+		"id,name,population,fips\n"+
+			"geoId/06,California,39000000,06\n"+
+			"geoId/36,New York,19000000,36\n"+
+			"geoId/48,Texas,29000000,48\n"), 0644)
+	if err != nil {
+		t.Fatalf("WriteFile() = %s", err)
+	}
+	db, err := sqldb.NewSQLite(mappings, dir)
+	if err != nil {
+		t.Fatalf("NewSQLite() = %s", err)
+	}
+	defer db.Close()
+	s := &store.Store{SQLDb: db}
+	metadata := &resource.Metadata{Mappings: mappings}
+
+	for _, c := range []struct {
+		in   *pb.QueryRequest
+		want *pb.QueryResponse
+	}{
+		{
+			&pb.QueryRequest{Sparql: `SELECT ?name
+			 WHERE {
+				 ?p typeOf Place .
+				 ?p name ?name .
+				 FILTER(REGEX(?name, "^new", "i"))
+			 }`},
+			&pb.QueryResponse{
+				Header: []string{"?name"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{{Value: "New York", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "New York"}}}},
+				},
+			},
+		},
+		{
+			&pb.QueryRequest{Sparql: `SELECT ?name ?population
+			 WHERE {
+				 ?p typeOf Place .
+				 ?p name ?name .
+				 ?p population ?population
+			 }
+			 ORDER BY DESC(?population) LIMIT 1 OFFSET 1`},
+			&pb.QueryResponse{
+				Header: []string{"?name", "?population"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{
+						{Value: "Texas", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "Texas"}},
+						{Value: "29000000", TypedValue: &pb.QueryResponseCell_IntValue{IntValue: 29000000}},
+					}},
+				},
+			},
+		},
+		{
+			// Codes with leading zeros are strings.
+			&pb.QueryRequest{Sparql: `SELECT ?fips
+			 WHERE {
+				 ?p typeOf Place .
+				 ?p name "California" .
+				 ?p fips ?fips
+			 }`},
+			&pb.QueryResponse{
+				Header: []string{"?fips"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{{Value: "06", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "06"}}}},
+				},
+			},
+		},
+		{
+			// A variable left unbound by an optional group is bound by the next.
+			&pb.QueryRequest{Sparql: `SELECT ?name ?fips
+			 WHERE {
+				 ?p typeOf Place .
+				 ?p name ?name .
+				 OPTIONAL { ?p typeOf Place . ?p fips ?fips FILTER(?fips = "06") }
+				 OPTIONAL { ?p typeOf Place . ?p fips ?fips FILTER(?fips != "06") }
+			 }
+			 ORDER BY ?name`},
+			&pb.QueryResponse{
+				Header: []string{"?name", "?fips"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{
+						{Value: "California", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "California"}},
+						{Value: "06", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "06"}},
+					}},
+					{Cells: []*pb.QueryResponseCell{
+						{Value: "New York", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "New York"}},
+						{Value: "36", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "36"}},
+					}},
+					{Cells: []*pb.QueryResponseCell{
+						{Value: "Texas", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "Texas"}},
+						{Value: "48", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "48"}},
+					}},
+				},
+			},
+		},
+		{
+			// Nested optional groups that do not match have one solution, unbound.
+			&pb.QueryRequest{Sparql: `SELECT ?fips
+			 WHERE {
+				 OPTIONAL { OPTIONAL { ?p typeOf Place . ?p fips ?fips FILTER(?fips = "99") } }
+			 }`},
+			&pb.QueryResponse{
+				Header: []string{"?fips"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{{}}},
+				},
+			},
+		},
+		{
+			// An optional group that does not match has one solution, unbound.
+			&pb.QueryRequest{Sparql: `SELECT ?fips
+			 WHERE {
+				 OPTIONAL { ?p typeOf Place . ?p fips ?fips FILTER(?fips = "99") }
+			 }`},
+			&pb.QueryResponse{
+				Header: []string{"?fips"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{{}}},
+				},
+			},
+		},
+		{
+			&pb.QueryRequest{Sparql: `SELECT ?p
+			 WHERE {
+				 ?p typeOf Place .
+				 ?p name "Texas"
+			 }`},
+			&pb.QueryResponse{
+				Header: []string{"?p"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{{Value: "geoId/48", TypedValue: &pb.QueryResponseCell_Dcid{Dcid: "geoId/48"}}}},
+				},
+			},
+		},
+		{
+			&pb.QueryRequest{Sparql: `SELECT (COUNT(?p) AS ?count)
+			 WHERE {
+				 ?p typeOf Place .
+				 ?p name ?name .
+				 FILTER(STRENDS(?name, "s") || CONTAINS(?name, "if"))
+			 }`},
+			&pb.QueryResponse{
+				Header: []string{"?count"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{{Value: "2", TypedValue: &pb.QueryResponseCell_IntValue{IntValue: 2}}}},
+				},
+			},
+		},
+		{
+			&pb.QueryRequest{
+				Sparql: `SELECT ?name
+				 WHERE {
+					 ?p typeOf Place .
+					 ?p dcid $dcids .
+					 ?p name ?name .
+					 FILTER(?name != $name)
+				 }`,
+				Parameters: map[string]*pb.QueryParameter{
+					"dcids": {Values: []string{"geoId/06", "geoId/48", "geoId/99"}},
+					"name":  {Values: []string{"Texas"}},
+				},
+			},
+			&pb.QueryResponse{
+				Header: []string{"?name"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{{Value: "California", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "California"}}}},
+				},
+			},
+		},
+		{
+			&pb.QueryRequest{Datalog: `SELECT ?name, typeOf ?p Place, dcid ?p geoId/06, name ?p ?name`},
+			&pb.QueryResponse{
+				Header: []string{"?name"},
+				Rows: []*pb.QueryResponseRow{
+					{Cells: []*pb.QueryResponseCell{{Value: "California", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "California"}}}},
+				},
+			},
+		},
+	} {
+		got, err := Query(context.Background(), c.in, metadata, s)
+		if err != nil {
+			t.Errorf("Query(%v) = %s", c.in, err)
+			continue
+		}
+		if diff := cmp.Diff(got, c.want, protocmp.Transform()); diff != "" {
+			t.Errorf("Query(%v) got diff %+v", c.in, diff)
+		}
+	}
+}
+
+func TestQueryCache(t *testing.T) {
+	mappings, err := mcf.ParseMapping(`
+Node: E:Place->E1
+typeOf: Place
+dcid: C:Place->id
+name: C:Place->name
+functionalDeps: dcid
+`, "dc")
+	if err != nil {
+		t.Fatalf("ParseMapping() = %s", err)
+	}
+	dir := t.TempDir()
+	err = ioutil.WriteFile(path.Join(dir, "Place.csv"), []byte(
+		"id,name\n"+
+			"geoId/06,California\n"), 0644)
+	if err != nil {
+		t.Fatalf("WriteFile() = %s", err)
+	}
+	db, err := sqldb.NewSQLite(mappings, dir)
+	if err != nil {
+		t.Fatalf("NewSQLite() = %s", err)
+	}
+	defer db.Close()
+	s := &store.Store{SQLDb: db, QueryCache: sqldb.NewCache(10, time.Minute)}
+	metadata := &resource.Metadata{Mappings: mappings}
+
+	for _, c := range []struct {
+		in       *pb.QueryRequest
+		metadata *resource.Metadata
+		want     bool
+	}{
+		{&pb.QueryRequest{Sparql: "SELECT ?name WHERE {?p typeOf Place . ?p name ?name}"}, metadata, false},
+		// Only whitespace differs, so the translated SQL is the same.
+		{&pb.QueryRequest{Sparql: "SELECT ?name\nWHERE {\n?p typeOf Place .\n?p name ?name\n}"}, metadata, true},
+		// Reloaded metadata invalidates the cache.
+		{&pb.QueryRequest{Sparql: "SELECT ?name WHERE {?p typeOf Place . ?p name ?name}"},
+			&resource.Metadata{Mappings: mappings, MappingGeneration: 1}, false},
+		{&pb.QueryRequest{Sparql: "SELECT ?name WHERE {?p typeOf Place . ?p name ?name}"},
+			&resource.Metadata{Mappings: mappings, MappingGeneration: 1}, true},
+		// Requests on older metadata bypass the cache.
+		{&pb.QueryRequest{Sparql: "SELECT ?name WHERE {?p typeOf Place . ?p name ?name}"}, metadata, false},
+		{&pb.QueryRequest{Sparql: "SELECT ?name WHERE {?p typeOf Place . ?p name ?name}"},
+			&resource.Metadata{Mappings: mappings, MappingGeneration: 1}, true},
+	} {
+		got, err := Query(context.Background(), c.in, c.metadata, s)
+		if err != nil {
+			t.Fatalf("Query(%v) = %s", c.in, err)
+		}
+		if got.GetCacheHit() != c.want {
+			t.Errorf("Query(%v).CacheHit = %v, want %v", c.in, got.GetCacheHit(), c.want)
+		}
+		want := []*pb.QueryResponseRow{{Cells: []*pb.QueryResponseCell{{Value: "California", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "California"}}}}}
+		if diff := cmp.Diff(got.GetRows(), want, protocmp.Transform()); diff != "" {
+			t.Errorf("Query(%v) got diff %+v", c.in, diff)
+		}
+	}
+}
+
+func TestToCell(t *testing.T) {
+	for _, c := range []struct {
+		value interface{}
+		ref   bool
+		typ   bigquery.FieldType
+		want  *pb.QueryResponseCell
+	}{
+		{nil, false, "", &pb.QueryResponseCell{}},
+		{int64(3), false, "", &pb.QueryResponseCell{
+			Value: "3", TypedValue: &pb.QueryResponseCell_IntValue{IntValue: 3}}},
+		{0.5, false, "", &pb.QueryResponseCell{
+			Value: "0.5", TypedValue: &pb.QueryResponseCell_DoubleValue{DoubleValue: 0.5}}},
+		{big.NewRat(5, 4), false, "", &pb.QueryResponseCell{
+			Value: "1.25", TypedValue: &pb.QueryResponseCell_DoubleValue{DoubleValue: 1.25}}},
+		{true, false, "", &pb.QueryResponseCell{
+			Value: "true", TypedValue: &pb.QueryResponseCell_BoolValue{BoolValue: true}}},
+		{time.Date(2021, 3, 1, 12, 30, 0, 0, time.UTC), false, "", &pb.QueryResponseCell{
+			Value:      "2021-03-01T12:30:00Z",
+			TypedValue: &pb.QueryResponseCell_DateValue{DateValue: "2021-03-01T12:30:00Z"}}},
+		{civil.Date{Year: 2021, Month: 3, Day: 1}, false, "", &pb.QueryResponseCell{
+			Value: "2021-03-01", TypedValue: &pb.QueryResponseCell_DateValue{DateValue: "2021-03-01"}}},
+		{"Texas", false, "", &pb.QueryResponseCell{
+			Value: "Texas", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "Texas"}}},
+		{"geoId/48", true, "", &pb.QueryResponseCell{
+			Value: "geoId/48", TypedValue: &pb.QueryResponseCell_Dcid{Dcid: "geoId/48"}}},
+		// Values converted to the column type.
+		{int64(48), false, bigquery.StringFieldType, &pb.QueryResponseCell{
+			Value: "48", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "48"}}},
+		{"29000000", false, bigquery.IntegerFieldType, &pb.QueryResponseCell{
+			Value: "29000000", TypedValue: &pb.QueryResponseCell_IntValue{IntValue: 29000000}}},
+		{int64(2), false, bigquery.FloatFieldType, &pb.QueryResponseCell{
+			Value: "2", TypedValue: &pb.QueryResponseCell_DoubleValue{DoubleValue: 2}}},
+		{"TRUE", false, bigquery.BooleanFieldType, &pb.QueryResponseCell{
+			Value: "true", TypedValue: &pb.QueryResponseCell_BoolValue{BoolValue: true}}},
+		// Values that do not convert keep their type.
+		{"n/a", false, bigquery.IntegerFieldType, &pb.QueryResponseCell{
+			Value: "n/a", TypedValue: &pb.QueryResponseCell_StringValue{StringValue: "n/a"}}},
+	} {
+		got := toCell(c.value, c.ref, c.typ)
+		if diff := cmp.Diff(got, c.want, protocmp.Transform()); diff != "" {
+			t.Errorf("toCell(%v, %v, %s) got diff %+v", c.value, c.ref, c.typ, diff)
+		}
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	s := &store.Store{SQLDb: &sqldb.SQLite{}}
+	for _, in := range []*pb.QueryRequest{
+		{Sparql: "SELECT ?p WHERE {?p typeOf Place}", Datalog: "SELECT ?p, typeOf ?p Place"},
+		{Datalog: "SELECT ?p typeOf ?p Place"},
+	} {
+		_, err := Query(context.Background(), in, &resource.Metadata{}, s)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Query(%v) = %v, want InvalidArgument", in, err)
+		}
+	}
+
+	// Errors in the query have their details.
+	_, err := Query(context.Background(),
+		&pb.QueryRequest{Sparql: "SELECT ?a\nWHERE {\n  ?a name ?n"}, &resource.Metadata{}, s)
+	st, _ := status.FromError(err)
+	if st.Code() != codes.InvalidArgument || len(st.Details()) != 1 {
+		t.Fatalf("Query() = %v, want InvalidArgument with details", err)
+	}
+	want := &pb.QueryError{Line: 3, Column: 13, Token: "EOF", Expected: []string{"}"}}
+	if diff := cmp.Diff(st.Details()[0], want, protocmp.Transform()); diff != "" {
+		t.Errorf("Query() got details diff %+v", diff)
+	}
+}
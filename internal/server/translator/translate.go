@@ -44,12 +44,12 @@ func Translate(
 	}
 	nodes, queries, opts, err := sparql.ParseQuery(in.GetSparql())
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	trans, err := translator.Translate(
 		mappings, nodes, queries, metadata.SubTypeMap, opts)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	out.Sql = trans.SQL
 	translation, err := json.MarshalIndent(trans, "", "  ")
@@ -16,9 +16,12 @@ package bigtable
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"sync"
 
 	cbt "cloud.google.com/go/bigtable"
+	pb "github.com/datacommonsorg/mixer/internal/proto"
 	"github.com/datacommonsorg/mixer/internal/util"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
@@ -213,3 +216,40 @@ func Read(
 	}
 	return baseResult, branchResult, nil
 }
+
+// ReadAllPlacesMetadata scans the place metadata cache of a table, and calls
+// action with each place and its decoded metadata. The scan stops at the first
+// error of action.
+func ReadAllPlacesMetadata(
+	ctx context.Context,
+	btTable *cbt.Table,
+	action func(string, *pb.PlaceMetadataCache) error,
+) error {
+	var rowErr error
+	err := btTable.ReadRows(ctx, cbt.PrefixRange(BtPlacesMetadataPrefix),
+		func(btRow cbt.Row) bool {
+			if len(btRow[BtFamily]) == 0 {
+				return true
+			}
+			place := strings.TrimPrefix(btRow.Key(), BtPlacesMetadataPrefix)
+			jsonRaw, err := util.UnzipAndDecode(string(btRow[BtFamily][0].Value))
+			if err != nil {
+				rowErr = err
+				return false
+			}
+			var data pb.PlaceMetadataCache
+			if err := json.Unmarshal(jsonRaw, &data); err != nil {
+				rowErr = err
+				return false
+			}
+			if err := action(place, &data); err != nil {
+				rowErr = err
+				return false
+			}
+			return true
+		})
+	if err != nil {
+		return err
+	}
+	return rowErr
+}
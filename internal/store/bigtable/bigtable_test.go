@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"cloud.google.com/go/bigtable"
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/util"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -101,3 +103,33 @@ func TestReadTwoTables(t *testing.T) {
 		}
 	}
 }
+
+func TestReadAllPlacesMetadata(t *testing.T) {
+	ctx := context.Background()
+	data := map[string]string{"key1": "data1"}
+	for place, name := range map[string]string{"geoId/06": "California", "geoId/08": "Colorado"} {
+		value, err := util.ZipAndEncode(
+			[]byte(`{"places": [{"dcid": "` + place + `", "name": "` + name + `"}]}`))
+		if err != nil {
+			t.Fatalf("ZipAndEncode() = %s", err)
+		}
+		data[BtPlacesMetadataPrefix+place] = value
+	}
+	btTable, err := SetupBigtable(ctx, data)
+	if err != nil {
+		t.Fatalf("SetupBigtable() = %s", err)
+	}
+	got := map[string]string{}
+	err = ReadAllPlacesMetadata(ctx, btTable,
+		func(place string, data *pb.PlaceMetadataCache) error {
+			got[place] = data.GetPlaces()[0].GetName()
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("ReadAllPlacesMetadata() = %s", err)
+	}
+	want := map[string]string{"geoId/06": "California", "geoId/08": "Colorado"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("ReadAllPlacesMetadata() got diff %+v", diff)
+	}
+}
@@ -64,8 +64,8 @@ func SetupBigtable(
 	}
 	bt := client.Open(testTable)
 
-	mut := bigtable.NewMutation()
 	for key, value := range data {
+		mut := bigtable.NewMutation()
 		mut.Set(BtFamily, "value", bigtable.Now(), []byte(value))
 		if err = bt.Apply(ctx, key, mut); err != nil {
 			return nil, err
@@ -0,0 +1,311 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geo holds an in-memory index of place centroids to find the places
+// near a point.
+package geo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	cbt "cloud.google.com/go/bigtable"
+	pb "github.com/datacommonsorg/mixer/internal/proto"
+	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+const (
+	// Mean radius of the Earth.
+	earthRadiusKm = 6371.0
+	// Half of the circumference of the Earth, the largest distance between two
+	// points.
+	maxDistanceKm = earthRadiusKm * math.Pi
+	// Radius of the first search for the nearest places. It is multiplied by
+	// nearestRadiusFactor until there are enough places.
+	nearestRadiusKm     = 10.0
+	nearestRadiusFactor = 4.0
+	// Number of cells that cover a search circle.
+	searchCoveringCells = 16
+	// Number of rows read from Bigtable at a time when loading the index.
+	loadBatchSize = 10000
+)
+
+// Place is a place with a centroid.
+type Place struct {
+	Dcid      string
+	Name      string
+	Types     []string
+	Latitude  float64
+	Longitude float64
+}
+
+// NearbyPlace is a place found near a point.
+type NearbyPlace struct {
+	*Place
+	DistanceKm float64
+}
+
+type indexedPlace struct {
+	cell  s2.CellID
+	place *Place
+}
+
+// CentroidIndex indexes places by the leaf S2 cells of their centroids. The
+// places in a cell are in a contiguous range of the sorted cells.
+type CentroidIndex struct {
+	places []*indexedPlace
+	dcids  map[string]*Place
+}
+
+// NewCentroidIndex builds the centroid index of places.
+func NewCentroidIndex(places []*Place) *CentroidIndex {
+	idx := &CentroidIndex{dcids: map[string]*Place{}}
+	for _, p := range places {
+		idx.places = append(idx.places, &indexedPlace{
+			cell:  s2.CellIDFromLatLng(s2.LatLngFromDegrees(p.Latitude, p.Longitude)),
+			place: p,
+		})
+		idx.dcids[p.Dcid] = p
+	}
+	sort.Slice(idx.places, func(i, j int) bool {
+		return idx.places[i].cell < idx.places[j].cell
+	})
+	return idx
+}
+
+// Len gets the number of places in the index.
+func (idx *CentroidIndex) Len() int {
+	return len(idx.places)
+}
+
+// Get gets a place of the index by its dcid.
+func (idx *CentroidIndex) Get(dcid string) (*Place, bool) {
+	p, ok := idx.dcids[dcid]
+	return p, ok
+}
+
+func hasType(p *Place, placeType string) bool {
+	if placeType == "" {
+		return true
+	}
+	for _, t := range p.Types {
+		if t == placeType {
+			return true
+		}
+	}
+	return false
+}
+
+// within gets the places of a type within a distance of a point, from the
+// nearest.
+func (idx *CentroidIndex) within(center s2.LatLng, radiusKm float64, placeType string) []*NearbyPlace {
+	circle := s2.CapFromCenterAngle(s2.PointFromLatLng(center), s1.Angle(radiusKm/earthRadiusKm))
+	coverer := &s2.RegionCoverer{MaxLevel: 30, MaxCells: searchCoveringCells}
+	result := []*NearbyPlace{}
+	for _, cell := range coverer.Covering(circle) {
+		lo, hi := cell.RangeMin(), cell.RangeMax()
+		i := sort.Search(len(idx.places), func(i int) bool { return idx.places[i].cell >= lo })
+		for ; i < len(idx.places) && idx.places[i].cell <= hi; i++ {
+			p := idx.places[i].place
+			if !hasType(p, placeType) {
+				continue
+			}
+			d := center.Distance(s2.LatLngFromDegrees(p.Latitude, p.Longitude)).Radians() * earthRadiusKm
+			if d <= radiusKm {
+				result = append(result, &NearbyPlace{Place: p, DistanceKm: d})
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DistanceKm != result[j].DistanceKm {
+			return result[i].DistanceKm < result[j].DistanceKm
+		}
+		return result[i].Dcid < result[j].Dcid
+	})
+	return result
+}
+
+// Search finds the places of a type near a point, from the nearest. Places are
+// within radiusKm of the point if it is positive, and there are at most limit
+// places if it is positive. At least one of them should be positive. It also
+// returns whether more places within radiusKm were cut by the limit.
+func (idx *CentroidIndex) Search(
+	lat, lng, radiusKm float64, limit int, placeType string) ([]*NearbyPlace, bool) {
+	center := s2.LatLngFromDegrees(lat, lng)
+	var result []*NearbyPlace
+	if radiusKm > 0 {
+		result = idx.within(center, radiusKm, placeType)
+	} else {
+		// Widen the search until it has enough places. All the places within
+		// the radius are found, so the nearest are among them.
+		for r := nearestRadiusKm; ; r *= nearestRadiusFactor {
+			result = idx.within(center, r, placeType)
+			if len(result) >= limit || r >= maxDistanceKm {
+				break
+			}
+		}
+	}
+	if limit > 0 && len(result) > limit {
+		return result[:limit], radiusKm > 0
+	}
+	return result, false
+}
+
+// LoadCentroidCSV loads the places of a CSV file with a header of columns
+// "dcid", "name", "typeOf", "latitude" and "longitude". Places of several
+// types have them separated by commas.
+func LoadCentroidCSV(path string) (*CentroidIndex, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	reader := csv.NewReader(f)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header of %s: %s", path, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"dcid", "latitude", "longitude"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %s in %s", name, path)
+		}
+	}
+	get := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	places := []*Place{}
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		p := &Place{Dcid: get(row, "dcid"), Name: get(row, "name")}
+		if t := get(row, "typeOf"); t != "" {
+			p.Types = strings.Split(t, ",")
+		}
+		lat, latErr := strconv.ParseFloat(get(row, "latitude"), 64)
+		lng, lngErr := strconv.ParseFloat(get(row, "longitude"), 64)
+		if p.Dcid == "" || latErr != nil || lngErr != nil {
+			return nil, fmt.Errorf("invalid place at line %d of %s", line, path)
+		}
+		p.Latitude, p.Longitude = lat, lng
+		places = append(places, p)
+	}
+	log.Printf("Built centroid index of %d places", len(places))
+	return NewCentroidIndex(places), nil
+}
+
+// LoadCentroidIndex loads the places of the place metadata cache of a Bigtable
+// with their latitude and longitude.
+func LoadCentroidIndex(ctx context.Context, baseTable *cbt.Table) (*CentroidIndex, error) {
+	places := map[string]*Place{}
+	err := bigtable.ReadAllPlacesMetadata(ctx, baseTable,
+		func(dcid string, data *pb.PlaceMetadataCache) error {
+			for _, info := range data.GetPlaces() {
+				if info.GetDcid() == dcid {
+					places[dcid] = &Place{Dcid: dcid, Name: info.GetName()}
+					if info.GetType() != "" {
+						places[dcid].Types = []string{info.GetType()}
+					}
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	dcids := []string{}
+	for dcid := range places {
+		dcids = append(dcids, dcid)
+	}
+	btGroup := bigtable.NewBigtableGroup(baseTable, nil)
+	coordinates := map[string]map[string]float64{"latitude": {}, "longitude": {}}
+	for prop, values := range coordinates {
+		for i := 0; i < len(dcids); i += loadBatchSize {
+			j := i + loadBatchSize
+			if j > len(dcids) {
+				j = len(dcids)
+			}
+			dataMap, _, err := bigtable.Read(
+				ctx,
+				btGroup,
+				bigtable.BuildPropertyValuesKey(dcids[i:j], prop, true),
+				func(dcid string, jsonRaw []byte) (interface{}, error) {
+					var propVals struct {
+						Nodes []struct {
+							Value string `json:"value"`
+						} `json:"entities"`
+					}
+					if err := json.Unmarshal(jsonRaw, &propVals); err != nil {
+						return nil, err
+					}
+					if len(propVals.Nodes) == 0 {
+						return nil, nil
+					}
+					v, err := strconv.ParseFloat(propVals.Nodes[0].Value, 64)
+					if err != nil {
+						return nil, nil
+					}
+					return v, nil
+				},
+				func(rowKey string) (string, error) {
+					l := strings.TrimPrefix(rowKey, bigtable.BtOutPropValPrefix)
+					return strings.TrimSuffix(l, "^"+prop), nil
+				},
+				false, /* readBranch */
+			)
+			if err != nil {
+				return nil, err
+			}
+			for dcid, v := range dataMap {
+				if v, ok := v.(float64); ok {
+					values[dcid] = v
+				}
+			}
+		}
+	}
+	result := []*Place{}
+	for dcid, p := range places {
+		lat, latOk := coordinates["latitude"][dcid]
+		lng, lngOk := coordinates["longitude"][dcid]
+		if latOk && lngOk {
+			p.Latitude, p.Longitude = lat, lng
+			result = append(result, p)
+		}
+	}
+	log.Printf("Built centroid index of %d places", len(result))
+	return NewCentroidIndex(result), nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"io/ioutil"
+	"math"
+	"path"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const centroidCSV = `dcid,name,typeOf,latitude,longitude
+geoId/0649670,Mountain View,City,37.3861,-122.0839
+geoId/0677000,Sunnyvale,City,37.3688,-122.0363
+geoId/0668000,San Jose,City,37.3382,-121.8863
+geoId/0667000,San Francisco,"City,County",37.7749,-122.4194
+geoId/0644000,Los Angeles,City,34.0522,-118.2437
+ws/KNUQ,Moffett Field,WeatherStation,37.4161,-122.0491
+country/NZL,New Zealand,Country,-41.2865,174.7762
+`
+
+func TestCentroidIndex(t *testing.T) {
+	file := path.Join(t.TempDir(), "centroids.csv")
+	if err := ioutil.WriteFile(file, []byte(centroidCSV), 0644); err != nil {
+		t.Fatalf("WriteFile() = %s", err)
+	}
+	idx, err := LoadCentroidCSV(file)
+	if err != nil {
+		t.Fatalf("LoadCentroidCSV() = %s", err)
+	}
+	if idx.Len() != 7 {
+		t.Errorf("Len() = %d, want 7", idx.Len())
+	}
+	if p, ok := idx.Get("geoId/0667000"); !ok || !cmp.Equal(p.Types, []string{"City", "County"}) {
+		t.Errorf("Get(geoId/0667000) = %v, want types City and County", p)
+	}
+	for _, c := range []struct {
+		desc      string
+		radiusKm  float64
+		limit     int
+		placeType string
+		want      []string
+		truncated bool
+	}{
+		{"within 20km", 20, 0, "", []string{"geoId/0649670", "ws/KNUQ", "geoId/0677000", "geoId/0668000"}, false},
+		{"cities within 60km", 60, 0, "City",
+			[]string{"geoId/0649670", "geoId/0677000", "geoId/0668000", "geoId/0667000"}, false},
+		{"2 nearest", 0, 2, "", []string{"geoId/0649670", "ws/KNUQ"}, false},
+		{"nearest weather station", 0, 1, "WeatherStation", []string{"ws/KNUQ"}, false},
+		// The search widens to the other side of the Earth.
+		{"nearest country", 0, 5, "Country", []string{"country/NZL"}, false},
+		{"3 nearest within 20km", 20, 3, "", []string{"geoId/0649670", "ws/KNUQ", "geoId/0677000"}, true},
+		{"3 nearest within 5km", 5, 3, "", []string{"geoId/0649670", "ws/KNUQ", "geoId/0677000"}, false},
+		{"unknown type", 0, 5, "State", []string{}, false},
+	} {
+		got := []string{}
+		prev := 0.0
+		places, truncated := idx.Search(37.39, -122.06, c.radiusKm, c.limit, c.placeType)
+		if truncated != c.truncated {
+			t.Errorf("Search(%s) got truncated %v, want %v", c.desc, truncated, c.truncated)
+		}
+		for _, p := range places {
+			got = append(got, p.Dcid)
+			if p.DistanceKm < prev || (c.radiusKm > 0 && p.DistanceKm > c.radiusKm) {
+				t.Errorf("Search(%s) got distance %v of %s", c.desc, p.DistanceKm, p.Dcid)
+			}
+			prev = p.DistanceKm
+		}
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("Search(%s) got diff %+v", c.desc, diff)
+		}
+	}
+	// Mountain View to Sunnyvale is about 4.6km.
+	got, _ := idx.Search(37.3861, -122.0839, 5, 0, "City")
+	if len(got) != 2 || math.Abs(got[1].DistanceKm-4.6) > 0.2 {
+		t.Errorf("Search(Mountain View) = %v, want Sunnyvale at about 4.6km", got)
+	}
+}
+
+func TestLoadCentroidCSVError(t *testing.T) {
+	for _, content := range []string{
+		"dcid,name,latitude\ngeoId/06,California,37",
+		"dcid,latitude,longitude\ngeoId/06,north,-120",
+		"dcid,latitude,longitude\n,37,-120",
+	} {
+		file := path.Join(t.TempDir(), "centroids.csv")
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() = %s", err)
+		}
+		if _, err := LoadCentroidCSV(file); err == nil {
+			t.Errorf("LoadCentroidCSV(%q) = nil, want error", content)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqldb
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// MaxCachedRows is the maximum number of rows of a cached query result. Larger
+// results are not cached, so the memory used by the cache stays bounded.
+const MaxCachedRows = 1000
+
+// Cache holds the rows of recent query results in memory. When the cache is
+// full, the least recently used result is removed.
+//
+// Results are cached for a generation of the data that queries run on, like the
+// schema mapping. Generations increase when the data is reloaded, and results
+// of older generations are dropped.
+type Cache struct {
+	size       int
+	ttl        time.Duration
+	lock       sync.Mutex
+	lru        *list.List
+	entries    map[string]*list.Element
+	generation int64
+	// now gets the current time, and is replaced in tests.
+	now func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	rows    [][]interface{}
+	expires time.Time
+}
+
+// NewCache creates a cache that holds at most size results, each for the ttl
+// duration.
+func NewCache(size int, ttl time.Duration) *Cache {
+	return &Cache{
+		size:    size,
+		ttl:     ttl,
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+		now:     time.Now,
+	}
+}
+
+// CacheKey gets the cache key of a query on a dataset, for a generation of the
+// data.
+func CacheKey(
+	generation int64, dataset, sql string, params map[string]interface{},
+) (string, error) {
+	// Map keys are sorted by json.Marshal.
+	b, err := json.Marshal([]interface{}{generation, dataset, sql, params})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Get gets the rows of a cached query result.
+func (c *Cache) Get(key string) ([][]interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.rows, true
+}
+
+// Put adds the rows of a query result of a generation to the cache. Results of
+// an older generation than the cache, or with more than MaxCachedRows rows, are
+// not added.
+func (c *Cache) Put(generation int64, key string, rows [][]interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.size <= 0 || generation < c.generation || len(rows) > MaxCachedRows {
+		return
+	}
+	if generation > c.generation {
+		c.purge()
+		c.generation = generation
+	}
+	entry := &cacheEntry{key: key, rows: rows, expires: c.now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Purge removes all the results from the cache.
+func (c *Cache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.purge()
+}
+
+func (c *Cache) purge() {
+	c.lru.Init()
+	c.entries = map[string]*list.Element{}
+}
+
+// CheckGeneration purges the cache when a generation is newer than that of the
+// cached results, and reports whether the generation is the current one. An
+// older generation never replaces a newer one.
+func (c *Cache) CheckGeneration(generation int64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if generation > c.generation {
+		c.purge()
+		c.generation = generation
+	}
+	return generation == c.generation
+}
+
+// Len gets the number of results in the cache.
+func (c *Cache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqldb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewCache(2, time.Minute)
+	c.now = func() time.Time { return now }
+	rows := [][]interface{}{{"geoId/06"}}
+
+	c.Put(0, "a", rows)
+	c.Put(0, "b", rows)
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("Get(a) missed")
+	}
+	// "b" is the least recently used.
+	c.Put(0, "c", rows)
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Get(b) hit after eviction")
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get(a) hit after expiry")
+	}
+
+	c.Put(0, "a", rows)
+	if !c.CheckGeneration(1) {
+		t.Errorf("CheckGeneration(1) = false for a newer generation")
+	}
+	if got := c.Len(); got != 0 {
+		t.Errorf("Len() = %d after generation change, want 0", got)
+	}
+	c.Put(1, "a", rows)
+	if !c.CheckGeneration(1) {
+		t.Errorf("CheckGeneration(1) = false for the same generation")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("Get(a) missed with the same generation")
+	}
+	// An older generation doesn't purge the cache, and its results are dropped.
+	if c.CheckGeneration(0) {
+		t.Errorf("CheckGeneration(0) = true for an older generation")
+	}
+	c.Put(0, "b", rows)
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Get(b) hit for an older generation")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("Get(a) missed after an older generation")
+	}
+	// Large results are not cached.
+	c.Put(1, "c", make([][]interface{}, MaxCachedRows+1))
+	if _, ok := c.Get("c"); ok {
+		t.Errorf("Get(c) hit for %d rows", MaxCachedRows+1)
+	}
+	c.Purge()
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get(a) hit after Purge()")
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	a, err := CacheKey(0, "dc", "SELECT 1", map[string]interface{}{"x": "1", "y": []string{"2"}})
+	if err != nil {
+		t.Fatalf("CacheKey() = %s", err)
+	}
+	b, err := CacheKey(0, "dc", "SELECT 1", map[string]interface{}{"y": []string{"2"}, "x": "1"})
+	if err != nil {
+		t.Fatalf("CacheKey() = %s", err)
+	}
+	if a != b {
+		t.Errorf("CacheKey() = %s and %s, want the same key", a, b)
+	}
+	c, err := CacheKey(0, "dc2", "SELECT 1", map[string]interface{}{"x": "1", "y": []string{"2"}})
+	if err != nil {
+		t.Fatalf("CacheKey() = %s", err)
+	}
+	if a == c {
+		t.Errorf("CacheKey() = %s for different datasets", a)
+	}
+	d, err := CacheKey(1, "dc", "SELECT 1", map[string]interface{}{"x": "1", "y": []string{"2"}})
+	if err != nil {
+		t.Fatalf("CacheKey() = %s", err)
+	}
+	if a == d {
+		t.Errorf("CacheKey() = %s for different generations", a)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqldb runs the SQL queries translated from Sparql queries.
+package sqldb
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/datacommonsorg/mixer/internal/translator/dialect"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Executor runs SQL queries on a database.
+type Executor interface {
+	// Dialect gets the SQL dialect of the database.
+	Dialect() dialect.Dialect
+	// DryRun gets the number of bytes a query would process, without running
+	// it. It is 0 if the database can not estimate it.
+	DryRun(ctx context.Context, sql string, params map[string]interface{}) (int64, error)
+	// Query runs a query and gets the rows of the result. The params are the
+	// values of the query parameters, each a string or a list of strings. The
+	// query fails if it would process more than maxBytes bytes, unless maxBytes
+	// is 0.
+	Query(ctx context.Context, sql string, params map[string]interface{}, maxBytes int64) (
+		[][]interface{}, error)
+	// ColumnTypes gets the types of the columns of a table, keyed by column name.
+	// The table is a path like "dc.Place". Columns of unknown type are missing.
+	ColumnTypes(ctx context.Context, table string) (map[string]bigquery.FieldType, error)
+}
+
+// BigQuery runs queries on BigQuery.
+type BigQuery struct {
+	client *bigquery.Client
+	// Column types of the tables, keyed by table path.
+	columnTypes sync.Map
+}
+
+// NewBigQuery creates a new BigQuery executor.
+func NewBigQuery(client *bigquery.Client) *BigQuery {
+	return &BigQuery{client: client}
+}
+
+// Dialect implements Executor.
+func (b *BigQuery) Dialect() dialect.Dialect {
+	return dialect.BigQuery{}
+}
+
+// query creates a BigQuery query with parameters.
+func (b *BigQuery) query(sql string, params map[string]interface{}) *bigquery.Query {
+	q := b.client.Query(sql)
+	names := []string{}
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		q.Parameters = append(q.Parameters, bigquery.QueryParameter{Name: name, Value: params[name]})
+	}
+	return q
+}
+
+// DryRun implements Executor.
+func (b *BigQuery) DryRun(ctx context.Context, sql string, params map[string]interface{}) (
+	int64, error) {
+	q := b.query(sql, params)
+	q.DryRun = true
+	job, err := q.Run(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stats := job.LastStatus().Statistics
+	if stats == nil {
+		return 0, status.Errorf(codes.Internal, "No statistics in the dry run of the query")
+	}
+	return stats.TotalBytesProcessed, nil
+}
+
+// Query implements Executor.
+func (b *BigQuery) Query(
+	ctx context.Context, sql string, params map[string]interface{}, maxBytes int64) (
+	[][]interface{}, error) {
+	q := b.query(sql, params)
+	q.MaxBytesBilled = maxBytes
+	it, err := q.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := [][]interface{}{}
+	for {
+		var row []bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, len(row))
+		for i, v := range row {
+			values[i] = v
+		}
+		result = append(result, values)
+	}
+	return result, nil
+}
+
+// ColumnTypes implements Executor. The schema of a table is read once.
+func (b *BigQuery) ColumnTypes(ctx context.Context, table string) (
+	map[string]bigquery.FieldType, error) {
+	if v, ok := b.columnTypes.Load(table); ok {
+		return v.(map[string]bigquery.FieldType), nil
+	}
+	var t *bigquery.Table
+	parts := strings.Split(table, ".")
+	switch len(parts) {
+	case 2:
+		t = b.client.Dataset(parts[0]).Table(parts[1])
+	case 3:
+		t = b.client.DatasetInProject(parts[0], parts[1]).Table(parts[2])
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid table: %s", table)
+	}
+	md, err := t.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]bigquery.FieldType{}
+	for _, f := range md.Schema {
+		result[f.Name] = f.Type
+	}
+	b.columnTypes.Store(table, result)
+	return result, nil
+}
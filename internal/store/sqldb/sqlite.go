@@ -0,0 +1,375 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/datacommonsorg/mixer/internal/translator/dialect"
+	"github.com/datacommonsorg/mixer/internal/translator/types"
+	"github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sqliteDriver is the SQLite driver with the functions used by the SQLite
+// dialect.
+const sqliteDriver = "sqlite3_mixer"
+
+// Compiled regular expressions, keyed by pattern.
+var regexpCache sync.Map
+
+func init() {
+	sql.Register(sqliteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := conn.RegisterFunc("regexp", regexpMatch, true); err != nil {
+				return err
+			}
+			return conn.RegisterFunc("in_param", inParam, true)
+		},
+	})
+}
+
+// regexpMatch implements the REGEXP operator of SQLite. NULL values do not
+// match.
+func regexpMatch(pattern, value interface{}) (bool, error) {
+	if pattern == nil || value == nil {
+		return false, nil
+	}
+	p := toString(pattern)
+	re, ok := regexpCache.Load(p)
+	if !ok {
+		compiled, err := regexp.Compile(p)
+		if err != nil {
+			return false, err
+		}
+		re, _ = regexpCache.LoadOrStore(p, compiled)
+	}
+	return re.(*regexp.Regexp).MatchString(toString(value)), nil
+}
+
+// inParam checks if a value is in a list parameter, which is a JSON array of
+// strings.
+func inParam(value interface{}, list string) (bool, error) {
+	if value == nil {
+		return false, nil
+	}
+	values := []string{}
+	if err := json.Unmarshal([]byte(list), &values); err != nil {
+		return false, err
+	}
+	v := toString(value)
+	for _, item := range values {
+		if item == v {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func toString(v interface{}) string {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// SQLite runs queries on an in-memory SQLite database, which has the tables
+// of the schema mapping.
+type SQLite struct {
+	db *sql.DB
+	// Column types of the tables, keyed by table path.
+	columnTypes map[string]map[string]bigquery.FieldType
+}
+
+// NewSQLite creates an in-memory SQLite database with the tables and columns
+// of the schema mapping.
+//
+// The rows of a table are loaded from the CSV file in dataDir named after the
+// table without the dataset, like "Place.csv" for table "dc.Place". The first
+// line of the file has the column names. Tables without a file are empty. The
+// type of a column is inferred from its values in the file.
+func NewSQLite(mappings []*types.Mapping, dataDir string) (*SQLite, error) {
+	db, err := sql.Open(sqliteDriver, ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	// Each connection has its own in-memory database.
+	db.SetMaxOpenConns(1)
+
+	d := dialect.SQLite{}
+	columnTypes := map[string]map[string]bigquery.FieldType{}
+	for path, cols := range getTableColumns(mappings) {
+		name := path[strings.LastIndex(path, ".")+1:]
+		file := filepath.Join(dataDir, name+".csv")
+		header, records, err := readCSV(path, cols, file)
+		if err != nil {
+			return nil, err
+		}
+		columnTypes[path] = map[string]bigquery.FieldType{}
+		for i, h := range header {
+			values := []string{}
+			for _, record := range records {
+				values = append(values, record[i])
+			}
+			if typ := inferColumnType(values); typ != "" {
+				columnTypes[path][h] = typ
+			}
+		}
+		defs := []string{}
+		for _, c := range cols {
+			// NUMERIC affinity stores numbers as numbers, and other values as
+			// text, so both can be compared as in BigQuery. Text columns keep
+			// values that look like numbers, like "06085", as text.
+			affinity := "NUMERIC"
+			switch columnTypes[path][c] {
+			case bigquery.StringFieldType, bigquery.BooleanFieldType:
+				affinity = "TEXT"
+			}
+			defs = append(defs, d.Ident(c)+" "+affinity)
+		}
+		_, err = db.Exec(fmt.Sprintf("CREATE TABLE %s (%s)", d.Table(path), strings.Join(defs, ", ")))
+		if err != nil {
+			return nil, err
+		}
+		if err := insertRows(db, path, header, records); err != nil {
+			return nil, err
+		}
+	}
+	return &SQLite{db: db, columnTypes: columnTypes}, nil
+}
+
+// inferColumnType gets the type of a column from its values in a CSV file. It is
+// INTEGER, FLOAT or BOOLEAN if all the values are, and STRING otherwise. Codes
+// with leading zeros, like "06085", are strings. The type is empty if the
+// column has no value.
+func inferColumnType(values []string) bigquery.FieldType {
+	isInt, isFloat, isBool, empty := true, true, true, true
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		empty = false
+		if len(v) > 1 && v[0] == '0' && v[1] != '.' {
+			isInt, isFloat = false, false
+		}
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			isInt = false
+		}
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			isFloat = false
+		}
+		if _, err := strconv.ParseBool(v); err != nil || isFloat {
+			isBool = false
+		}
+	}
+	switch {
+	case empty:
+		return ""
+	case isInt:
+		return bigquery.IntegerFieldType
+	case isFloat:
+		return bigquery.FloatFieldType
+	case isBool:
+		return bigquery.BooleanFieldType
+	default:
+		return bigquery.StringFieldType
+	}
+}
+
+// getTableColumns gets the columns of each table in the schema mapping,
+// keyed by table path.
+func getTableColumns(mappings []*types.Mapping) map[string][]string {
+	tables := map[string]map[string]struct{}{}
+	add := func(c types.Column) {
+		path := c.Table.Path()
+		if _, ok := tables[path]; !ok {
+			tables[path] = map[string]struct{}{}
+		}
+		tables[path][c.Name] = struct{}{}
+	}
+	for _, m := range mappings {
+		if c, ok := m.Pred.(types.Column); ok {
+			add(c)
+		}
+		if c, ok := m.Obj.(types.Column); ok {
+			add(c)
+		}
+	}
+	result := map[string][]string{}
+	for path, cols := range tables {
+		for c := range cols {
+			result[path] = append(result[path], c)
+		}
+		sort.Strings(result[path])
+	}
+	return result
+}
+
+// readCSV reads the header and the records of the CSV file of a table. There
+// is no record if the file does not exist.
+func readCSV(path string, cols []string, file string) ([]string, [][]string, error) {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	known := map[string]struct{}{}
+	for _, c := range cols {
+		known[c] = struct{}{}
+	}
+	for _, h := range header {
+		if _, ok := known[h]; !ok {
+			return nil, nil, status.Errorf(codes.InvalidArgument,
+				"Column %s in %s is not in the schema mapping of table %s", h, file, path)
+		}
+	}
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, records, nil
+}
+
+// insertRows inserts the records of a CSV file into a table. Empty values are
+// NULL.
+func insertRows(db *sql.DB, path string, header []string, records [][]string) error {
+	if len(header) == 0 {
+		return nil
+	}
+	d := dialect.SQLite{}
+	names := []string{}
+	params := []string{}
+	for _, h := range header {
+		names = append(names, d.Ident(h))
+		params = append(params, "?")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		d.Table(path), strings.Join(names, ", "), strings.Join(params, ", ")))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, record := range records {
+		values := make([]interface{}, len(record))
+		for i, v := range record {
+			if v != "" {
+				values[i] = v
+			}
+		}
+		if _, err := stmt.Exec(values...); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Dialect implements Executor.
+func (s *SQLite) Dialect() dialect.Dialect {
+	return dialect.SQLite{}
+}
+
+// DryRun implements Executor. SQLite does not estimate the cost of a query.
+func (s *SQLite) DryRun(ctx context.Context, query string, params map[string]interface{}) (
+	int64, error) {
+	return 0, nil
+}
+
+// Query implements Executor. The database is in memory, so maxBytes is not
+// used.
+func (s *SQLite) Query(
+	ctx context.Context, query string, params map[string]interface{}, maxBytes int64) (
+	[][]interface{}, error) {
+	args := []interface{}{}
+	for name, v := range params {
+		if list, ok := v.([]string); ok {
+			b, err := json.Marshal(list)
+			if err != nil {
+				return nil, err
+			}
+			v = string(b)
+		}
+		args = append(args, sql.Named(name, v))
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to run query: %v", err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := [][]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result = append(result, values)
+	}
+	return result, rows.Err()
+}
+
+// ColumnTypes implements Executor. The types are inferred from the CSV files.
+func (s *SQLite) ColumnTypes(ctx context.Context, table string) (
+	map[string]bigquery.FieldType, error) {
+	return s.columnTypes[table], nil
+}
+
+// Close closes the database.
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqldb
+
+import (
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func TestInferColumnType(t *testing.T) {
+	for _, c := range []struct {
+		values []string
+		want   bigquery.FieldType
+	}{
+		{[]string{"1", "", "-20"}, bigquery.IntegerFieldType},
+		{[]string{"1", "0.5", "0"}, bigquery.FloatFieldType},
+		{[]string{"true", "False"}, bigquery.BooleanFieldType},
+		{[]string{"1", "true"}, bigquery.StringFieldType},
+		{[]string{"06", "36"}, bigquery.StringFieldType},
+		{[]string{"geoId/06"}, bigquery.StringFieldType},
+		{[]string{"", ""}, ""},
+	} {
+		if got := inferColumnType(c.values); got != c.want {
+			t.Errorf("inferColumnType(%v) = %s, want %s", c.values, got, c.want)
+		}
+	}
+}
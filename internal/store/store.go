@@ -18,7 +18,9 @@ import (
 	"cloud.google.com/go/bigquery"
 	cbt "cloud.google.com/go/bigtable"
 	"github.com/datacommonsorg/mixer/internal/store/bigtable"
+	"github.com/datacommonsorg/mixer/internal/store/geo"
 	"github.com/datacommonsorg/mixer/internal/store/memdb"
+	"github.com/datacommonsorg/mixer/internal/store/sqldb"
 )
 
 // Store holds the handlers to BigQuery and Bigtable
@@ -26,6 +28,12 @@ type Store struct {
 	BqClient *bigquery.Client
 	MemDb    *memdb.MemDb
 	BtGroup  *bigtable.Group
+	// SQL database that serves Sparql queries.
+	SQLDb sqldb.Executor
+	// Cache of recent Sparql query results. Nil if disabled.
+	QueryCache *sqldb.Cache
+	// Index of place centroids to find nearby places. Nil if not loaded.
+	Centroids *geo.CentroidIndex
 }
 
 // NewStore creates a new store.
@@ -34,9 +42,13 @@ func NewStore(
 	memDb *memdb.MemDb,
 	baseTable *cbt.Table,
 	branchTable *cbt.Table) *Store {
-	return &Store{
+	s := &Store{
 		BqClient: bqClient,
 		MemDb:    memDb,
 		BtGroup:  bigtable.NewBigtableGroup(baseTable, branchTable),
 	}
+	if bqClient != nil {
+		s.SQLDb = sqldb.NewBigQuery(bqClient)
+	}
+	return s
 }
@@ -0,0 +1,134 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datacommonsorg/mixer/internal/translator/dialect"
+	"github.com/datacommonsorg/mixer/internal/translator/types"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// isGrouped checks if a query groups its results.
+func isGrouped(opts *types.QueryOptions) bool {
+	return len(opts.GroupBy) > 0 || len(opts.Aggregates) > 0
+}
+
+// compileAggregate translates an aggregate to SQL.
+func compileAggregate(
+	d dialect.Dialect, agg *types.Aggregate, resolve func(types.Node) (string, bool)) (
+	string, error) {
+	arg := "*"
+	if agg.Arg != nil {
+		var err error
+		arg, err = compileFilter(d, agg.Arg, resolve)
+		if err != nil {
+			return "", err
+		}
+		// Values can be stored as strings, like the object values of triples.
+		if agg.Func == "SUM" || agg.Func == "AVG" {
+			arg = d.ToFloat(arg)
+		}
+	}
+	if agg.Distinct {
+		if agg.Arg == nil {
+			return "", status.Errorf(codes.InvalidArgument, "COUNT(DISTINCT *) is not supported")
+		}
+		arg = "DISTINCT " + arg
+	}
+	return fmt.Sprintf("%s(%s)", agg.Func, arg), nil
+}
+
+// compileAggregates translates the aggregate projections of a query to SQL.
+func compileAggregates(resolve func(types.Node) (string, bool), opts *types.QueryOptions) (
+	map[types.Node]string, error) {
+	result := map[types.Node]string{}
+	d := sqlDialect(opts)
+	for n, agg := range opts.Aggregates {
+		sql, err := compileAggregate(d, agg, resolve)
+		if err != nil {
+			return nil, err
+		}
+		result[n] = sql
+	}
+	return result, nil
+}
+
+// getGroupBy builds the GROUP BY and HAVING clauses. The selected nodes that
+// are not aggregated should be grouped.
+func getGroupBy(
+	nodes []types.Node,
+	resolve func(types.Node) (string, bool),
+	aggs map[types.Node]string,
+	opts *types.QueryOptions) (string, error) {
+	if !isGrouped(opts) {
+		if len(opts.Having) > 0 {
+			return "", types.NewQueryError(
+				opts.Having[0].Pos, "", "HAVING should be used with GROUP BY or aggregates")
+		}
+		return "", nil
+	}
+	grouped := map[types.Node]struct{}{}
+	cols := []string{}
+	for i, n := range opts.GroupBy {
+		col, ok := resolve(n)
+		if !ok {
+			var pos types.Pos
+			if i < len(opts.GroupByPos) {
+				pos = opts.GroupByPos[i]
+			}
+			return "", types.NewQueryError(
+				pos, n.Alias, "Variable %s in GROUP BY is not bound", n.Alias)
+		}
+		grouped[n] = struct{}{}
+		cols = append(cols, col)
+	}
+	for _, n := range nodes {
+		if _, ok := aggs[n]; ok {
+			continue
+		}
+		if _, ok := grouped[n]; !ok {
+			return "", types.NewQueryError(
+				types.Pos{}, n.Alias, "Variable %s should be in GROUP BY or aggregated", n.Alias)
+		}
+	}
+
+	sql := ""
+	if len(cols) > 0 {
+		sql += " GROUP BY " + strings.Join(cols, ", ")
+	}
+	// HAVING can refer to the aggregate projections.
+	conds := []string{}
+	for _, f := range opts.Having {
+		cond, err := compileFilter(sqlDialect(opts), f, func(n types.Node) (string, bool) {
+			if agg, ok := aggs[n]; ok {
+				return agg, true
+			}
+			return resolve(n)
+		})
+		if err != nil {
+			return "", err
+		}
+		conds = append(conds, cond)
+	}
+	if len(conds) > 0 {
+		sql += " HAVING " + strings.Join(conds, " AND ")
+	}
+	return sql, nil
+}
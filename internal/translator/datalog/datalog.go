@@ -89,6 +89,8 @@ func ParseQuery(queryString string) ([]types.Node, []*types.Query, error) {
 		var query *types.Query
 		if strings.HasPrefix(terms[2], "?") {
 			query = types.NewQuery(terms[0], terms[1], types.NewNode(terms[2]))
+		} else if strings.HasPrefix(terms[2], "$") && len(terms) == 3 {
+			query = types.NewQuery(terms[0], terms[1], types.Param{Name: terms[2][1:]})
 		} else {
 			if len(terms) == 3 {
 				query = types.NewQuery(terms[0], terms[1], terms[2])
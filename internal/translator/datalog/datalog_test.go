@@ -122,6 +122,12 @@ func TestParseQuery(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"select ?a, dcid ?a $dcids",
+			[]types.Node{types.NewNode("?a")},
+			[]*types.Query{types.NewQuery("dcid", "?a", types.Param{Name: "dcids"})},
+			false,
+		},
 		{
 			`select ?a, name ?a "San Jose, CA" "SJ in CA"`,
 			[]types.Node{types.NewNode("?a")},
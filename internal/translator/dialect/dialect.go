@@ -0,0 +1,215 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dialect contains the SQL syntax of the databases the translator
+// generates queries for.
+package dialect
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Dialect builds the parts of a SQL query that differ between databases. The
+// arguments of the string functions are SQL expressions.
+type Dialect interface {
+	// Table references a table by its path, like "dc.Place".
+	Table(path string) string
+	// Column references a column of a table alias.
+	Column(alias, name string) string
+	// String quotes a string literal.
+	String(s string) string
+	// Regex checks if a string matches a regular expression.
+	Regex(s, pattern string, ignoreCase bool) string
+	// Contains checks if a string contains a substring.
+	Contains(s, sub string) string
+	// StartsWith checks if a string starts with a prefix.
+	StartsWith(s, prefix string) string
+	// EndsWith checks if a string ends with a suffix.
+	EndsWith(s, suffix string) string
+	// ToString converts a value to a string.
+	ToString(s string) string
+	// ToFloat converts a value to a float.
+	ToFloat(s string) string
+	// LimitOffset builds the LIMIT and OFFSET clauses. A limit or offset of 0
+	// is not set.
+	LimitOffset(limit, offset int) string
+	// Param references a query parameter with a single value.
+	Param(name string) string
+	// InParam checks if a value is in a query parameter with a list of values.
+	InParam(s, name string) string
+}
+
+// BigQuery is the dialect of BigQuery standard SQL.
+type BigQuery struct{}
+
+// Table implements Dialect.
+func (BigQuery) Table(path string) string {
+	return "`" + path + "`"
+}
+
+// Column implements Dialect. Column names like "c1.p" are struct fields.
+func (BigQuery) Column(alias, name string) string {
+	return alias + "." + name
+}
+
+// String implements Dialect.
+func (BigQuery) String(s string) string {
+	return strconv.Quote(s)
+}
+
+// Regex implements Dialect.
+func (BigQuery) Regex(s, pattern string, ignoreCase bool) string {
+	if ignoreCase {
+		return fmt.Sprintf(`REGEXP_CONTAINS(%s, CONCAT("(?i)", %s))`, s, pattern)
+	}
+	return fmt.Sprintf("REGEXP_CONTAINS(%s, %s)", s, pattern)
+}
+
+// Contains implements Dialect.
+func (BigQuery) Contains(s, sub string) string {
+	return fmt.Sprintf("STRPOS(%s, %s) > 0", s, sub)
+}
+
+// StartsWith implements Dialect.
+func (BigQuery) StartsWith(s, prefix string) string {
+	return fmt.Sprintf("STARTS_WITH(%s, %s)", s, prefix)
+}
+
+// EndsWith implements Dialect.
+func (BigQuery) EndsWith(s, suffix string) string {
+	return fmt.Sprintf("ENDS_WITH(%s, %s)", s, suffix)
+}
+
+// ToString implements Dialect.
+func (BigQuery) ToString(s string) string {
+	return fmt.Sprintf("CAST(%s AS STRING)", s)
+}
+
+// ToFloat implements Dialect. Values that are not numbers are NULL.
+func (BigQuery) ToFloat(s string) string {
+	return fmt.Sprintf("SAFE_CAST(%s AS FLOAT64)", s)
+}
+
+// LimitOffset implements Dialect.
+func (BigQuery) LimitOffset(limit, offset int) string {
+	sql := ""
+	if limit > 0 {
+		sql += fmt.Sprintf(" LIMIT %d", limit)
+	} else if offset > 0 {
+		// OFFSET can only be used with LIMIT.
+		sql += fmt.Sprintf(" LIMIT %d", math.MaxInt64)
+	}
+	if offset > 0 {
+		sql += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return sql
+}
+
+// Param implements Dialect.
+func (BigQuery) Param(name string) string {
+	return "@" + name
+}
+
+// InParam implements Dialect. The parameter is an array.
+func (BigQuery) InParam(s, name string) string {
+	return fmt.Sprintf("%s IN UNNEST(@%s)", s, name)
+}
+
+// SQLite is the dialect of SQLite. The REGEXP operator needs a "regexp"
+// function registered with the database connection, and list parameters need
+// an "in_param" function.
+type SQLite struct{}
+
+// Table implements Dialect. The whole path is one table name.
+func (d SQLite) Table(path string) string {
+	return d.Ident(path)
+}
+
+// Column implements Dialect.
+func (d SQLite) Column(alias, name string) string {
+	return alias + "." + d.Ident(name)
+}
+
+// String implements Dialect.
+func (SQLite) String(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Regex implements Dialect.
+func (SQLite) Regex(s, pattern string, ignoreCase bool) string {
+	if ignoreCase {
+		return fmt.Sprintf("%s REGEXP ('(?i)' || %s)", s, pattern)
+	}
+	return fmt.Sprintf("%s REGEXP %s", s, pattern)
+}
+
+// Contains implements Dialect.
+func (SQLite) Contains(s, sub string) string {
+	return fmt.Sprintf("INSTR(%s, %s) > 0", s, sub)
+}
+
+// StartsWith implements Dialect.
+func (SQLite) StartsWith(s, prefix string) string {
+	return fmt.Sprintf("SUBSTR(%s, 1, LENGTH(%s)) = %s", s, prefix, prefix)
+}
+
+// EndsWith implements Dialect.
+func (SQLite) EndsWith(s, suffix string) string {
+	return fmt.Sprintf(
+		"SUBSTR(%s, LENGTH(%s) - LENGTH(%s) + 1) = %s", s, s, suffix, suffix)
+}
+
+// ToString implements Dialect.
+func (SQLite) ToString(s string) string {
+	return fmt.Sprintf("CAST(%s AS TEXT)", s)
+}
+
+// ToFloat implements Dialect.
+func (SQLite) ToFloat(s string) string {
+	return fmt.Sprintf("CAST(%s AS REAL)", s)
+}
+
+// LimitOffset implements Dialect.
+func (SQLite) LimitOffset(limit, offset int) string {
+	sql := ""
+	if limit > 0 {
+		sql += fmt.Sprintf(" LIMIT %d", limit)
+	} else if offset > 0 {
+		// A negative limit means no limit.
+		sql += " LIMIT -1"
+	}
+	if offset > 0 {
+		sql += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return sql
+}
+
+// Param implements Dialect.
+func (SQLite) Param(name string) string {
+	return "@" + name
+}
+
+// InParam implements Dialect. SQLite has no array parameters, so the
+// parameter is a JSON array of strings, checked by the "in_param" function.
+func (SQLite) InParam(s, name string) string {
+	return fmt.Sprintf("in_param(%s, @%s)", s, name)
+}
+
+// Ident quotes an identifier, like a table or column name.
+func (SQLite) Ident(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"github.com/datacommonsorg/mixer/internal/translator/types"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bindParams gets the values of the query parameters used in a group
+// pattern. A parameter in a query statement is a list of values, and a
+// parameter in a filter is a single value.
+func bindParams(g *types.GroupPattern, opts *types.QueryOptions) (
+	map[string]interface{}, error) {
+	// Whether each parameter is used as a list.
+	isList := map[string]bool{}
+	use := func(p types.Param, list bool) error {
+		if prev, ok := isList[p.Name]; ok && prev != list {
+			return status.Errorf(codes.InvalidArgument,
+				"Parameter %s is used both as a list and as a single value", p)
+		}
+		isList[p.Name] = list
+		return nil
+	}
+	var walkFilter func(expr interface{}) error
+	walkFilter = func(expr interface{}) error {
+		switch v := expr.(type) {
+		case types.Param:
+			return use(v, false)
+		case *types.Filter:
+			for _, arg := range v.Args {
+				if err := walkFilter(arg); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	var walkGroup func(g *types.GroupPattern) error
+	walkGroup = func(g *types.GroupPattern) error {
+		for _, q := range g.Queries {
+			if p, ok := q.Obj.(types.Param); ok {
+				if err := use(p, true); err != nil {
+					return err
+				}
+			}
+		}
+		for _, f := range g.Filters {
+			if err := walkFilter(f); err != nil {
+				return err
+			}
+		}
+		for _, o := range g.Optionals {
+			if err := walkGroup(o); err != nil {
+				return err
+			}
+		}
+		for _, u := range g.Unions {
+			for _, b := range u {
+				if err := walkGroup(b); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walkGroup(g); err != nil {
+		return nil, err
+	}
+	for _, f := range opts.Having {
+		if err := walkFilter(f); err != nil {
+			return nil, err
+		}
+	}
+
+	result := map[string]interface{}{}
+	for name, list := range isList {
+		values, ok := opts.Params[name]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Parameter $%s is not set", name)
+		}
+		if list {
+			result[name] = values
+			continue
+		}
+		if len(values) != 1 {
+			return nil, status.Errorf(
+				codes.InvalidArgument, "Parameter $%s should have one value, got %d", name, len(values))
+		}
+		result[name] = values[0]
+	}
+	return result, nil
+}
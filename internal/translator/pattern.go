@@ -0,0 +1,435 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datacommonsorg/mixer/internal/translator/dialect"
+	"github.com/datacommonsorg/mixer/internal/translator/types"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nodeName gets the SQL column name of a node.
+func nodeName(n types.Node) string {
+	return strings.TrimPrefix(strings.ReplaceAll(n.Alias, "/", "_"), "?")
+}
+
+// nodeColumn gets the SQL expression of a node from the constraints.
+func nodeColumn(
+	d dialect.Dialect, n types.Node, constraints []Constraint, constNode map[types.Node]string) (
+	string, bool) {
+	if str, ok := constNode[n]; ok {
+		return d.String(str), true
+	}
+	for _, c := range constraints {
+		if n == c.RHS {
+			return column(d, c.LHS), true
+		}
+	}
+	return "", false
+}
+
+// compileFilter translates a filter expression to a SQL condition. The
+// resolve function gets the SQL expression of a node.
+func compileFilter(
+	d dialect.Dialect, expr interface{}, resolve func(types.Node) (string, bool)) (string, error) {
+	switch v := expr.(type) {
+	case types.Node:
+		col, ok := resolve(v)
+		if !ok {
+			// The position is that of the enclosing expression.
+			return "", types.NewQueryError(
+				types.Pos{}, v.Alias, "Variable %s in FILTER is not bound", v.Alias)
+		}
+		return col, nil
+	case *types.Aggregate:
+		return compileAggregate(d, v, resolve)
+	case types.Literal:
+		if v.IsString {
+			return d.String(v.Value), nil
+		}
+		return v.Value, nil
+	case types.Param:
+		return d.Param(v.Name), nil
+	case *types.Filter:
+		args := []string{}
+		for _, arg := range v.Args {
+			s, err := compileFilter(d, arg, resolve)
+			if err != nil {
+				if qe, ok := err.(*types.QueryError); ok && qe.Pos.Line == 0 {
+					qe.Pos = v.Pos
+				}
+				return "", err
+			}
+			args = append(args, s)
+		}
+		switch v.Op {
+		case "":
+			return args[0], nil
+		case "||":
+			return fmt.Sprintf("(%s OR %s)", args[0], args[1]), nil
+		case "&&":
+			return fmt.Sprintf("(%s AND %s)", args[0], args[1]), nil
+		case "!":
+			return fmt.Sprintf("NOT (%s)", args[0]), nil
+		case "=", "!=", "<", "<=", ">", ">=":
+			return fmt.Sprintf("%s %s %s", args[0], v.Op, args[1]), nil
+		case "REGEX":
+			if len(args) == 3 {
+				flags, ok := v.Args[2].(types.Literal)
+				if !ok || flags.Value != "i" {
+					return "", types.NewQueryError(
+						v.Pos, v.Op, "Only the \"i\" flag is supported for REGEX")
+				}
+				return d.Regex(args[0], args[1], true), nil
+			}
+			return d.Regex(args[0], args[1], false), nil
+		case "CONTAINS":
+			return d.Contains(args[0], args[1]), nil
+		case "STRSTARTS":
+			return d.StartsWith(args[0], args[1]), nil
+		case "STRENDS":
+			return d.EndsWith(args[0], args[1]), nil
+		case "STR":
+			return d.ToString(args[0]), nil
+		case "LCASE":
+			return fmt.Sprintf("LOWER(%s)", args[0]), nil
+		case "UCASE":
+			return fmt.Sprintf("UPPER(%s)", args[0]), nil
+		}
+		return "", types.NewQueryError(v.Pos, v.Op, "Unsupported FILTER operator %s", v.Op)
+	}
+	return "", status.Errorf(codes.InvalidArgument, "Invalid FILTER expression %v", expr)
+}
+
+// requiredVars gets the variables that a group pattern binds in every row,
+// which are those of its statements and those of all the branches of its
+// unions.
+func requiredVars(g *types.GroupPattern) map[types.Node]struct{} {
+	result := map[types.Node]struct{}{}
+	for _, n := range groupVars(&types.GroupPattern{Queries: g.Queries}) {
+		result[n] = struct{}{}
+	}
+	for _, u := range g.Unions {
+		count := map[types.Node]int{}
+		for _, b := range u {
+			for n := range requiredVars(b) {
+				count[n]++
+			}
+		}
+		for n, c := range count {
+			if c == len(u) {
+				result[n] = struct{}{}
+			}
+		}
+	}
+	return result
+}
+
+// groupVars gets the variables of a group pattern, in order of appearance.
+func groupVars(g *types.GroupPattern) []types.Node {
+	result := []types.Node{}
+	seen := map[types.Node]struct{}{}
+	add := func(n types.Node) {
+		if _, ok := seen[n]; !ok && strings.HasPrefix(n.Alias, "?") {
+			seen[n] = struct{}{}
+			result = append(result, n)
+		}
+	}
+	for _, q := range g.Queries {
+		add(q.Sub)
+		if n, ok := q.Obj.(types.Node); ok {
+			add(n)
+		}
+	}
+	for _, u := range g.Unions {
+		for _, b := range u {
+			for _, n := range groupVars(b) {
+				add(n)
+			}
+		}
+	}
+	for _, o := range g.Optionals {
+		for _, n := range groupVars(o) {
+			add(n)
+		}
+	}
+	return result
+}
+
+// groupTranslator translates group patterns with optional groups and unions.
+type groupTranslator struct {
+	dialect     dialect.Dialect
+	mappings    []*types.Mapping
+	subTypeMap  map[string]string
+	bindings    []Binding
+	constraints []Constraint
+}
+
+// translateGroup translates a group pattern with optional groups or unions.
+//
+// Each group is translated to a subquery that selects its variables. The
+// subqueries are joined on their shared variables: the alternatives of a union
+// are combined with UNION ALL and joined with JOIN, and optional groups are
+// joined with LEFT JOIN. Provenance is not queried in this case.
+func translateGroup(
+	mappings []*types.Mapping, nodes []types.Node, group *types.GroupPattern,
+	subTypeMap map[string]string, opts *types.QueryOptions) (*Translation, error) {
+	t := &groupTranslator{
+		dialect: sqlDialect(opts), mappings: mappings, subTypeMap: subTypeMap}
+	sql, err := t.groupSQL(group, nodes, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Translation{sql, nodes, t.bindings, t.constraints, map[int][]int{}, nil}, nil
+}
+
+// groupSQL builds the SQL query of a group pattern that selects the given
+// variables, with the query options applied.
+func (t *groupTranslator) groupSQL(
+	g *types.GroupPattern, vars []types.Node, opts *types.QueryOptions) (string, error) {
+	if opts == nil {
+		opts = &types.QueryOptions{Dialect: t.dialect}
+	}
+	var (
+		resolve func(types.Node) (string, bool)
+		from    string
+		conds   []string
+		err     error
+	)
+	if len(g.Optionals) == 0 && len(g.Unions) == 0 {
+		resolve, from, conds, err = t.simpleSQL(g)
+	} else {
+		resolve, from, conds, err = t.compositeSQL(g)
+	}
+	if err != nil {
+		return "", err
+	}
+	aggs, err := compileAggregates(resolve, opts)
+	if err != nil {
+		return "", err
+	}
+	cols := []string{}
+	for _, n := range vars {
+		col, ok := aggs[n]
+		if !ok {
+			if col, ok = resolve(n); !ok {
+				col = "NULL"
+			}
+		}
+		cols = append(cols, fmt.Sprintf("%s AS %s", col, nodeName(n)))
+	}
+	sql := "SELECT"
+	if opts.Distinct {
+		sql += " DISTINCT"
+	}
+	sql += " " + strings.Join(cols, ", ") + from
+	if len(conds) > 0 {
+		sql += " WHERE " + strings.Join(conds, " AND ")
+	}
+	groupBy, err := getGroupBy(vars, resolve, aggs, opts)
+	if err != nil {
+		return "", err
+	}
+	return sql + groupBy + getOrderLimit(t.dialect, opts), nil
+}
+
+// simpleSQL builds the parts of the SQL query of a group pattern that only
+// has query statements and filters.
+func (t *groupTranslator) simpleSQL(g *types.GroupPattern) (
+	func(types.Node) (string, bool), string, []string, error) {
+	if len(g.Queries) == 0 {
+		return nil, "", nil, status.Errorf(
+			codes.InvalidArgument, "Group pattern should have at least one triple")
+	}
+	bindings, constraints, constNode, err := resolveConstraints(
+		t.mappings, g.Queries, t.subTypeMap)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	t.bindings = append(t.bindings, bindings...)
+	t.constraints = append(t.constraints, constraints...)
+	resolve := func(n types.Node) (string, bool) {
+		return nodeColumn(t.dialect, n, constraints, constNode)
+	}
+	from, conds := getFromWhere(t.dialect, constraints)
+	for _, f := range g.Filters {
+		cond, err := compileFilter(t.dialect, f, resolve)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		conds = append(conds, cond)
+	}
+	return resolve, from, conds, nil
+}
+
+// compositeSQL builds the parts of the SQL query of a group pattern that has
+// optional groups or unions, by joining the subqueries of its parts.
+func (t *groupTranslator) compositeSQL(g *types.GroupPattern) (
+	func(types.Node) (string, bool), string, []string, error) {
+	// Alias of the first subquery that binds each variable in every row, and
+	// the aliases of the subqueries that may leave it unbound, as NULL.
+	boundTable := map[types.Node]string{}
+	nullTables := map[types.Node][]string{}
+	resolve := func(n types.Node) (string, bool) {
+		if alias, ok := boundTable[n]; ok {
+			return fmt.Sprintf("%s.%s", alias, nodeName(n)), true
+		}
+		cols := []string{}
+		for _, alias := range nullTables[n] {
+			cols = append(cols, fmt.Sprintf("%s.%s", alias, nodeName(n)))
+		}
+		switch len(cols) {
+		case 0:
+			return "", false
+		case 1:
+			return cols[0], true
+		}
+		return fmt.Sprintf("COALESCE(%s)", strings.Join(cols, ", ")), true
+	}
+	from := ""
+	conds := []string{}
+	count := 0
+
+	// join adds a subquery to the FROM clause. The required variables are
+	// bound in every row of the subquery. The filters are added to the join
+	// condition.
+	join := func(
+		sql string,
+		subVars []types.Node,
+		required map[types.Node]struct{},
+		joinType string,
+		filters []*types.Filter,
+	) error {
+		alias := fmt.Sprintf("_g%d", count)
+		count++
+		on := []string{}
+		for _, n := range subVars {
+			col, ok := resolve(n)
+			if !ok {
+				continue
+			}
+			subCol := fmt.Sprintf("%s.%s", alias, nodeName(n))
+			_, isBound := boundTable[n]
+			_, isRequired := required[n]
+			if isBound && isRequired {
+				on = append(on, fmt.Sprintf("%s = %s", col, subCol))
+			} else {
+				// An unbound variable is compatible with any value. Plain equality
+				// is kept for the other variables, as outer joins in BigQuery need it.
+				on = append(on, fmt.Sprintf(
+					"(%s = %s OR %s IS NULL OR %s IS NULL)", col, subCol, col, subCol))
+			}
+		}
+		subTable := map[types.Node]struct{}{}
+		for _, n := range subVars {
+			subTable[n] = struct{}{}
+		}
+		for _, f := range filters {
+			cond, err := compileFilter(t.dialect, f, func(n types.Node) (string, bool) {
+				if _, ok := subTable[n]; ok {
+					return fmt.Sprintf("%s.%s", alias, nodeName(n)), true
+				}
+				return resolve(n)
+			})
+			if err != nil {
+				return err
+			}
+			on = append(on, cond)
+		}
+		if from == "" && joinType != "JOIN" {
+			// A group without required parts has one solution when its optional
+			// parts do not match, so they are joined onto a table of one row.
+			from = " FROM (SELECT 1) AS _unit"
+		}
+		if from == "" {
+			from = fmt.Sprintf(" FROM (%s) AS %s", sql, alias)
+			conds = append(conds, on...)
+		} else if len(on) == 0 && joinType == "JOIN" {
+			from += fmt.Sprintf(" CROSS JOIN (%s) AS %s", sql, alias)
+		} else if len(on) == 0 {
+			from += fmt.Sprintf(" %s (%s) AS %s ON TRUE", joinType, sql, alias)
+		} else {
+			from += fmt.Sprintf(
+				" %s (%s) AS %s ON %s", joinType, sql, alias, strings.Join(on, " AND "))
+		}
+		for _, n := range subVars {
+			if _, ok := boundTable[n]; ok {
+				continue
+			}
+			if _, ok := required[n]; ok && joinType == "JOIN" {
+				boundTable[n] = alias
+			} else {
+				nullTables[n] = append(nullTables[n], alias)
+			}
+		}
+		return nil
+	}
+
+	if len(g.Queries) > 0 {
+		main := &types.GroupPattern{Queries: g.Queries}
+		mainVars := groupVars(main)
+		sql, err := t.groupSQL(main, mainVars, nil)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		if err := join(sql, mainVars, requiredVars(main), "JOIN", nil); err != nil {
+			return nil, "", nil, err
+		}
+	}
+	for _, u := range g.Unions {
+		union := &types.GroupPattern{Unions: [][]*types.GroupPattern{u}}
+		unionVars := groupVars(union)
+		branches := []string{}
+		for _, b := range u {
+			sql, err := t.groupSQL(b, unionVars, nil)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			branches = append(branches, sql)
+		}
+		err := join(
+			strings.Join(branches, " UNION ALL "), unionVars, requiredVars(union), "JOIN", nil)
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+	for _, o := range g.Optionals {
+		// Filters of an optional group are part of the join condition, so they
+		// can refer to the variables of the enclosing group.
+		opt := &types.GroupPattern{Queries: o.Queries, Optionals: o.Optionals, Unions: o.Unions}
+		optVars := groupVars(opt)
+		sql, err := t.groupSQL(opt, optVars, nil)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		if err := join(sql, optVars, requiredVars(opt), "LEFT JOIN", o.Filters); err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	for _, f := range g.Filters {
+		cond, err := compileFilter(t.dialect, f, resolve)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		conds = append(conds, cond)
+	}
+	return resolve, from, conds, nil
+}
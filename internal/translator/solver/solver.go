@@ -67,11 +67,11 @@ func GetNodeType(queries []*types.Query) (map[string]string, error) {
 	for _, q := range queries {
 		if q.Pred == tmcf.TypeOf {
 			if _, ok := result[q.Sub.Alias]; ok {
-				return nil, status.Error(codes.InvalidArgument, "Duplicate select node type")
+				return nil, types.NewStatementError(q, "Duplicate select node type")
 			}
 			if _, ok := q.Obj.(string); !ok {
-				return nil, status.Errorf(
-					codes.InvalidArgument, "Node should be string, got %s of type %T", q.Obj, q.Obj)
+				return nil, types.NewStatementError(
+					q, "Node should be string, got %s of type %T", q.Obj, q.Obj)
 			}
 			result[q.Sub.Alias] = q.Obj.(string)
 		}
@@ -282,8 +282,13 @@ func RewriteQuery(queries []*types.Query, subTypeMap map[string]string) []*types
 			continue
 		}
 		in := typeOfNodeInfo[n]
-		res[in.pos] = types.NewQuery(tmcf.TypeOf, n.Alias, subTypeMap[in.t])
-		res = append(res, types.NewQuery("subType", n.Alias, in.t))
+		typeOf := types.NewQuery(tmcf.TypeOf, n.Alias, subTypeMap[in.t])
+		subType := types.NewQuery("subType", n.Alias, in.t)
+		// Keep the position of the original statement for error reporting.
+		typeOf.Pos = res[in.pos].Pos
+		subType.Pos = res[in.pos].Pos
+		res[in.pos] = typeOf
+		res = append(res, subType)
 	}
 	return res
 }
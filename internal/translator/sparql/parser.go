@@ -18,6 +18,9 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
+
+	"github.com/datacommonsorg/mixer/internal/translator/types"
 )
 
 // ParseError represents an error that occurred during parsing.
@@ -28,6 +31,16 @@ type ParseError struct {
 	Pos      Pos
 }
 
+func (e *ParseError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if len(e.Expected) == 0 {
+		return fmt.Sprintf("Unexpected %s", e.Found)
+	}
+	return fmt.Sprintf("Unexpected %s, expected %s", e.Found, strings.Join(e.Expected, " or "))
+}
+
 // newParseError returns a new instance of ParseError.
 func newParseError(found string, expected []string, pos Pos) *ParseError {
 	return &ParseError{Found: found, Expected: expected, Pos: pos}
@@ -40,6 +53,13 @@ type QueryTree struct {
 	W *Where
 	O *Orderby
 	L int
+	// GROUP BY variables.
+	G []string
+	// Position of each GROUP BY variable.
+	gPos []Pos
+	// HAVING conditions.
+	H      []*types.Filter
+	Offset int
 }
 
 // Prologue represents query prologue information
@@ -52,6 +72,8 @@ type Prologue struct {
 type Select struct {
 	Variable []string
 	Distinct bool
+	// Aggregate projections, keyed by their variable.
+	Aggregates map[string]*types.Aggregate
 }
 
 // Triple reprensts a triple in Sparql query.
@@ -61,9 +83,47 @@ type Triple struct {
 	Objs []string
 }
 
-// Where represents the where condition in Sparql query.
+// Where represents the where condition in Sparql query, which is a group
+// graph pattern.
 type Where struct {
 	Triples []Triple
+	// Position of each triple in the query.
+	pos       []Pos
+	Filters   []*types.Filter
+	Optionals []*Where
+	// Each union is a list of alternative groups.
+	Unions [][]*Where
+}
+
+// filterFuncs maps the supported filter functions to their number of
+// arguments. REGEX takes an optional flags argument.
+var filterFuncs = map[string][]int{
+	"CONTAINS":  {2},
+	"LCASE":     {1},
+	"REGEX":     {2, 3},
+	"STR":       {1},
+	"STRENDS":   {2},
+	"STRSTARTS": {2},
+	"UCASE":     {1},
+}
+
+// aggregateFuncs contains the supported aggregate functions.
+var aggregateFuncs = map[string]struct{}{
+	"AVG":   {},
+	"COUNT": {},
+	"MAX":   {},
+	"MIN":   {},
+	"SUM":   {},
+}
+
+// compareOps maps comparison tokens to filter operators.
+var compareOps = map[Token]string{
+	EQ:  "=",
+	NEQ: "!=",
+	LT:  "<",
+	LTE: "<=",
+	GT:  ">",
+	GTE: ">=",
 }
 
 // Orderby represents the order by condition.
@@ -139,6 +199,18 @@ func (p *Parser) parseSelect() (*Select, *ParseError) {
 			p.Unscan()
 			return &result, nil
 		}
+		if tok == LPAREN {
+			variable, agg, err := p.parseProjection()
+			if err != nil {
+				return nil, err
+			}
+			if result.Aggregates == nil {
+				result.Aggregates = map[string]*types.Aggregate{}
+			}
+			result.Variable = append(result.Variable, variable)
+			result.Aggregates[variable] = agg
+			continue
+		}
 		if tok != VARIABLE {
 			return nil, newParseError(tokstr(tok, lit), []string{"?..."}, pos)
 		}
@@ -146,45 +218,120 @@ func (p *Parser) parseSelect() (*Select, *ParseError) {
 	}
 }
 
+// parseProjection parses an aggregate projection like (COUNT(?a) AS ?count),
+// after the opening parenthesis.
+func (p *Parser) parseProjection() (string, *types.Aggregate, *ParseError) {
+	_, pos, _ := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	expr, err := p.parseOr()
+	if err != nil {
+		return "", nil, err
+	}
+	agg, ok := expr.(*types.Aggregate)
+	if !ok {
+		return "", nil, newParseError(fmt.Sprintf("%v", expr), []string{"aggregate"}, pos)
+	}
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != AS {
+		return "", nil, newParseError(tokstr(tok, lit), []string{"AS"}, pos)
+	}
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok != VARIABLE {
+		return "", nil, newParseError(tokstr(tok, lit), []string{"?..."}, pos)
+	}
+	variable := lit
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != RPAREN {
+		return "", nil, newParseError(tokstr(tok, lit), []string{")"}, pos)
+	}
+	return variable, agg, nil
+}
+
 func (p *Parser) parseWhere() (*Where, *ParseError) {
-	result := Where{}
 	tok, pos, lit := p.ScanIgnoreWhitespace()
 	if tok != WHERE {
 		return nil, newParseError(tokstr(tok, lit), []string{"Where"}, pos)
 	}
-	tok, pos, lit = p.ScanIgnoreWhitespace()
+	return p.parseGroup()
+}
+
+// parseGroup parses a group graph pattern enclosed in braces.
+func (p *Parser) parseGroup() (*Where, *ParseError) {
+	result := Where{}
+	tok, pos, lit := p.ScanIgnoreWhitespace()
 	if tok != LBRAC {
 		return nil, newParseError(tokstr(tok, lit), []string{"{"}, pos)
 	}
 	var sub string
 	var pred string
 	var objs []string
+	var subPos Pos
 	idx := 0
-	for {
-		tok, _, lit := p.ScanIgnoreWhitespace()
-		if tok == EOF {
-			return nil, newParseError(tokstr(tok, lit), []string{"}"}, pos)
+	endTriple := func() {
+		if sub != "" && pred != "" {
+			result.Triples = append(result.Triples, Triple{sub, pred, objs})
+			result.pos = append(result.pos, subPos)
 		}
-		if tok == RBRAC {
-			if sub != "" && pred != "" {
-				result.Triples = append(result.Triples, Triple{sub, pred, objs})
-			}
+		idx = 0
+		sub = ""
+		pred = ""
+		objs = []string{}
+	}
+	for {
+		tok, tokPos, lit := p.ScanIgnoreWhitespace()
+		switch tok {
+		case EOF:
+			return nil, newParseError(tokstr(tok, lit), []string{"}"}, tokPos)
+		case RBRAC:
+			endTriple()
 			return &result, nil
-		}
-		if tok == DOT {
-			result.Triples = append(result.Triples, Triple{sub, pred, objs})
-			idx = 0
-			sub = ""
-			pred = ""
-			objs = []string{}
+		case DOT:
+			endTriple()
 			continue
-		}
-		if tok == LPAREN || tok == RPAREN {
+		case LPAREN, RPAREN:
+			continue
+		case FILTER:
+			endTriple()
+			_, pos, _ := p.ScanIgnoreWhitespace()
+			p.Unscan()
+			filter, err := p.parseFilter()
+			if err != nil {
+				return nil, err
+			}
+			if hasAggregate(filter) {
+				return nil, &ParseError{Message: "Aggregates are not allowed in FILTER", Pos: pos}
+			}
+			result.Filters = append(result.Filters, filter)
+			continue
+		case OPTIONAL:
+			endTriple()
+			group, err := p.parseGroup()
+			if err != nil {
+				return nil, err
+			}
+			result.Optionals = append(result.Optionals, group)
+			continue
+		case LBRAC:
+			endTriple()
+			p.Unscan()
+			groups, err := p.parseUnion()
+			if err != nil {
+				return nil, err
+			}
+			if len(groups) > 1 {
+				result.Unions = append(result.Unions, groups)
+			} else {
+				// A nested group without UNION is joined with the enclosing group.
+				result.Triples = append(result.Triples, groups[0].Triples...)
+				result.pos = append(result.pos, groups[0].pos...)
+				result.Filters = append(result.Filters, groups[0].Filters...)
+				result.Optionals = append(result.Optionals, groups[0].Optionals...)
+				result.Unions = append(result.Unions, groups[0].Unions...)
+			}
 			continue
 		}
 		switch idx {
 		case 0:
 			sub = lit
+			subPos = tokPos
 			idx++
 		case 1:
 			pred = lit
@@ -193,11 +340,315 @@ func (p *Parser) parseWhere() (*Where, *ParseError) {
 			if tok == STRING {
 				lit = fmt.Sprintf(`"%s"`, lit)
 			}
+			// A parameter has its own list of values.
+			if len(objs) > 0 && (tok == PARAMETER || strings.HasPrefix(objs[0], "$")) {
+				return nil, &ParseError{
+					Message: "A parameter can not be used in a list of objects", Pos: tokPos}
+			}
 			objs = append(objs, lit)
 		}
 	}
 }
 
+// parseUnion parses groups separated by UNION.
+func (p *Parser) parseUnion() ([]*Where, *ParseError) {
+	result := []*Where{}
+	for {
+		group, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, group)
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != UNION {
+			p.Unscan()
+			return result, nil
+		}
+	}
+}
+
+// parseFilter parses the constraint following FILTER, which is either an
+// expression in parentheses or a function call.
+func (p *Parser) parseFilter() (*types.Filter, *ParseError) {
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok == IDENT {
+		return p.parseFunc(lit, pos)
+	}
+	if tok != LPAREN {
+		return nil, newParseError(tokstr(tok, lit), []string{"(", "function"}, pos)
+	}
+	exprPos := p.peekPos()
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != RPAREN {
+		return nil, newParseError(tokstr(tok, lit), []string{")"}, pos)
+	}
+	return toFilter(expr, exprPos), nil
+}
+
+// toFilter wraps a term of an expression at a position as a filter.
+func toFilter(expr interface{}, pos Pos) *types.Filter {
+	if f, ok := expr.(*types.Filter); ok {
+		return f
+	}
+	return &types.Filter{Args: []interface{}{expr}, Pos: queryPos(pos)}
+}
+
+// queryPos converts a position of the scanner, which starts at 0, to a
+// position of the query.
+func queryPos(pos Pos) types.Pos {
+	return types.Pos{Line: pos.Line + 1, Column: pos.Char + 1}
+}
+
+// peekPos gets the position of the next token.
+func (p *Parser) peekPos() Pos {
+	_, pos, _ := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	return pos
+}
+
+// hasAggregate checks if an expression has an aggregate.
+func hasAggregate(expr interface{}) bool {
+	switch v := expr.(type) {
+	case *types.Aggregate:
+		return true
+	case *types.Filter:
+		for _, arg := range v.Args {
+			if hasAggregate(arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *Parser) parseOr() (interface{}, *ParseError) {
+	pos := p.peekPos()
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != OR {
+			p.Unscan()
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &types.Filter{Op: "||", Args: []interface{}{left, right}, Pos: queryPos(pos)}
+	}
+}
+
+func (p *Parser) parseAnd() (interface{}, *ParseError) {
+	pos := p.peekPos()
+	left, err := p.parseRelational()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != AND {
+			p.Unscan()
+			return left, nil
+		}
+		right, err := p.parseRelational()
+		if err != nil {
+			return nil, err
+		}
+		left = &types.Filter{Op: "&&", Args: []interface{}{left, right}, Pos: queryPos(pos)}
+	}
+}
+
+func (p *Parser) parseRelational() (interface{}, *ParseError) {
+	pos := p.peekPos()
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	tok, _, _ := p.ScanIgnoreWhitespace()
+	op, ok := compareOps[tok]
+	if !ok {
+		p.Unscan()
+		return left, nil
+	}
+	right, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &types.Filter{Op: op, Args: []interface{}{left, right}, Pos: queryPos(pos)}, nil
+}
+
+func (p *Parser) parseUnary() (interface{}, *ParseError) {
+	tok, pos, _ := p.ScanIgnoreWhitespace()
+	if tok != NOT {
+		p.Unscan()
+		return p.parsePrimary()
+	}
+	expr, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &types.Filter{Op: "!", Args: []interface{}{expr}, Pos: queryPos(pos)}, nil
+}
+
+func (p *Parser) parsePrimary() (interface{}, *ParseError) {
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch tok {
+	case LPAREN:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != RPAREN {
+			return nil, newParseError(tokstr(tok, lit), []string{")"}, pos)
+		}
+		return expr, nil
+	case VARIABLE:
+		return types.NewNode(lit), nil
+	case PARAMETER:
+		return types.Param{Name: strings.TrimPrefix(lit, "$")}, nil
+	case STRING:
+		return types.Literal{Value: lit, IsString: true}, nil
+	case NUMBER:
+		return types.Literal{Value: lit}, nil
+	case TRUE, FALSE:
+		return types.Literal{Value: tok.String()}, nil
+	case IDENT:
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok == LPAREN {
+			p.Unscan()
+			if _, ok := aggregateFuncs[strings.ToUpper(lit)]; ok {
+				return p.parseAggregate(lit)
+			}
+			return p.parseFunc(lit, pos)
+		}
+		p.Unscan()
+		// Bare identifiers like dcids are compared as strings.
+		return types.Literal{Value: lit, IsString: true}, nil
+	}
+	return nil, newParseError(
+		tokstr(tok, lit), []string{"(", "?...", "$...", "STRING", "NUMBER", "function"}, pos)
+}
+
+// parseFunc parses the arguments of a function call.
+func (p *Parser) parseFunc(name string, pos Pos) (*types.Filter, *ParseError) {
+	fn := strings.ToUpper(name)
+	arity, ok := filterFuncs[fn]
+	if !ok {
+		return nil, newParseError(name, []string{"function"}, pos)
+	}
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != LPAREN {
+		return nil, newParseError(tokstr(tok, lit), []string{"("}, pos)
+	}
+	result := &types.Filter{Op: fn, Pos: queryPos(pos)}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		result.Args = append(result.Args, arg)
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		if tok == RPAREN {
+			break
+		}
+		if tok != COMMA {
+			return nil, newParseError(tokstr(tok, lit), []string{",", ")"}, pos)
+		}
+	}
+	for _, n := range arity {
+		if len(result.Args) == n {
+			return result, nil
+		}
+	}
+	return nil, &ParseError{
+		Message: fmt.Sprintf("Wrong number of arguments for %s", fn),
+		Found:   name,
+		Pos:     pos,
+	}
+}
+
+// parseAggregate parses the arguments of an aggregate function call.
+func (p *Parser) parseAggregate(name string) (*types.Aggregate, *ParseError) {
+	result := &types.Aggregate{Func: strings.ToUpper(name)}
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != LPAREN {
+		return nil, newParseError(tokstr(tok, lit), []string{"("}, pos)
+	}
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok == DISTINCT {
+		result.Distinct = true
+		tok, pos, lit = p.ScanIgnoreWhitespace()
+	}
+	if tok == MUL {
+		if result.Func != "COUNT" {
+			return nil, newParseError(tokstr(tok, lit), []string{"?..."}, pos)
+		}
+	} else {
+		p.Unscan()
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		result.Arg = arg
+	}
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != RPAREN {
+		return nil, newParseError(tokstr(tok, lit), []string{")"}, pos)
+	}
+	return result, nil
+}
+
+// parseGroupBy parses the GROUP BY variables and their positions.
+func (p *Parser) parseGroupBy() ([]string, []Pos, *ParseError) {
+	tok, _, _ := p.ScanIgnoreWhitespace()
+	if tok != GROUP {
+		p.Unscan()
+		return nil, nil, nil
+	}
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok != BY {
+		return nil, nil, newParseError(tokstr(tok, lit), []string{"BY"}, pos)
+	}
+	result := []string{}
+	positions := []Pos{}
+	for {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		if tok != VARIABLE {
+			if len(result) == 0 {
+				return nil, nil, newParseError(tokstr(tok, lit), []string{"?..."}, pos)
+			}
+			p.Unscan()
+			return result, positions, nil
+		}
+		result = append(result, lit)
+		positions = append(positions, pos)
+	}
+}
+
+func (p *Parser) parseHaving() ([]*types.Filter, *ParseError) {
+	tok, _, _ := p.ScanIgnoreWhitespace()
+	if tok != HAVING {
+		p.Unscan()
+		return nil, nil
+	}
+	result := []*types.Filter{}
+	for {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		if tok != LPAREN && tok != IDENT {
+			if len(result) == 0 {
+				return nil, newParseError(tokstr(tok, lit), []string{"("}, pos)
+			}
+			p.Unscan()
+			return result, nil
+		}
+		p.Unscan()
+		filter, err := p.parseFilter()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, filter)
+	}
+}
+
 func (p *Parser) parseOrderBy() (*Orderby, *ParseError) {
 	varString := ""
 	asc := true
@@ -258,6 +709,38 @@ func (p *Parser) parseLimit() (int, *ParseError) {
 	return limit, nil
 }
 
+// parseLimitOffset parses LIMIT and OFFSET, which can come in either order.
+func (p *Parser) parseLimitOffset() (int, int, *ParseError) {
+	limit := 0
+	offset := 0
+	for {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		switch tok {
+		case EOF:
+			return limit, offset, nil
+		case LIMIT:
+			p.Unscan()
+			var err *ParseError
+			limit, err = p.parseLimit()
+			if err != nil {
+				return 0, 0, err
+			}
+		case OFFSET:
+			tok, pos, lit = p.ScanIgnoreWhitespace()
+			if tok != NUMBER {
+				return 0, 0, newParseError(tokstr(tok, lit), []string{"NUMBER"}, pos)
+			}
+			var err error
+			offset, err = strconv.Atoi(lit)
+			if err != nil {
+				return 0, 0, newParseError(tokstr(tok, lit), []string{"NUMBER"}, pos)
+			}
+		default:
+			return 0, 0, newParseError(tokstr(tok, lit), []string{"LIMIT", "OFFSET"}, pos)
+		}
+	}
+}
+
 // Parse parses sparql query into syntax tree.
 func (p *Parser) Parse() (*QueryTree, *ParseError) {
 	prologue, err := p.parsePrologue()
@@ -272,15 +755,26 @@ func (p *Parser) Parse() (*QueryTree, *ParseError) {
 	if err != nil {
 		return nil, err
 	}
+	groupBy, groupByPos, err := p.parseGroupBy()
+	if err != nil {
+		return nil, err
+	}
+	having, err := p.parseHaving()
+	if err != nil {
+		return nil, err
+	}
 	orderby, err := p.parseOrderBy()
 	if err != nil {
 		return nil, err
 	}
-	limit, err := p.parseLimit()
+	limit, offset, err := p.parseLimitOffset()
 	if err != nil {
 		return nil, err
 	}
-	return &QueryTree{P: prologue, S: sel, W: where, O: orderby, L: limit}, nil
+	return &QueryTree{
+		P: prologue, S: sel, W: where, O: orderby, L: limit, G: groupBy, gPos: groupByPos,
+		H: having, Offset: offset,
+	}, nil
 }
 
 // Scan returns the next token from the underlying scanner.
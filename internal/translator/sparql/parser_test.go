@@ -18,6 +18,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/datacommonsorg/mixer/internal/translator/types"
 	"github.com/go-test/deep"
 )
 
@@ -73,15 +74,43 @@ func TestParseSelect(t *testing.T) {
 		},
 		{
 			"SELECT DISTINCT ?name ?person",
-			&Select{[]string{"?name", "?person"}, true},
+			&Select{Variable: []string{"?name", "?person"}, Distinct: true},
 			false,
 		},
 		{
 			`SELECT ?name ?person
 			WHERE {}`,
-			&Select{[]string{"?name", "?person"}, false},
+			&Select{Variable: []string{"?name", "?person"}},
 			false,
 		},
+		{
+			`SELECT ?state (COUNT(DISTINCT ?p) AS ?count) (sum(?area) AS ?area) (COUNT(*) AS ?n)
+			WHERE {}`,
+			&Select{
+				Variable: []string{"?state", "?count", "?area", "?n"},
+				Aggregates: map[string]*types.Aggregate{
+					"?count": {Func: "COUNT", Distinct: true, Arg: types.NewNode("?p")},
+					"?area":  {Func: "SUM", Arg: types.NewNode("?area")},
+					"?n":     {Func: "COUNT"},
+				},
+			},
+			false,
+		},
+		{
+			"SELECT (?a AS ?b)",
+			nil,
+			true,
+		},
+		{
+			"SELECT (SUM(*) AS ?b)",
+			nil,
+			true,
+		},
+		{
+			"SELECT (COUNT(?a) ?b)",
+			nil,
+			true,
+		},
 	} {
 		result, err := NewParser(strings.NewReader(c.query)).parseSelect()
 		if c.wantErr {
@@ -109,12 +138,12 @@ func TestParseWhere(t *testing.T) {
 		},
 		{
 			"Where {?person rdf:name ?name}",
-			&Where{[]Triple{{"?person", "rdf:name", []string{"?name"}}}},
+			&Where{Triples: []Triple{{"?person", "rdf:name", []string{"?name"}}}},
 			false,
 		},
 		{
 			"Where {?person rdf:name ?name . ?person rdf:address ?address }",
-			&Where{[]Triple{
+			&Where{Triples: []Triple{
 				{"?person", "rdf:name", []string{"?name"}},
 				{"?person", "rdf:address", []string{"?address"}},
 			}},
@@ -122,11 +151,100 @@ func TestParseWhere(t *testing.T) {
 		},
 		{
 			`Where { ?a name ("San Jose, CA" "SJ in CA") }`,
-			&Where{[]Triple{
+			&Where{Triples: []Triple{
 				{"?a", "name", []string{"\"San Jose, CA\"", "\"SJ in CA\""}},
 			}},
 			false,
 		},
+		{
+			`Where {
+				?a name ?name .
+				?a age ?age
+				FILTER (?age >= 18 && !(?name = "Bob" || regex(?name, "^A", "i")))
+			}`,
+			&Where{
+				Triples: []Triple{
+					{"?a", "name", []string{"?name"}},
+					{"?a", "age", []string{"?age"}},
+				},
+				Filters: []*types.Filter{{
+					Op: "&&",
+					Args: []interface{}{
+						&types.Filter{Op: ">=", Args: []interface{}{
+							types.NewNode("?age"), types.Literal{Value: "18"}},
+							Pos: types.Pos{Line: 4, Column: 13}},
+						&types.Filter{Op: "!", Args: []interface{}{
+							&types.Filter{Op: "||", Args: []interface{}{
+								&types.Filter{Op: "=", Args: []interface{}{
+									types.NewNode("?name"), types.Literal{Value: "Bob", IsString: true}},
+									Pos: types.Pos{Line: 4, Column: 29}},
+								&types.Filter{Op: "REGEX", Args: []interface{}{
+									types.NewNode("?name"),
+									types.Literal{Value: "^A", IsString: true},
+									types.Literal{Value: "i", IsString: true}},
+									Pos: types.Pos{Line: 4, Column: 46}},
+							}, Pos: types.Pos{Line: 4, Column: 29}},
+						}, Pos: types.Pos{Line: 4, Column: 27}},
+					},
+					Pos: types.Pos{Line: 4, Column: 13},
+				}},
+			},
+			false,
+		},
+		{
+			`Where {
+				?a typeOf City .
+				OPTIONAL { ?a name ?name FILTER contains(?name, "San") } .
+				{ ?a containedInPlace geoId/06 } UNION { ?a containedInPlace geoId/36 }
+			}`,
+			&Where{
+				Triples: []Triple{{"?a", "typeOf", []string{"City"}}},
+				Optionals: []*Where{{
+					Triples: []Triple{{"?a", "name", []string{"?name"}}},
+					Filters: []*types.Filter{{Op: "CONTAINS", Args: []interface{}{
+						types.NewNode("?name"), types.Literal{Value: "San", IsString: true}},
+						Pos: types.Pos{Line: 3, Column: 37}}},
+				}},
+				Unions: [][]*Where{{
+					{Triples: []Triple{{"?a", "containedInPlace", []string{"geoId/06"}}}},
+					{Triples: []Triple{{"?a", "containedInPlace", []string{"geoId/36"}}}},
+				}},
+			},
+			false,
+		},
+		{
+			`Where { ?a dcid $dcids . ?a name ?name FILTER (?name != $name) }`,
+			&Where{
+				Triples: []Triple{
+					{"?a", "dcid", []string{"$dcids"}},
+					{"?a", "name", []string{"?name"}},
+				},
+				Filters: []*types.Filter{{Op: "!=", Args: []interface{}{
+					types.NewNode("?name"), types.Param{Name: "name"}},
+					Pos: types.Pos{Line: 1, Column: 48}}},
+			},
+			false,
+		},
+		{
+			"Where { ?a dcid (geoId/06 $dcids) }",
+			nil,
+			true,
+		},
+		{
+			"Where { ?a name ?name FILTER (?name = ) }",
+			nil,
+			true,
+		},
+		{
+			"Where { ?a name ?name FILTER length(?name) }",
+			nil,
+			true,
+		},
+		{
+			"Where { ?a name ?name FILTER strstarts(?name) }",
+			nil,
+			true,
+		},
 	} {
 		result, err := NewParser(strings.NewReader(c.query)).parseWhere()
 		if c.wantErr {
@@ -235,8 +353,8 @@ func TestParseTree(t *testing.T) {
 			`,
 			&QueryTree{
 				P: &Prologue{Base: "<http://schema.org/>", Prefix: map[string]string{}},
-				S: &Select{[]string{"?dcid"}, true},
-				W: &Where{[]Triple{
+				S: &Select{Variable: []string{"?dcid"}, Distinct: true},
+				W: &Where{Triples: []Triple{
 					{"?p", "typeOf", []string{"Place"}},
 					{"?p", "subType", []string{"City"}},
 					{"?p", "name", []string{"\"San Jose\""}},
@@ -256,8 +374,8 @@ func TestParseTree(t *testing.T) {
 			`,
 			&QueryTree{
 				P: &Prologue{Base: "<http://schema.org/>", Prefix: map[string]string{}},
-				S: &Select{[]string{"?a"}, false},
-				W: &Where{[]Triple{
+				S: &Select{Variable: []string{"?a"}},
+				W: &Where{Triples: []Triple{
 					{"?a", "name", []string{"\"San Jose, CA\"", "\"SJ in CA\""}},
 				}},
 				O: &Orderby{"?a", true},
@@ -265,6 +383,56 @@ func TestParseTree(t *testing.T) {
 			},
 			false,
 		},
+		{
+			`SELECT ?state (COUNT(?p) AS ?count)
+			 WHERE {
+			 	?p containedInPlace ?state
+			 }
+			 GROUP BY ?state
+			 HAVING (?count > 10)
+			 ORDER BY DESC(?count)
+			 OFFSET 20 LIMIT 10
+			`,
+			&QueryTree{
+				P: &Prologue{Prefix: map[string]string{}},
+				S: &Select{
+					Variable: []string{"?state", "?count"},
+					Aggregates: map[string]*types.Aggregate{
+						"?count": {Func: "COUNT", Arg: types.NewNode("?p")},
+					},
+				},
+				W: &Where{Triples: []Triple{
+					{"?p", "containedInPlace", []string{"?state"}},
+				}},
+				G: []string{"?state"},
+				H: []*types.Filter{{Op: ">", Args: []interface{}{
+					types.NewNode("?count"), types.Literal{Value: "10"}},
+					Pos: types.Pos{Line: 6, Column: 13}}},
+				O:      &Orderby{"?count", false},
+				L:      10,
+				Offset: 20,
+			},
+			false,
+		},
+		{
+			`SELECT ?a
+			 WHERE {
+			 	?a name ?name FILTER (COUNT(?name) > 1)
+			 }
+			`,
+			nil,
+			true,
+		},
+		{
+			`SELECT ?a
+			 WHERE {
+			 	?a name ?name
+			 }
+			 OFFSET ten
+			`,
+			nil,
+			true,
+		},
 	} {
 		result, err := NewParser(strings.NewReader(c.query)).Parse()
 		if c.wantErr {
@@ -137,7 +137,11 @@ func ScanBareIdent(r io.RuneScanner) string {
 	var buf bytes.Buffer
 	for {
 		ch, _, err := r.ReadRune()
-		if err != nil {
+		if err == io.EOF {
+			// Leave EOF to the caller, so its position is not counted twice.
+			_ = r.UnreadRune()
+			break
+		} else if err != nil {
 			break
 		} else if !isIdentChar(ch) {
 			err := r.UnreadRune()
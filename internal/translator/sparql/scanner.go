@@ -70,13 +70,38 @@ func (s *Scanner) Scan() (tok Token, pos Pos, lit string) {
 			return tok, pos, "?" + lit
 		}
 		return VARIABLE, pos, "?" + lit
+	case '$':
+		tok, _, lit = s.scanIdent(false)
+		if tok != IDENT || lit == "" {
+			return ILLEGAL, pos, "$" + lit
+		}
+		return PARAMETER, pos, "$" + lit
 	case '#':
 		return HASH, pos, "#"
 	case '=':
 		return EQ, pos, ""
+	case '!':
+		if s.scanNext('=') {
+			return NEQ, pos, ""
+		}
+		return NOT, pos, ""
+	case '&':
+		if s.scanNext('&') {
+			return AND, pos, ""
+		}
+	case '|':
+		if s.scanNext('|') {
+			return OR, pos, ""
+		}
 	case '<':
+		if s.scanNext('=') {
+			return LTE, pos, ""
+		}
 		return LT, pos, "<"
 	case '>':
+		if s.scanNext('=') {
+			return GTE, pos, ""
+		}
 		return GT, pos, ">"
 	case '(':
 		return LPAREN, pos, ""
@@ -90,10 +115,21 @@ func (s *Scanner) Scan() (tok Token, pos Pos, lit string) {
 		return COMMA, pos, ""
 	case ';':
 		return SEMICOLON, pos, ""
+	case '*':
+		return MUL, pos, ""
 	}
 	return ILLEGAL, pos, string(ch0)
 }
 
+// scanNext consumes the next rune if it is ch.
+func (s *Scanner) scanNext(ch rune) bool {
+	if ch1, _ := s.r.read(); ch1 == ch {
+		return true
+	}
+	s.r.unread()
+	return false
+}
+
 // scanWhitespace consumes the current rune and all contiguous whitespace.
 func (s *Scanner) scanWhitespace() (tok Token, pos Pos, lit string) {
 	// Create a buffer and read the current character into it.
@@ -126,6 +162,7 @@ func (s *Scanner) scanIdent(lookup bool) (tok Token, pos Pos, lit string) {
 	var buf bytes.Buffer
 	for {
 		if ch, pos0 := s.r.read(); ch == eof {
+			s.r.unread()
 			break
 		} else if ch == '"' {
 			return BADSTRING, pos0, "\""
@@ -48,7 +48,15 @@ func TestScanSimple(t *testing.T) {
 		{s: `or`, tok: OR},
 
 		{s: `=`, tok: EQ},
-		{s: `! `, tok: ILLEGAL, lit: "!"},
+		{s: `! `, tok: NOT},
+		{s: `!=`, tok: NEQ},
+		{s: `&&`, tok: AND},
+		{s: `||`, tok: OR},
+		{s: `& `, tok: ILLEGAL, lit: "&"},
+		{s: `<`, tok: LT, lit: "<"},
+		{s: `<=`, tok: LTE},
+		{s: `>`, tok: GT, lit: ">"},
+		{s: `>=`, tok: GTE},
 
 		// Misc tokens
 		{s: `(`, tok: LPAREN},
@@ -64,6 +72,8 @@ func TestScanSimple(t *testing.T) {
 		{s: `test"`, tok: BADSTRING, lit: "\"", pos: Pos{Line: 0, Char: 4}},
 		{s: `"test`, tok: BADSTRING, lit: `test`},
 		{s: `?host`, tok: VARIABLE, lit: `?host`},
+		{s: `$dcids`, tok: PARAMETER, lit: `$dcids`},
+		{s: `$ `, tok: ILLEGAL, lit: `$`},
 		{s: `geoId/06`, tok: IDENT, lit: `geoId/06`},
 
 		{s: `true`, tok: TRUE},
@@ -94,9 +104,11 @@ func TestScanSimple(t *testing.T) {
 		{s: `FROM`, tok: FROM},
 		{s: `IN`, tok: IN},
 		{s: `LIMIT`, tok: LIMIT},
+		{s: `OPTIONAL`, tok: OPTIONAL},
 		{s: `ORDER`, tok: ORDER},
 		{s: `PREFIX`, tok: PREFIX},
 		{s: `SELECT`, tok: SELECT},
+		{s: `UNION`, tok: UNION},
 		{s: `WHERE`, tok: WHERE},
 		{s: `seLECT`, tok: SELECT}, // case insensitive
 	}
@@ -16,45 +16,87 @@
 package sparql
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/datacommonsorg/mixer/internal/translator/types"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // ParseQuery parses a sparql query into list of nodes and list of query statements.
 func ParseQuery(queryString string) ([]types.Node, []*types.Query, *types.QueryOptions, error) {
 	queryTree, err := NewParser(strings.NewReader(queryString)).Parse()
 	if err != nil {
-		return nil, nil, nil, status.Errorf(
-			codes.InvalidArgument, "Invalid sparql query string\n%s", queryString)
+		return nil, nil, nil, &types.QueryError{
+			Pos:      queryPos(err.Pos),
+			Token:    err.Found,
+			Expected: err.Expected,
+			Message:  fmt.Sprintf("Invalid sparql query: %s", err),
+		}
+	}
+	opts := types.QueryOptions{
+		Limit:    queryTree.L,
+		Offset:   queryTree.Offset,
+		Distinct: queryTree.S.Distinct,
+		Having:   queryTree.H,
 	}
-	opts := types.QueryOptions{Limit: queryTree.L, Distinct: queryTree.S.Distinct}
 
 	nodes := []types.Node{}
 	for _, v := range queryTree.S.Variable {
 		nodes = append(nodes, types.NewNode(v))
 	}
+	for v, agg := range queryTree.S.Aggregates {
+		if opts.Aggregates == nil {
+			opts.Aggregates = map[types.Node]*types.Aggregate{}
+		}
+		opts.Aggregates[types.NewNode(v)] = agg
+	}
+	for i, v := range queryTree.G {
+		opts.GroupBy = append(opts.GroupBy, types.NewNode(v))
+		opts.GroupByPos = append(opts.GroupByPos, queryPos(queryTree.gPos[i]))
+	}
 
-	queries := []*types.Query{}
-	for _, t := range queryTree.W.Triples {
+	group := toGroupPattern(queryTree.W)
+	opts.Filters = group.Filters
+	opts.Optionals = group.Optionals
+	opts.Unions = group.Unions
+	if queryTree.O != nil {
+		opts.Orderby = queryTree.O.Variable
+		opts.ASC = queryTree.O.ASC
+	}
+	return nodes, group.Queries, &opts, nil
+}
+
+// toGroupPattern converts a parsed group graph pattern to query statements.
+func toGroupPattern(w *Where) *types.GroupPattern {
+	result := &types.GroupPattern{Queries: []*types.Query{}, Filters: w.Filters}
+	for i, t := range w.Triples {
 		var query *types.Query
 		if len(t.Objs) == 1 {
 			obj := t.Objs[0]
 			if strings.HasPrefix(obj, "?") {
 				query = types.NewQuery(t.Pred, t.Sub, types.NewNode(obj))
+			} else if strings.HasPrefix(obj, "$") {
+				query = types.NewQuery(t.Pred, t.Sub, types.Param{Name: obj[1:]})
 			} else {
 				query = types.NewQuery(t.Pred, t.Sub, obj)
 			}
 		} else {
 			query = types.NewQuery(t.Pred, t.Sub, t.Objs)
 		}
-		queries = append(queries, query)
+		if i < len(w.pos) {
+			query.Pos = queryPos(w.pos[i])
+		}
+		result.Queries = append(result.Queries, query)
 	}
-	if queryTree.O != nil {
-		opts.Orderby = queryTree.O.Variable
-		opts.ASC = queryTree.O.ASC
+	for _, o := range w.Optionals {
+		result.Optionals = append(result.Optionals, toGroupPattern(o))
+	}
+	for _, u := range w.Unions {
+		groups := []*types.GroupPattern{}
+		for _, g := range u {
+			groups = append(groups, toGroupPattern(g))
+		}
+		result.Unions = append(result.Unions, groups)
 	}
-	return nodes, queries, &opts, nil
+	return result
 }
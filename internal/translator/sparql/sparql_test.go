@@ -0,0 +1,73 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparql
+
+import (
+	"testing"
+
+	"github.com/datacommonsorg/mixer/internal/translator/types"
+	"github.com/go-test/deep"
+)
+
+func TestParseQueryError(t *testing.T) {
+	for _, c := range []struct {
+		query string
+		want  *types.QueryError
+	}{
+		{
+			"SELECT ?a\nWHERE {\n  ?a name ?n\n  FILTER (?n = )\n}",
+			&types.QueryError{
+				Pos:      types.Pos{Line: 4, Column: 16},
+				Token:    ")",
+				Expected: []string{"(", "?...", "$...", "STRING", "NUMBER", "function"},
+			},
+		},
+		{
+			"SELECT ?a\nWHERE {\n  ?a name ?n",
+			&types.QueryError{Pos: types.Pos{Line: 3, Column: 13}, Token: "EOF", Expected: []string{"}"}},
+		},
+		{
+			"SELECT ?a WHERE { ?a name ?n } LIMIT ten",
+			&types.QueryError{Pos: types.Pos{Line: 1, Column: 38}, Token: "ten", Expected: []string{"NUMBER"}},
+		},
+	} {
+		_, _, _, err := ParseQuery(c.query)
+		if err == nil {
+			t.Errorf("ParseQuery(%s) = nil, want error", c.query)
+			continue
+		}
+		queryErr, ok := err.(*types.QueryError)
+		if !ok {
+			t.Errorf("ParseQuery(%s) = %s, want a QueryError", c.query, err)
+			continue
+		}
+		got := *queryErr
+		got.Message = ""
+		if diff := deep.Equal(&got, c.want); diff != nil {
+			t.Errorf("ParseQuery(%s) got diff %v", c.query, diff)
+		}
+	}
+}
+
+func TestParseQueryPos(t *testing.T) {
+	_, queries, _, err := ParseQuery(
+		"SELECT ?n\nWHERE {\n  ?a typeOf State .\n  { ?a name ?n } UNION { ?a dcid ?n }\n}")
+	if err != nil {
+		t.Fatalf("ParseQuery() = %s", err)
+	}
+	if diff := deep.Equal(queries[0].Pos, types.Pos{Line: 3, Column: 3}); diff != nil {
+		t.Errorf("Unexpected diff %v", diff)
+	}
+}
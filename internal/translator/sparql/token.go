@@ -32,6 +32,7 @@ const (
 	IDENT     // rdf
 	URI       // <http://example.org>
 	VARIABLE  // ?a
+	PARAMETER // $a
 	NUMBER    // 123.45
 	STRING    // "abc"
 	BADSTRING // "abc
@@ -43,9 +44,13 @@ const (
 	AND // AND
 	OR  // OR
 	EQ  // =
+	NEQ // !=
+	NOT // !
 
 	LT        // <
+	LTE       // <=
 	GT        // >
+	GTE       // >=
 	LPAREN    // (
 	RPAREN    // )
 	LBRAC     // {
@@ -54,9 +59,11 @@ const (
 	SEMICOLON // ;
 	DOT       //.
 	HASH      // #
+	MUL       // *
 
 	keywordBeg
-	// ASC and following are Sparql keywords.
+	// AS and following are Sparql keywords.
+	AS
 	ASC
 	BASE
 	BY
@@ -64,11 +71,16 @@ const (
 	DISTINCT
 	FILTER
 	FROM
+	GROUP
+	HAVING
 	IN
 	LIMIT
+	OFFSET
+	OPTIONAL
 	ORDER
 	PREFIX
 	SELECT
+	UNION
 	WHERE
 	keywordEnd
 )
@@ -82,6 +94,7 @@ var (
 		IDENT:     "IDENT",
 		URI:       "URI",
 		VARIABLE:  "VARIABLE",
+		PARAMETER: "PARAMETER",
 		NUMBER:    "NUMBER",
 		STRING:    "STRING",
 		BADSTRING: "BADSTRING",
@@ -92,10 +105,14 @@ var (
 		AND: "AND",
 		OR:  "OR",
 
-		EQ: "=",
+		EQ:  "=",
+		NEQ: "!=",
+		NOT: "!",
 
 		LT:        "<",
+		LTE:       "<=",
 		GT:        ">",
+		GTE:       ">=",
 		LPAREN:    "(",
 		RPAREN:    ")",
 		LBRAC:     "{",
@@ -104,7 +121,9 @@ var (
 		SEMICOLON: ";",
 		DOT:       ".",
 		HASH:      ".",
+		MUL:       "*",
 
+		AS:       "AS",
 		ASC:      "ASC",
 		BASE:     "BASE",
 		BY:       "BY",
@@ -112,11 +131,16 @@ var (
 		DISTINCT: "DISTINCT",
 		FILTER:   "FILTER",
 		FROM:     "FROM",
+		GROUP:    "GROUP",
+		HAVING:   "HAVING",
 		IN:       "IN",
 		LIMIT:    "LIMIT",
+		OFFSET:   "OFFSET",
+		OPTIONAL: "OPTIONAL",
 		ORDER:    "ORDER",
 		PREFIX:   "PREFIX",
 		SELECT:   "SELECT",
+		UNION:    "UNION",
 		WHERE:    "WHERE",
 	}
 
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/datacommonsorg/mixer/internal/parser/tmcf"
+	"github.com/datacommonsorg/mixer/internal/translator/dialect"
 	"github.com/datacommonsorg/mixer/internal/translator/solver"
 	"github.com/datacommonsorg/mixer/internal/translator/types"
 
@@ -56,6 +57,44 @@ type Translation struct {
 	Bindings   []Binding
 	Constraint []Constraint
 	Prov       map[int][]int
+	// Parameters of the SQL query, keyed by name. Each value is a string or a
+	// list of strings.
+	Params map[string]interface{}
+}
+
+// IsNodeRef checks if the values of a node are the dcids of graph nodes. This
+// is the case for the subjects of query statements, and for objects mapped to
+// an entity or a type.
+func (t *Translation) IsNodeRef(n types.Node) bool {
+	for _, b := range t.Bindings {
+		if b.Query.Sub == n {
+			return true
+		}
+		if obj, ok := b.Query.Obj.(types.Node); !ok || obj != n {
+			continue
+		}
+		if _, ok := b.Mapping.Obj.(types.Entity); ok {
+			return true
+		}
+		if _, ok := b.Mapping.Obj.(string); ok && b.Mapping.Pred == "typeOf" {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeColumn gets the mapping column that the values of a node are read from,
+// if the node is the object of a query statement bound to a column.
+func (t *Translation) NodeColumn(n types.Node) (types.Column, bool) {
+	for _, b := range t.Bindings {
+		if obj, ok := b.Query.Obj.(types.Node); !ok || obj != n {
+			continue
+		}
+		if c, ok := b.Mapping.Obj.(types.Column); ok {
+			return c, true
+		}
+	}
+	return types.Column{}, false
 }
 
 // ProvInfo contains the provenance query metadata
@@ -67,20 +106,26 @@ type ProvInfo struct {
 // Graph represents the struct for terms matching.
 type Graph map[interface{}]map[interface{}]struct{}
 
-func addQuote(s string, useQuote ...bool) string {
+func addQuote(d dialect.Dialect, s string, useQuote ...bool) string {
 	if len(useQuote) == 0 || !useQuote[0] {
 		if _, err := strconv.ParseFloat(s, 64); err == nil {
 			return s
 		}
 	}
+	return d.String(strings.TrimSuffix(strings.TrimPrefix(s, `"`), `"`))
+}
 
-	if !strings.HasPrefix(s, `"`) {
-		s = `"` + s
+// sqlDialect gets the SQL dialect of a query.
+func sqlDialect(opts *types.QueryOptions) dialect.Dialect {
+	if opts == nil || opts.Dialect == nil {
+		return dialect.BigQuery{}
 	}
-	if !strings.HasSuffix(s, `"`) {
-		s += `"`
-	}
-	return s
+	return opts.Dialect
+}
+
+// column references a column in SQL.
+func column(d dialect.Dialect, c types.Column) string {
+	return d.Column(c.Table.Alias(), c.Name)
 }
 
 func sortMapSet(m map[interface{}]struct{}) []interface{} {
@@ -416,7 +461,7 @@ func (graph Graph) getResolvedEntity(
 				needBreak := false
 				for v := range vs {
 					switch v.(type) {
-					case string, *[]string:
+					case string, *[]string, types.Param:
 						resolvedEntities[e] = entityInfo{e, col, v}
 						needBreak = true
 					}
@@ -602,6 +647,17 @@ func (graph Graph) constructConstraint(
 				}
 			}
 		}
+		// If key is a parameter, each match form a constraint.
+		if param, ok := key.(types.Param); ok {
+			for _, value := range sorted {
+				if col, ok := value.(types.Column); ok {
+					result = append(result, Constraint{col, param})
+				} else {
+					return nil, nil, status.Errorf(
+						codes.InvalidArgument, "Parameter %s should match a column, get %v", param, value)
+				}
+			}
+		}
 		// If key is a slice of string, each match form a constraint.
 		if strSlice, ok := key.(*[]string); ok {
 			for _, value := range sorted {
@@ -645,6 +701,18 @@ func getSQL(
 	provCols := map[types.Column]int{}
 	provList := []types.Column{}
 	pc := len(nodes)
+	d := sqlDialect(opts)
+	resolve := func(n types.Node) (string, bool) {
+		return nodeColumn(d, n, constraints, constNode)
+	}
+	aggs, err := compileAggregates(resolve, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	// Provenance can not be selected with grouped results.
+	if isGrouped(opts) {
+		provInfo.query = false
+	}
 	sql := "SELECT"
 	if opts.Distinct {
 		sql += " DISTINCT"
@@ -653,15 +721,16 @@ func getSQL(
 		if idx != 0 {
 			sql += ","
 		}
+		if agg, ok := aggs[n]; ok {
+			sql += fmt.Sprintf(" %s AS %s", agg, nodeName(n))
+			continue
+		}
 		if str, ok := constNode[n]; ok {
-			sql += fmt.Sprintf(` "%s"`, str)
+			sql += " " + d.String(str)
 		}
 		for _, c := range constraints {
 			if n == c.RHS {
-				sql += fmt.Sprintf(" %s.%s AS %s",
-					c.LHS.Table.Alias(),
-					c.LHS.Name,
-					strings.TrimPrefix(strings.ReplaceAll(n.Alias, "/", "_"), "?"))
+				sql += fmt.Sprintf(" %s AS %s", column(d, c.LHS), nodeName(n))
 				if provInfo.query {
 					if provCol, ok := provInfo.tableProv[c.LHS.Table.Name]; ok {
 						provCol.Table.ID = c.LHS.Table.ID
@@ -680,9 +749,33 @@ func getSQL(
 		}
 	}
 	for i, p := range provList {
-		sql += ", " + fmt.Sprintf("%s.%s AS prov%d", p.Table.Alias(), p.Name, i)
+		sql += ", " + fmt.Sprintf("%s AS prov%d", column(d, p), i)
 	}
 
+	from, conds := getFromWhere(d, constraints)
+	sql += from
+	for _, f := range opts.Filters {
+		cond, err := compileFilter(d, f, resolve)
+		if err != nil {
+			return "", nil, err
+		}
+		conds = append(conds, cond)
+	}
+	if len(conds) > 0 {
+		sql += " WHERE " + strings.Join(conds, " AND ")
+	}
+	groupBy, err := getGroupBy(nodes, resolve, aggs, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	sql += groupBy
+	sql += getOrderLimit(d, opts)
+	return sql, prov, nil
+}
+
+// getFromWhere builds the FROM and JOIN clauses from the constraints, and
+// returns the conditions of the WHERE clause.
+func getFromWhere(d dialect.Dialect, constraints []Constraint) (string, []string) {
 	tableCounter := map[types.Table]int{}
 	constCounter := map[types.Table]int{}
 	joinConstraints := map[types.Table][]Constraint{}
@@ -746,7 +839,7 @@ func getSQL(
 		}
 	}
 
-	sql += fmt.Sprintf(" FROM %s AS %s", currTable.Name, currTable.Alias())
+	sql := fmt.Sprintf(" FROM %s AS %s", d.Table(currTable.Path()), currTable.Alias())
 
 	// Keep track of table that has been processed, they should already have an
 	// alias in SQL and could be used as "currTable".
@@ -767,10 +860,9 @@ func getSQL(
 			if _, ok := processedTable[otherCol.Table]; ok {
 				whereConstraints = append(whereConstraints, c)
 			} else {
-				sql += fmt.Sprintf(" JOIN %s AS %s", otherCol.Table.Name, otherCol.Table.Alias())
 				sql += fmt.Sprintf(
-					" ON %s.%s = %s.%s",
-					currCol.Table.Alias(), currCol.Name, otherCol.Table.Alias(), otherCol.Name)
+					" JOIN %s AS %s", d.Table(otherCol.Table.Path()), otherCol.Table.Alias())
+				sql += fmt.Sprintf(" ON %s = %s", column(d, currCol), column(d, otherCol))
 				processedTable[otherCol.Table] = struct{}{}
 
 			}
@@ -815,98 +907,138 @@ func getSQL(
 		return strings.Compare(
 			whereConstraints[i].LHS.String(), whereConstraints[j].LHS.String()) < 0
 	})
-	for idx, c := range whereConstraints {
-		if idx == 0 {
-			sql += " WHERE "
-		} else if idx != len(whereConstraints) {
-			sql += " AND "
-		}
+	conds := []string{}
+	for _, c := range whereConstraints {
 		switch v := c.RHS.(type) {
 		case types.Column:
-			sql += fmt.Sprintf("%s.%s = %s.%s", c.LHS.Table.Alias(), c.LHS.Name, v.Table.Alias(), v.Name)
+			conds = append(conds, fmt.Sprintf("%s = %s", column(d, c.LHS), column(d, v)))
 		case string:
 			// Before we have spanner table reflection, need to hardcode check here.
 			// But the user should really have quote for strings.
 			useQuote := strings.Contains(c.LHS.Table.Name, tmcf.Triple)
-			sql += fmt.Sprintf("%s.%s = %s", c.LHS.Table.Alias(), c.LHS.Name, addQuote(v, useQuote))
+			conds = append(conds, fmt.Sprintf(
+				"%s = %s", column(d, c.LHS), addQuote(d, v, useQuote)))
 		case []string:
 			strs := []string{}
 			for _, s := range v {
-				strs = append(strs, addQuote(s))
+				strs = append(strs, addQuote(d, s))
 			}
-			sql += fmt.Sprintf("%s.%s IN (%s)", c.LHS.Table.Alias(), c.LHS.Name, strings.Join(strs, ", "))
+			conds = append(conds, fmt.Sprintf(
+				"%s IN (%s)", column(d, c.LHS), strings.Join(strs, ", ")))
+		case types.Param:
+			conds = append(conds, d.InParam(column(d, c.LHS), v.Name))
 		}
 	}
+	return sql, conds
+}
+
+// getOrderLimit builds the ORDER BY and LIMIT clauses.
+func getOrderLimit(d dialect.Dialect, opts *types.QueryOptions) string {
+	sql := ""
 	if opts.Orderby != "" {
-		sql += fmt.Sprintf(
-			" ORDER BY %s", strings.TrimPrefix(strings.ReplaceAll(opts.Orderby, "/", "_"), "?"))
+		sql += fmt.Sprintf(" ORDER BY %s", nodeName(types.NewNode(opts.Orderby)))
 		if opts.ASC {
 			sql += " ASC"
 		} else {
 			sql += " DESC"
 		}
 	}
-	if opts.Limit > 0 {
-		sql += fmt.Sprintf(" LIMIT %d", opts.Limit)
-	}
-	return sql, prov, nil
+	return sql + d.LimitOffset(opts.Limit, opts.Offset)
 }
 
-// Translate takes a datalog query and translates to GoogleSQL query based on schema mapping.
-func Translate(
-	mappings []*types.Mapping, nodes []types.Node, queries []*types.Query,
-	subTypeMap map[string]string, options ...*types.QueryOptions) (
-	*Translation, error) {
+// resolveConstraints binds the query statements to the schema mapping, and
+// obtains the constraints used to construct SQL query.
+func resolveConstraints(
+	mappings []*types.Mapping, queries []*types.Query, subTypeMap map[string]string) (
+	[]Binding, []Constraint, map[types.Node]string, error) {
 	funcDeps, err := solver.GetFuncDeps(mappings)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	tableProv, err := solver.GetProvColumn(mappings)
-	if err != nil {
-		return nil, err
+	// Check the node types before the statements are rewritten, so errors
+	// refer to the statements in the query.
+	if _, err := solver.GetNodeType(queries); err != nil {
+		return nil, nil, nil, err
 	}
-
 	mappings = solver.PruneMapping(mappings)
 	queries = solver.RewriteQuery(queries, subTypeMap)
 	matchTriple, err := solver.MatchTriple(mappings, queries)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	queryID := solver.GetQueryID(queries, matchTriple)
 
 	bindingMap, err := Bind(mappings, queries)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	for _, q := range queries {
+		if len(bindingMap[q]) == 0 {
+			if q.IsTypeOf() {
+				return nil, nil, nil, types.NewStatementError(q, "No schema mapping for type %v", q.Obj)
+			}
+			return nil, nil, nil, types.NewStatementError(q, "No schema mapping for property %s", q.Pred)
+		}
 	}
 	bindingSets := getBindingSets(bindingMap)
 	if len(bindingSets) > 1 {
 		fmt.Printf("There are %d binding sets\n", len(bindingSets))
 	} else if len(bindingSets) == 0 {
-		return nil, status.Errorf(codes.Internal, "Failed to get translation result")
+		return nil, nil, nil, status.Errorf(codes.Internal, "Failed to get translation result")
 	}
 
 	nodeRefs := solver.GetNodeRef(queries)
 	graph := getGraph(bindingSets[0], queryID, nodeRefs)
 	constraints, constNode, err := GetConstraint(graph, funcDeps)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
+	return bindingSets[0], constraints, constNode, nil
+}
 
-	var (
-		queryProv    bool
-		queryOptions *types.QueryOptions
-	)
+// Translate takes a datalog query and translates to SQL query based on schema
+// mapping. The SQL dialect is set in the query options, and is BigQuery
+// standard SQL by default.
+func Translate(
+	mappings []*types.Mapping, nodes []types.Node, queries []*types.Query,
+	subTypeMap map[string]string, options ...*types.QueryOptions) (
+	*Translation, error) {
+	queryOptions := &types.QueryOptions{}
 	if len(options) > 0 {
 		queryOptions = options[0]
-		queryProv = options[0].Prov
-	} else {
-		queryOptions = &types.QueryOptions{}
+	}
+	group := &types.GroupPattern{
+		Queries:   queries,
+		Filters:   queryOptions.Filters,
+		Optionals: queryOptions.Optionals,
+		Unions:    queryOptions.Unions,
+	}
+	params, err := bindParams(group, queryOptions)
+	if err != nil {
+		return nil, err
+	}
+	if len(queryOptions.Optionals) > 0 || len(queryOptions.Unions) > 0 {
+		translation, err := translateGroup(mappings, nodes, group, subTypeMap, queryOptions)
+		if err != nil {
+			return nil, err
+		}
+		translation.Params = params
+		return translation, nil
 	}
 
-	sql, prov, err := getSQL(nodes, constraints, constNode, ProvInfo{queryProv, tableProv}, queryOptions)
+	tableProv, err := solver.GetProvColumn(mappings)
+	if err != nil {
+		return nil, err
+	}
+	bindings, constraints, constNode, err := resolveConstraints(mappings, queries, subTypeMap)
+	if err != nil {
+		return nil, err
+	}
+	sql, prov, err := getSQL(
+		nodes, constraints, constNode, ProvInfo{queryOptions.Prov, tableProv}, queryOptions)
 	if err != nil {
 		return nil, err
 	}
-	return &Translation{sql, nodes, bindingSets[0], constraints, prov}, nil
+	return &Translation{sql, nodes, bindings, constraints, prov, params}, nil
 }
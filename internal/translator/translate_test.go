@@ -20,12 +20,15 @@ import (
 	"testing"
 
 	"github.com/datacommonsorg/mixer/internal/translator/datalog"
+	"github.com/datacommonsorg/mixer/internal/translator/dialect"
 	"github.com/datacommonsorg/mixer/internal/translator/solver"
 	"github.com/datacommonsorg/mixer/internal/translator/sparql"
 	"github.com/datacommonsorg/mixer/internal/translator/testutil"
 	"github.com/datacommonsorg/mixer/internal/translator/types"
 
 	"github.com/go-test/deep"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestBind(t *testing.T) {
@@ -1090,6 +1093,310 @@ func TestSparql(t *testing.T) {
 	}
 }
 
+func TestSparqlPattern(t *testing.T) {
+	subTypeMap, err := solver.GetSubTypeMap("table_types.json")
+	if err != nil {
+		t.Fatalf("GetSubTypeMap() = %v", err)
+	}
+
+	mappings := testutil.ReadTestMapping(t, []string{
+		"testdata/test_mapping.mcf",
+	})
+	for _, c := range []struct {
+		name     string
+		queryStr string
+		wantSQL  string
+		wantErr  bool
+	}{
+		{
+			"filter",
+			`
+			SELECT ?name
+			WHERE {
+				?state typeOf State .
+				?state name ?name .
+				FILTER (regex(?name, "^New") && ?name != "New York")
+			}
+			LIMIT 10
+			`,
+			"SELECT _dc_v3_Place_0.name AS name FROM `dc_v3.Place` AS _dc_v3_Place_0 " +
+				"WHERE _dc_v3_Place_0.type = \"State\" " +
+				"AND (REGEXP_CONTAINS(_dc_v3_Place_0.name, \"^New\") AND _dc_v3_Place_0.name != \"New York\") " +
+				"LIMIT 10",
+			false,
+		},
+		{
+			"optional",
+			`
+			SELECT ?name ?tz
+			WHERE {
+				?state typeOf State .
+				?state name ?name .
+				OPTIONAL { ?state timezone ?tz FILTER strstarts(?tz, "America") }
+			}
+			ORDER BY ?name
+			`,
+			"SELECT _g0.name AS name, _g1.tz AS tz " +
+				"FROM (SELECT _dc_v3_Place_0.id AS state, _dc_v3_Place_0.name AS name " +
+				"FROM `dc_v3.Place` AS _dc_v3_Place_0 WHERE _dc_v3_Place_0.type = \"State\") AS _g0 " +
+				"LEFT JOIN (SELECT _dc_v3_Triple_0.subject_id AS state, _dc_v3_Triple_0.object_value AS tz " +
+				"FROM `dc_v3.Triple` AS _dc_v3_Triple_0 WHERE _dc_v3_Triple_0.predicate = \"timezone\") AS _g1 " +
+				"ON _g0.state = _g1.state AND STARTS_WITH(_g1.tz, \"America\") " +
+				"ORDER BY name ASC",
+			false,
+		},
+		{
+			"union",
+			`
+			SELECT DISTINCT ?name
+			WHERE {
+				{ ?p typeOf State . ?p name ?name } UNION { ?p typeOf County . ?p name ?name }
+				FILTER contains(lcase(?name), "new")
+			}
+			`,
+			"SELECT DISTINCT _g0.name AS name " +
+				"FROM (SELECT _dc_v3_Place_0.id AS p, _dc_v3_Place_0.name AS name " +
+				"FROM `dc_v3.Place` AS _dc_v3_Place_0 WHERE _dc_v3_Place_0.type = \"State\" " +
+				"UNION ALL SELECT _dc_v3_Place_0.id AS p, _dc_v3_Place_0.name AS name " +
+				"FROM `dc_v3.Place` AS _dc_v3_Place_0 WHERE _dc_v3_Place_0.type = \"County\") AS _g0 " +
+				"WHERE STRPOS(LOWER(_g0.name), \"new\") > 0",
+			false,
+		},
+		{
+			"unbound filter variable",
+			`
+			SELECT ?name
+			WHERE {
+				?state typeOf State .
+				?state name ?name .
+				FILTER (?other = "a")
+			}
+			`,
+			"",
+			true,
+		},
+	} {
+		nodes, queries, opts, err := sparql.ParseQuery(c.queryStr)
+		if err != nil {
+			t.Errorf("ParseQuery error: %s", err)
+			continue
+		}
+		translation, err := Translate(mappings, nodes, queries, subTypeMap, opts)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("Translate(%s) = nil, want error", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Translate(%s) = %s", c.name, err)
+			continue
+		}
+		if diff := deep.Equal(c.wantSQL, translation.SQL); diff != nil {
+			t.Errorf("getSQL unexpected sql diff for test %s, %v", c.name, diff)
+		}
+	}
+}
+
+func TestSparqlAggregate(t *testing.T) {
+	subTypeMap, err := solver.GetSubTypeMap("table_types.json")
+	if err != nil {
+		t.Fatalf("GetSubTypeMap() = %v", err)
+	}
+
+	mappings := testutil.ReadTestMapping(t, []string{
+		"testdata/test_mapping.mcf",
+	})
+	for _, c := range []struct {
+		name     string
+		queryStr string
+		wantSQL  string
+		wantErr  bool
+	}{
+		{
+			"group by",
+			`
+			SELECT ?state (COUNT(?plant) AS ?count)
+			WHERE {
+				?plant typeOf PowerPlant .
+				?plant containedInPlace ?state .
+			}
+			GROUP BY ?state
+			HAVING (?count > 10)
+			ORDER BY DESC(?count)
+			LIMIT 10
+			OFFSET 20
+			`,
+			"SELECT _dc_v3_Triple_1.object_value AS state, COUNT(_dc_v3_Triple_0.subject_id) AS count " +
+				"FROM `dc_v3.Triple` AS _dc_v3_Triple_0 " +
+				"JOIN `dc_v3.Triple` AS _dc_v3_Triple_1 ON _dc_v3_Triple_0.subject_id = _dc_v3_Triple_1.subject_id " +
+				"WHERE _dc_v3_Triple_0.object_id = \"PowerPlant\" AND _dc_v3_Triple_0.predicate = \"typeOf\" " +
+				"AND _dc_v3_Triple_1.predicate = \"containedInPlace\" " +
+				"GROUP BY _dc_v3_Triple_1.object_value HAVING COUNT(_dc_v3_Triple_0.subject_id) > 10 " +
+				"ORDER BY count DESC LIMIT 10 OFFSET 20",
+			false,
+		},
+		{
+			"no group by",
+			`
+			SELECT (COUNT(*) AS ?n) (AVG(?area) AS ?avg)
+			WHERE {
+				?s typeOf State .
+				?s landArea ?area
+			}
+			`,
+			"SELECT COUNT(*) AS n, AVG(SAFE_CAST(_dc_v3_Place_0.land_area AS FLOAT64)) AS avg " +
+				"FROM `dc_v3.Place` AS _dc_v3_Place_0 WHERE _dc_v3_Place_0.type = \"State\"",
+			false,
+		},
+		{
+			"optional",
+			`
+			SELECT ?name (COUNT(DISTINCT ?p) AS ?n)
+			WHERE {
+				?s typeOf State .
+				?s name ?name .
+				OPTIONAL { ?p containedInPlace ?s }
+			}
+			GROUP BY ?name
+			HAVING (COUNT(DISTINCT ?p) >= 2)
+			`,
+			"SELECT _g0.name AS name, COUNT(DISTINCT _g1.p) AS n " +
+				"FROM (SELECT _dc_v3_Place_0.id AS s, _dc_v3_Place_0.name AS name " +
+				"FROM `dc_v3.Place` AS _dc_v3_Place_0 WHERE _dc_v3_Place_0.type = \"State\") AS _g0 " +
+				"LEFT JOIN (SELECT _dc_v3_Triple_0.subject_id AS p, _dc_v3_Triple_0.object_value AS s " +
+				"FROM `dc_v3.Triple` AS _dc_v3_Triple_0 WHERE _dc_v3_Triple_0.predicate = \"containedInPlace\") AS _g1 " +
+				"ON _g0.s = _g1.s " +
+				"GROUP BY _g0.name HAVING COUNT(DISTINCT _g1.p) >= 2",
+			false,
+		},
+		{
+			"offset without limit",
+			`
+			SELECT ?name
+			WHERE {
+				?s typeOf State .
+				?s name ?name
+			}
+			OFFSET 5
+			`,
+			"SELECT _dc_v3_Place_0.name AS name FROM `dc_v3.Place` AS _dc_v3_Place_0 " +
+				"WHERE _dc_v3_Place_0.type = \"State\" LIMIT 9223372036854775807 OFFSET 5",
+			false,
+		},
+		{
+			"variable not grouped",
+			`
+			SELECT ?name ?s (COUNT(*) AS ?n)
+			WHERE {
+				?s typeOf State .
+				?s name ?name
+			}
+			GROUP BY ?name
+			`,
+			"",
+			true,
+		},
+	} {
+		nodes, queries, opts, err := sparql.ParseQuery(c.queryStr)
+		if err != nil {
+			t.Errorf("ParseQuery error: %s", err)
+			continue
+		}
+		translation, err := Translate(mappings, nodes, queries, subTypeMap, opts)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("Translate(%s) = nil, want error", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Translate(%s) = %s", c.name, err)
+			continue
+		}
+		if diff := deep.Equal(c.wantSQL, translation.SQL); diff != nil {
+			t.Errorf("getSQL unexpected sql diff for test %s, %v", c.name, diff)
+		}
+	}
+}
+
+func TestTranslateError(t *testing.T) {
+	db := "dc_v3"
+	mappings := []*types.Mapping{}
+	for _, m := range [][]string{
+		{"functionalDeps", "E:Place->E1", "dcid"},
+		{"typeOf", "E:Place->E1", "Place"},
+		{"dcid", "E:Place->E1", "C:Place->id"},
+		{"name", "E:Place->E1", "C:Place->name"},
+	} {
+		mapping, err := types.NewMapping(m[0], m[1], m[2], db)
+		if err != nil {
+			t.Fatalf("NewMapping(%v) = %s", m, err)
+		}
+		mappings = append(mappings, mapping)
+	}
+	for _, c := range []struct {
+		name     string
+		queryStr string
+		want     *types.QueryError
+	}{
+		{
+			"unmapped property",
+			"SELECT ?name\nWHERE {\n  ?a typeOf Place .\n  ?a population ?p\n}",
+			&types.QueryError{Pos: types.Pos{Line: 4, Column: 3}, Statement: "?a population ?p"},
+		},
+		{
+			"duplicate type",
+			"SELECT ?name\nWHERE {\n  ?a typeOf Place .\n  ?a typeOf City .\n  ?a name ?name\n}",
+			&types.QueryError{Pos: types.Pos{Line: 4, Column: 3}, Statement: "?a typeOf City"},
+		},
+		{
+			"unbound filter variable",
+			"SELECT ?name\nWHERE {\n  ?a typeOf Place .\n  ?a name ?name\n  FILTER (?b = 1)\n}",
+			&types.QueryError{Pos: types.Pos{Line: 5, Column: 11}, Token: "?b"},
+		},
+		{
+			"unbound group by variable",
+			"SELECT (COUNT(?a) AS ?count)\nWHERE {\n  ?a typeOf Place .\n  ?a name ?name\n}\n" +
+				"GROUP BY ?b",
+			&types.QueryError{Pos: types.Pos{Line: 6, Column: 10}, Token: "?b"},
+		},
+		{
+			"having without group by",
+			"SELECT ?name\nWHERE {\n  ?a typeOf Place .\n  ?a name ?name\n}\n" +
+				"HAVING (?name = \"a\")",
+			&types.QueryError{Pos: types.Pos{Line: 6, Column: 9}},
+		},
+		{
+			"unbound having variable",
+			"SELECT ?name (COUNT(?a) AS ?count)\nWHERE {\n  ?a typeOf Place .\n  ?a name ?name\n}\n" +
+				"GROUP BY ?name\nHAVING (?count > 1 && ?b > 1)",
+			&types.QueryError{Pos: types.Pos{Line: 7, Column: 23}, Token: "?b"},
+		},
+	} {
+		nodes, queries, opts, err := sparql.ParseQuery(c.queryStr)
+		if err != nil {
+			t.Errorf("ParseQuery error: %s", err)
+			continue
+		}
+		_, err = Translate(mappings, nodes, queries, map[string]string{}, opts)
+		if err == nil {
+			t.Errorf("Translate(%s) = nil, want error", c.name)
+			continue
+		}
+		queryErr, ok := err.(*types.QueryError)
+		if !ok {
+			t.Errorf("Translate(%s) = %s, want a QueryError", c.name, err)
+			continue
+		}
+		got := *queryErr
+		got.Message = ""
+		if diff := deep.Equal(&got, c.want); diff != nil {
+			t.Errorf("Translate(%s) got diff %v", c.name, diff)
+		}
+	}
+}
+
 func TestStatVarObs(t *testing.T) {
 	subTypeMap, err := solver.GetSubTypeMap("table_types.json")
 	if err != nil {
@@ -1138,3 +1445,152 @@ func TestStatVarObs(t *testing.T) {
 		}
 	}
 }
+
+func TestSparqlDialect(t *testing.T) {
+	subTypeMap, err := solver.GetSubTypeMap("table_types.json")
+	if err != nil {
+		t.Fatalf("GetSubTypeMap() = %v", err)
+	}
+
+	mappings := testutil.ReadTestMapping(t, []string{
+		"testdata/test_mapping.mcf",
+	})
+	for _, c := range []struct {
+		name     string
+		queryStr string
+		wantSQL  string
+	}{
+		{
+			"sqlite",
+			`
+				SELECT ?observation ?place
+				WHERE {
+				 ?observation typeOf StatVarObservation .
+				 ?observation variableMeasured Count_Person .
+				 ?observation observationAbout ?place .
+				 ?place typeOf Country .
+				 ?place name ?name .
+				 FILTER(STRSTARTS(?name, "United") || REGEX(?name, "'s$", "i"))
+				}
+				OFFSET 10
+				`,
+			`SELECT _dc_v3_StatVarObservation_0."id" AS observation, _dc_v3_Place_1."id" AS place ` +
+				`FROM "dc_v3.Place" AS _dc_v3_Place_1 ` +
+				`JOIN "dc_v3.StatVarObservation" AS _dc_v3_StatVarObservation_0 ` +
+				`ON _dc_v3_Place_1."id" = _dc_v3_StatVarObservation_0."observation_about" ` +
+				`WHERE _dc_v3_Place_1."type" = 'Country' ` +
+				`AND _dc_v3_StatVarObservation_0."variable_measured" = 'Count_Person' ` +
+				`AND (SUBSTR(_dc_v3_Place_1."name", 1, LENGTH('United')) = 'United' ` +
+				`OR _dc_v3_Place_1."name" REGEXP ('(?i)' || '''s$')) ` +
+				`LIMIT -1 OFFSET 10`,
+		},
+	} {
+		nodes, queries, opts, err := sparql.ParseQuery(c.queryStr)
+		if err != nil {
+			t.Errorf("ParseQuery error: %s", err)
+			continue
+		}
+		opts.Dialect = dialect.SQLite{}
+		translation, err := Translate(mappings, nodes, queries, subTypeMap, opts)
+		if err != nil {
+			t.Errorf("Translate(%s) = %s", c.name, err)
+			continue
+		}
+		if diff := deep.Equal(c.wantSQL, translation.SQL); diff != nil {
+			t.Errorf("getSQL unexpected sql diff for test %s, %v", c.name, diff)
+		}
+	}
+}
+
+func TestSparqlParams(t *testing.T) {
+	subTypeMap, err := solver.GetSubTypeMap("table_types.json")
+	if err != nil {
+		t.Fatalf("GetSubTypeMap() = %v", err)
+	}
+
+	mappings := testutil.ReadTestMapping(t, []string{
+		"testdata/test_mapping.mcf",
+	})
+	for _, c := range []struct {
+		name       string
+		queryStr   string
+		params     map[string][]string
+		wantSQL    string
+		wantParams map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			"list and single value",
+			`
+			SELECT ?name
+			WHERE {
+				?place typeOf Place .
+				?place dcid $dcids .
+				?place name ?name .
+				FILTER(?name != $name)
+			}
+			`,
+			map[string][]string{"dcids": {"geoId/06", "geoId/36"}, "name": {"Ohio"}},
+			"SELECT _dc_v3_Place_0.name AS name FROM `dc_v3.Place` AS _dc_v3_Place_0 " +
+				"WHERE _dc_v3_Place_0.id IN UNNEST(@dcids) AND _dc_v3_Place_0.name != @name",
+			map[string]interface{}{"dcids": []string{"geoId/06", "geoId/36"}, "name": "Ohio"},
+			false,
+		},
+		{
+			"missing parameter",
+			`SELECT ?name WHERE { ?place typeOf Place . ?place dcid $dcids . ?place name ?name }`,
+			map[string][]string{},
+			"",
+			nil,
+			true,
+		},
+		{
+			"single value parameter with a list",
+			`SELECT ?name WHERE { ?place typeOf Place . ?place name ?name FILTER(?name = $name) }`,
+			map[string][]string{"name": {"Ohio", "Iowa"}},
+			"",
+			nil,
+			true,
+		},
+		{
+			"parameter used as list and single value",
+			`
+			SELECT ?name
+			WHERE {
+				?place typeOf Place .
+				?place name $name .
+				?place name ?name .
+				FILTER(?name = $name)
+			}
+			`,
+			map[string][]string{"name": {"Ohio"}},
+			"",
+			nil,
+			true,
+		},
+	} {
+		nodes, queries, opts, err := sparql.ParseQuery(c.queryStr)
+		if err != nil {
+			t.Errorf("ParseQuery error: %s", err)
+			continue
+		}
+		opts.Params = c.params
+		translation, err := Translate(mappings, nodes, queries, subTypeMap, opts)
+		if c.wantErr {
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Translate(%s) = %v, want InvalidArgument", c.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Translate(%s) = %s", c.name, err)
+			continue
+		}
+		if diff := deep.Equal(c.wantSQL, translation.SQL); diff != nil {
+			t.Errorf("getSQL unexpected sql diff for test %s, %v", c.name, diff)
+		}
+		if diff := deep.Equal(c.wantParams, translation.Params); diff != nil {
+			t.Errorf("Translate(%s) unexpected params diff %v", c.name, diff)
+		}
+	}
+}
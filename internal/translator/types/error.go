@@ -0,0 +1,55 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+)
+
+// QueryError is an error in a query, with the details to locate it. The
+// server returns it as an InvalidArgument status with the details.
+type QueryError struct {
+	// Position of the error in the query, unknown if the line is 0.
+	Pos Pos
+	// The unexpected token.
+	Token string
+	// The tokens that were expected instead.
+	Expected []string
+	// The query statement that failed to translate, like "?a typeOf State".
+	Statement string
+	Message   string
+}
+
+func (e *QueryError) Error() string {
+	if e.Pos.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: %s", e.Pos.Line, e.Pos.Column, e.Message)
+	}
+	return e.Message
+}
+
+// NewQueryError creates an error at a position of the query.
+func NewQueryError(pos Pos, token string, format string, args ...interface{}) error {
+	return &QueryError{Pos: pos, Token: token, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewStatementError creates an error for a query statement that fails to
+// translate.
+func NewStatementError(q *Query, format string, args ...interface{}) error {
+	return &QueryError{
+		Pos:       q.Pos,
+		Statement: q.String(),
+		Message:   fmt.Sprintf("%s: %s", q, fmt.Sprintf(format, args...)),
+	}
+}
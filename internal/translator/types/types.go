@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/datacommonsorg/mixer/internal/parser/tmcf"
+	"github.com/datacommonsorg/mixer/internal/translator/dialect"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -36,6 +37,67 @@ type QueryOptions struct {
 	Distinct bool
 	Orderby  string
 	ASC      bool
+	Offset   int
+	// Aggregate projections, keyed by the node they are bound to.
+	Aggregates map[Node]*Aggregate
+	GroupBy    []Node
+	// Position of each GROUP BY variable in the query.
+	GroupByPos []Pos
+	Having     []*Filter
+	// Filters, optional groups and unions of the top level group pattern.
+	Filters   []*Filter
+	Optionals []*GroupPattern
+	Unions    [][]*GroupPattern
+	// SQL dialect of the translated query. BigQuery if not set.
+	Dialect dialect.Dialect
+	// Values of the named parameters, keyed by name without "$".
+	Params map[string][]string
+}
+
+// Param is a named parameter of a query, like $dcids. Its values are bound
+// as parameters of the SQL query instead of being part of the SQL text.
+type Param struct {
+	// Name without the leading "$".
+	Name string
+}
+
+func (p Param) String() string {
+	return "$" + p.Name
+}
+
+// Literal represents a constant in a filter expression.
+type Literal struct {
+	Value    string
+	IsString bool
+}
+
+// Filter represents a filter expression in a query.
+type Filter struct {
+	// Operator like "=", "&&", "!", or function name like "REGEX".
+	Op string
+	// Each argument is a Node, a Literal, a Param or a *Filter.
+	Args []interface{}
+	// Position of the expression in the query.
+	Pos Pos
+}
+
+// Aggregate represents an aggregate function call like COUNT(DISTINCT ?a).
+type Aggregate struct {
+	// Function name like "COUNT", "SUM".
+	Func     string
+	Distinct bool
+	// Aggregated expression, a Node or a *Filter. Nil for COUNT(*).
+	Arg interface{}
+}
+
+// GroupPattern represents a group of query statements, with the filters,
+// optional groups and unions that apply to it.
+type GroupPattern struct {
+	Queries   []*Query
+	Filters   []*Filter
+	Optionals []*GroupPattern
+	// Each union is a list of alternative groups.
+	Unions [][]*GroupPattern
 }
 
 // Node represents a reference of a graph node in datalog query.
@@ -65,6 +127,11 @@ func (t Table) String() string {
 	return fmt.Sprintf("%s%s", t.Name, t.ID)
 }
 
+// Path gets the table path without quotes, like "dc.Place".
+func (t Table) Path() string {
+	return strings.Trim(t.Name, "`")
+}
+
 // Alias gets table's alias used in SQL query.
 func (t Table) Alias() string {
 	r, _ := regexp.Compile("[.`:-]")
@@ -193,6 +260,10 @@ func NewMapping(pred, sub, obj, db string) (*Mapping, error) {
 	return &Mapping{Pred: p, Sub: *s, Obj: o}, nil
 }
 
+func (q *Mapping) String() string {
+	return fmt.Sprintf("%v %v %v", q.Sub, q.Pred, q.Obj)
+}
+
 // IsTriple checks if a mapping is about Triples table.
 func (q *Mapping) IsTriple() bool {
 	return strings.Contains(q.Sub.Table.Name, tmcf.Triple)
@@ -204,8 +275,28 @@ type Query struct {
 	Pred string
 	// Query subject is a node.
 	Sub Node
-	// Query object is a node or string.
+	// Query object is a node, a string, a list of strings or a Param.
 	Obj interface{}
+	// Position of the statement in the query.
+	Pos Pos
+}
+
+// Pos is a position in the query text. Line and Column start at 1, and are 0
+// when the position is unknown.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+func (q *Query) String() string {
+	var obj string
+	switch v := q.Obj.(type) {
+	case []string:
+		obj = "(" + strings.Join(v, " ") + ")"
+	default:
+		obj = fmt.Sprintf("%v", v)
+	}
+	return fmt.Sprintf("%s %s %s", q.Sub, q.Pred, obj)
 }
 
 // NewQuery creates a new Query instance.
@@ -17,6 +17,7 @@ package util
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -29,7 +30,11 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -406,3 +411,36 @@ func KeysToSlice(m map[string]bool) []string {
 	sort.Strings(s)
 	return s
 }
+
+// Letters that are not decomposed into a base letter and marks.
+var letterTransliteration = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l",
+	'đ': "d", 'ð': "d", 'þ': "th", 'ı': "i",
+}
+
+// Transliterate lowercases a string and folds its letters to ASCII when
+// possible, like "São Tomé" to "sao tome".
+func Transliterate(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(s))
+	if err != nil {
+		folded = strings.ToLower(s)
+	}
+	var sb strings.Builder
+	for _, r := range folded {
+		if v, ok := letterTransliteration[r]; ok {
+			sb.WriteString(v)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// HashStrings gets a short hash of a list of strings, regardless of their order.
+func HashStrings(strs []string) string {
+	sorted := append([]string{}, strs...)
+	sort.Strings(sorted)
+	h := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return base64.RawURLEncoding.EncodeToString(h[:8])
+}
@@ -213,3 +213,19 @@ func TestKeysToSlice(t *testing.T) {
 		t.Errorf("places.keysToSlice(%v) = %v; expected %v", m, result, expected)
 	}
 }
+
+func TestTransliterate(t *testing.T) {
+	for _, c := range []struct {
+		in, want string
+	}{
+		{"São Tomé", "sao tome"},
+		{"Düsseldorf", "dusseldorf"},
+		{"Łódź", "lodz"},
+		{"Straße", "strasse"},
+		{"Ærø", "aero"},
+	} {
+		if got := Transliterate(c.in); got != c.want {
+			t.Errorf("Transliterate(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}